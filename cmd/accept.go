@@ -5,13 +5,14 @@ import (
 	"fmt"
 	"os"
 	"strconv"
-	"strings"
 
+	"github.com/beekhof/jira-tool/pkg/batch"
 	"github.com/beekhof/jira-tool/pkg/config"
 	"github.com/beekhof/jira-tool/pkg/editor"
 	"github.com/beekhof/jira-tool/pkg/gemini"
 	"github.com/beekhof/jira-tool/pkg/jira"
 	"github.com/beekhof/jira-tool/pkg/parser"
+	"github.com/beekhof/jira-tool/pkg/prompt"
 	"github.com/beekhof/jira-tool/pkg/qa"
 
 	"github.com/spf13/cobra"
@@ -22,12 +23,23 @@ const (
 	editCommand        = "edit"
 )
 
+var (
+	acceptResumeFlag  bool
+	acceptSprintFlag  string
+	acceptReleaseFlag string
+)
+
 var acceptCmd = &cobra.Command{
 	Use:   "accept [TICKET_ID]",
 	Short: "Convert a research ticket into an Epic and tasks",
 	Long: `Accept a completed research ticket and convert it into a new Epic
-with decomposed sub-tasks. The ticket will be transitioned to "Done" status.`,
-	Args: cobra.ExactArgs(1),
+with decomposed sub-tasks. The ticket is transitioned to "Done" once the Epic
+and all its tasks exist. If creating them fails partway, you can resume from the
+failed task or roll back the tickets created so far.
+
+If the Q&A for the Epic plan is interrupted, run the command again with --resume
+to continue it or to generate the plan from the saved answers.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runAccept,
 }
 
@@ -39,33 +51,44 @@ func runAccept(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	ticketID := normalizeTicketID(args[0], cfg.DefaultProject)
-	client, err := jira.NewClient(configDir, GetNoCache())
+	ticket, err := ticketArg(args)
 	if err != nil {
 		return err
 	}
-
-	if err := transitionToDone(client, ticketID); err != nil {
+	ticketID := normalizeTicketID(ticket, cfg.DefaultProject)
+	client, err := newJiraClient(configDir)
+	if err != nil {
 		return err
 	}
 
-	sources, err := gatherResearchSources(client, ticketID)
+	// Resolve --sprint and --release first, so a typo fails before anything is created
+	targets, err := resolveTargets(client, cfg, cfg.DefaultProject,
+		ticketTargets{Sprint: acceptSprintFlag, Release: acceptReleaseFlag})
 	if err != nil {
 		return err
 	}
 
 	reader := bufio.NewReader(os.Stdin)
-	selectedSource, err := selectResearchSource(reader, sources)
+	batchStore := newBatchStore(configDir)
+	runner := batch.NewRunner(client, batchStore)
+	earlier, err := checkUnfinishedBatch(runner, batchStore, reader, batch.NameFor(acceptSessionCommand, ticketID))
 	if err != nil {
 		return err
 	}
+	if earlier != nil {
+		if !earlier.Finished {
+			return nil // Rolled back
+		}
+		return promptAssignments(client, reader, cfg, targets, earlier.CreatedKeys(), configDir)
+	}
 
-	epicSummary, err := promptEpicSummary(reader)
+	store := qa.NewSessionStore(qa.GetSessionsDir(configDir))
+	session, generateNow, err := acceptSession(client, reader, store, ticketID)
 	if err != nil {
 		return err
 	}
 
-	plan, err := generateEpicPlan(client, cfg, ticketID, epicSummary, selectedSource, configDir)
+	plan, err := generateEpicPlan(cfg, store, session, generateNow, configDir)
 	if err != nil {
 		return err
 	}
@@ -75,6 +98,7 @@ func runAccept(_ *cobra.Command, args []string) error {
 		return err
 	}
 	if plan == "" {
+		finishSession(store, session)
 		return nil // User canceled
 	}
 
@@ -83,41 +107,34 @@ func runAccept(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to parse epic plan: %w", err)
 	}
 
-	issueKeys, err := createEpicAndTasks(client, cfg, epic, tasks)
+	issueKeys, err := createEpicAndTasks(client, batchStore, reader, cfg, ticketID, epic, tasks)
 	if err != nil {
 		return err
 	}
+	finishSession(store, session)
 
-	if err := promptSprintAssignment(client, reader, issueKeys, configDir); err != nil {
-		return err
-	}
-
-	if err := promptReleaseAssignment(client, reader, cfg.DefaultProject, issueKeys, configDir); err != nil {
-		return err
-	}
-
-	return nil
+	return promptAssignments(client, reader, cfg, targets, issueKeys, configDir)
 }
 
-func transitionToDone(client jira.JiraClient, ticketID string) error {
-	transitions, err := client.GetTransitions(ticketID)
-	if err != nil {
+// promptAssignments adds the new Epic and tasks to the sprint and release given with flags,
+// or offers to add them to one
+func promptAssignments(
+	client jira.JiraClient, reader *bufio.Reader, cfg *config.Config, targets *resolvedTargets,
+	issueKeys []string, configDir string,
+) error {
+	if err := targets.apply(client, issueKeys); err != nil {
 		return err
 	}
 
-	var doneTransitionID string
-	for _, t := range transitions {
-		if strings.EqualFold(t.To.Name, "Done") || strings.EqualFold(t.To.Name, "Closed") {
-			doneTransitionID = t.ID
-			break
+	if acceptSprintFlag == "" {
+		if err := promptSprintAssignment(client, reader, issueKeys, configDir); err != nil {
+			return err
 		}
 	}
-
-	if doneTransitionID == "" {
-		return fmt.Errorf("could not find 'Done' transition for ticket %s", ticketID)
+	if acceptReleaseFlag == "" {
+		return promptReleaseAssignment(client, reader, cfg.DefaultProject, issueKeys, configDir)
 	}
-
-	return client.TransitionTicket(ticketID, doneTransitionID)
+	return nil
 }
 
 type researchSource struct {
@@ -168,13 +185,10 @@ func selectResearchSource(reader *bufio.Reader, sources []researchSource) (resea
 	for i, source := range sources {
 		fmt.Printf("[%d] %s: %s\n", i+1, source.Type, source.Name)
 	}
-	fmt.Print("> ")
-
-	choice, err := reader.ReadString('\n')
+	choice, err := prompt.Line(reader, "research_source", "> ")
 	if err != nil {
 		return researchSource{}, err
 	}
-	choice = strings.TrimSpace(choice)
 	selected, err := strconv.Atoi(choice)
 	if err != nil {
 		return researchSource{}, fmt.Errorf("invalid selection: %s", choice)
@@ -188,25 +202,43 @@ func selectResearchSource(reader *bufio.Reader, sources []researchSource) (resea
 }
 
 func promptEpicSummary(reader *bufio.Reader) (string, error) {
-	fmt.Print("New Epic Summary: ")
-	epicSummary, err := reader.ReadString('\n')
+	return prompt.Line(reader, "epic_summary", "New Epic Summary: ")
+}
+
+// acceptSession returns the saved Epic plan session when --resume is given
+// Otherwise it asks for the research source and Epic summary, and starts a new session
+// (the research ticket is closed once the batch creating the Epic completes)
+func acceptSession(
+	client jira.JiraClient, reader *bufio.Reader, store *qa.SessionStore, ticketID string,
+) (*qa.Session, bool, error) {
+	if acceptResumeFlag {
+		session, generateNow, err := loadResumeSession(reader, store, acceptSessionCommand, ticketID)
+		if err != nil {
+			return nil, false, err
+		}
+		if session == nil {
+			return nil, false, fmt.Errorf("no unfinished accept session for %s", ticketID)
+		}
+		return session, generateNow, nil
+	}
+
+	sources, err := gatherResearchSources(client, ticketID)
 	if err != nil {
-		return "", err
+		return nil, false, err
 	}
-	return strings.TrimSpace(epicSummary), nil
-}
 
-func generateEpicPlan(
-	client jira.JiraClient, cfg *config.Config, ticketID, epicSummary string,
-	selectedSource researchSource, configDir string,
-) (string, error) {
-	context := fmt.Sprintf("Epic Summary: %s\n\nResearch Text:\n%s", epicSummary, selectedSource.Text)
+	selectedSource, err := selectResearchSource(reader, sources)
+	if err != nil {
+		return nil, false, err
+	}
 
-	geminiClient, err := gemini.NewClient(configDir)
+	epicSummary, err := promptEpicSummary(reader)
 	if err != nil {
-		return "", err
+		return nil, false, err
 	}
 
+	context := fmt.Sprintf("Epic Summary: %s\n\nResearch Text:\n%s", epicSummary, selectedSource.Text)
+
 	issues, err := client.SearchTickets(fmt.Sprintf("key = %s", ticketID))
 	var ticketSummary string
 	if err == nil && len(issues) > 0 {
@@ -218,14 +250,21 @@ func generateEpicPlan(
 		spikeIdentifier = ticketSummary
 	}
 
-	answerInputMethod := cfg.AnswerInputMethod
-	if answerInputMethod == "" {
-		answerInputMethod = defaultInputMethod
+	// Child tickets of the research ticket are not relevant to the plan, so no Jira client is passed
+	session := qa.NewSession(acceptSessionCommand, context, spikeIdentifier, "Epic", "", nil, ticketID, "")
+	return session, false, nil
+}
+
+func generateEpicPlan(
+	cfg *config.Config, store *qa.SessionStore, session *qa.Session, generateNow bool, configDir string,
+) (string, error) {
+	geminiClient, err := gemini.NewClient(configDir, GetNoCache())
+	if err != nil {
+		return "", err
 	}
+	geminiClient.SetTicketKey(session.TicketKey)
 
-	return qa.RunQnAFlow(
-		geminiClient, context, cfg.MaxQuestions, spikeIdentifier, "Epic", "",
-		nil, "", "", answerInputMethod)
+	return runQnASession(geminiClient, cfg, store, session, generateNow)
 }
 
 func confirmAndEditPlan(reader *bufio.Reader, plan string) (string, error) {
@@ -233,13 +272,10 @@ func confirmAndEditPlan(reader *bufio.Reader, plan string) (string, error) {
 	fmt.Println("---")
 	fmt.Println(plan)
 	fmt.Println("---")
-	fmt.Print("\nCreate this Epic and all sub-tasks? [Y/n/e(dit)] ")
-
-	confirm, err := reader.ReadString('\n')
+	confirm, err := prompt.Choose(reader, "create_epic", "\nCreate this Epic and all sub-tasks? [Y/n/e(dit)] ")
 	if err != nil {
 		return "", err
 	}
-	confirm = strings.TrimSpace(strings.ToLower(confirm))
 
 	if confirm == "e" || confirm == editCommand {
 		editedPlan, err := editor.OpenInEditor(plan)
@@ -257,51 +293,36 @@ func confirmAndEditPlan(reader *bufio.Reader, plan string) (string, error) {
 	return plan, nil
 }
 
+// createEpicAndTasks creates the Epic and its tasks as one batch, then transitions the
+// research ticket to Done
 func createEpicAndTasks(
-	client jira.JiraClient, cfg *config.Config,
-	epic parser.Epic, tasks []parser.Task,
+	client jira.JiraClient, store *batch.Store, reader *bufio.Reader, cfg *config.Config,
+	ticketID string, epic parser.Epic, tasks []parser.Task,
 ) ([]string, error) {
 	project := cfg.DefaultProject
 	if project == "" {
 		return nil, fmt.Errorf("default_project not configured. Please run 'jira init'")
 	}
 
-	epicKey, err := client.CreateTicket(project, "Epic", epic.Title)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create epic: %w", err)
-	}
-
-	if epic.Description != "" {
-		if err := client.UpdateTicketDescription(epicKey, epic.Description); err != nil {
-			return nil, fmt.Errorf("failed to update epic description: %w", err)
-		}
+	items := []batch.Item{{Type: "Epic", Summary: epic.Title, Description: epic.Description}}
+	for _, task := range tasks {
+		items = append(items, batch.Item{Type: "Task", Summary: task.Summary, ParentItem: 1})
 	}
 
-	fmt.Printf("Created Epic: %s\n", epicKey)
-
-	issueKeys := []string{epicKey}
-	for _, task := range tasks {
-		taskKey, err := client.CreateTicketWithParent(project, "Task", task.Summary, epicKey)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create task: %w", err)
-		}
-		issueKeys = append(issueKeys, taskKey)
-		fmt.Printf("Created Task: %s\n", taskKey)
+	b := batch.New(acceptSessionCommand, ticketID, project, items)
+	b.CloseSource = true
+	if err := runBatch(batch.NewRunner(client, store), store, reader, b); err != nil {
+		return nil, err
 	}
 
-	return issueKeys, nil
+	return b.CreatedKeys(), nil
 }
 
 func promptSprintAssignment(client jira.JiraClient, reader *bufio.Reader, issueKeys []string, configDir string) error {
-	fmt.Print("\nAdd this Epic and its tasks to an active Sprint? [y/N] ")
-	choice, err := reader.ReadString('\n')
-	if err != nil {
+	add, err := prompt.Offer(reader, "add_to_sprint", "\nAdd this Epic and its tasks to an active Sprint? [y/N] ")
+	if err != nil || !add {
 		return err
 	}
-	choice = strings.TrimSpace(strings.ToLower(choice))
-	if choice != "y" && choice != "yes" {
-		return nil
-	}
 
 	boardID := 1
 	sprints, err := client.GetActiveSprints(boardID)
@@ -350,13 +371,10 @@ func selectSprint(
 			fmt.Printf("[%d] %s\n", i+1, sprint.Name)
 		}
 	}
-	fmt.Print("> ")
-
-	choice, err := reader.ReadString('\n')
+	choice, err := prompt.Line(reader, "sprint", "> ")
 	if err != nil {
 		return 0, "", err
 	}
-	choice = strings.TrimSpace(choice)
 	selected, err := strconv.Atoi(choice)
 	if err != nil {
 		return 0, "", fmt.Errorf("invalid selection: %s", choice)
@@ -387,15 +405,10 @@ func promptReleaseAssignment(
 	client jira.JiraClient, reader *bufio.Reader, project string,
 	issueKeys []string, configDir string,
 ) error {
-	fmt.Print("\nAdd this Epic and its tasks to a Release/Fix Version? [y/N] ")
-	choice, err := reader.ReadString('\n')
-	if err != nil {
+	add, err := prompt.Offer(reader, "add_to_release", "\nAdd this Epic and its tasks to a Release/Fix Version? [y/N] ")
+	if err != nil || !add {
 		return err
 	}
-	choice = strings.TrimSpace(strings.ToLower(choice))
-	if choice != "y" && choice != "yes" {
-		return nil
-	}
 
 	releases, err := client.GetReleases(project)
 	if err != nil {
@@ -454,13 +467,10 @@ func selectRelease(
 			fmt.Printf("[%d] %s\n", i+1, release.Name)
 		}
 	}
-	fmt.Print("> ")
-
-	choice, err := reader.ReadString('\n')
+	choice, err := prompt.Line(reader, "release", "> ")
 	if err != nil {
 		return "", "", err
 	}
-	choice = strings.TrimSpace(choice)
 	selected, err := strconv.Atoi(choice)
 	if err != nil {
 		return "", "", fmt.Errorf("invalid selection: %s", choice)
@@ -488,5 +498,11 @@ func selectRelease(
 }
 
 func init() {
+	acceptCmd.Flags().BoolVar(&acceptResumeFlag, "resume", false,
+		"Resume the unfinished Epic plan Q&A for this ticket")
+	acceptCmd.Flags().StringVar(&acceptSprintFlag, "sprint", "",
+		"Add the Epic and tasks to this sprint (name, ID, \"active\" or \"next\") instead of asking")
+	acceptCmd.Flags().StringVar(&acceptReleaseFlag, "release", "",
+		"Add the Epic and tasks to this unreleased version (name or ID) instead of asking")
 	rootCmd.AddCommand(acceptCmd)
 }
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/plan"
+	"github.com/beekhof/jira-tool/pkg/prompt"
+	"github.com/beekhof/jira-tool/pkg/triage"
+
+	"github.com/spf13/cobra"
+)
+
+var applyCmd = &cobra.Command{
+	Use:   "apply FILE",
+	Short: "Create and update a backlog of epics, stories and tasks from a plan file",
+	Long: `Apply a plan file that declares a hierarchy of epics, stories and tasks with their
+descriptions, points, components, assignees and links. Tickets without a key are created,
+and the fields and links of the others are updated where they differ from the plan.
+The keys of created tickets are written back into the file, so applying it again only
+makes the changes since.
+
+The changes are shown first, like a Terraform plan, and made once confirmed. With
+--dry-run, nothing is changed and the file is left as it is.
+
+FILE is YAML, or Markdown if it ends in .md; see the README for both formats.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runApply,
+}
+
+func runApply(_ *cobra.Command, args []string) error {
+	configDir := GetConfigDir()
+	cfg, err := config.LoadConfig(config.GetConfigPath(configDir))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	p, err := plan.Load(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := newJiraClient(configDir)
+	if err != nil {
+		return err
+	}
+
+	runner := plan.NewRunner(client, triage.NewApplier(client, cfg))
+	runner.Project = cfg.DefaultProject
+	runner.EpicLinkField = cfg.EpicLinkFieldID
+
+	changes, err := runner.Diff(p)
+	if err != nil {
+		return err
+	}
+	plan.Print(os.Stdout, p, changes)
+	if create, update, _ := plan.Summary(changes); create+update == 0 {
+		return nil
+	}
+
+	// Keys made up by a dry run must never be written to the file
+	saved := func() error { return p.Save(args[0]) }
+	if dryRunFlag {
+		saved = func() error { return nil }
+	} else {
+		ok, err := prompt.Confirm(bufio.NewReader(os.Stdin), "apply_plan", "\nApply these changes? [y/N] ", false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Nothing was changed.")
+			return nil
+		}
+	}
+
+	if err := runner.Apply(p, changes, saved); err != nil {
+		if dryRunFlag {
+			return err
+		}
+		return fmt.Errorf("%w (the keys of the tickets created so far are saved; apply again to continue)", err)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+}
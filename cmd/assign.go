@@ -19,14 +19,15 @@ var (
 )
 
 var assignCmd = &cobra.Command{
-	Use:   "assign TICKET_ID",
+	Use:   "assign [TICKET_ID]",
 	Short: "Assign or unassign a ticket",
 	Long: `Assign or unassign a Jira ticket.
 The ticket ID should be in the format PROJECT-NUMBER (e.g., ENG-123).
 If no project prefix is provided, the default project will be used.
+Without a ticket ID, the one named by the current git branch is used.
 
 Use --unassign flag to unassign the ticket instead of assigning it.`,
-	Args: cobra.ExactArgs(1),
+	Args: cobra.MaximumNArgs(1),
 	RunE: runAssign,
 }
 
@@ -35,7 +36,7 @@ func runAssign(_ *cobra.Command, args []string) error {
 	configDir := GetConfigDir()
 
 	// Create Jira client
-	client, err := jira.NewClient(configDir, GetNoCache())
+	client, err := newJiraClient(configDir)
 	if err != nil {
 		return err
 	}
@@ -48,7 +49,11 @@ func runAssign(_ *cobra.Command, args []string) error {
 	}
 
 	// Normalize ticket ID (add default project if needed)
-	ticketID := normalizeTicketID(args[0], cfg.DefaultProject)
+	ticket, err := ticketArg(args)
+	if err != nil {
+		return err
+	}
+	ticketID := normalizeTicketID(ticket, cfg.DefaultProject)
 
 	// Assign or unassign the ticket
 	if unassignFlag {
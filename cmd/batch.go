@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/beekhof/jira-tool/pkg/batch"
+	"github.com/beekhof/jira-tool/pkg/prompt"
+
+	"github.com/spf13/cobra"
+)
+
+// newBatchStore returns the store for unfinished batches, or nil in a dry run
+// so that made-up keys are never saved for a later resume
+func newBatchStore(configDir string) *batch.Store {
+	if dryRunFlag {
+		return nil
+	}
+	return batch.NewStore(batch.GetBatchesDir(configDir))
+}
+
+// runBatch creates the tickets of a batch. If it fails, the user can resume from the failed item,
+// roll back the tickets created so far, or leave the batch saved for later.
+func runBatch(runner *batch.Runner, store *batch.Store, reader *bufio.Reader, b *batch.Batch) error {
+	for {
+		err := runner.Run(b)
+		if err == nil {
+			return nil
+		}
+		if store == nil {
+			return err
+		}
+
+		fmt.Printf("\nError: %v\n", err)
+		fmt.Printf("%d of %d ticket(s) created so far.\n", len(b.CreatedKeys()), len(b.Items))
+		choice, readErr := prompt.Line(reader, "batch_failed",
+			"[r]esume from the failed item, roll [b]ack the created tickets, or [l]eave it for later? ")
+		if readErr != nil {
+			printBatchHint(b)
+			return err
+		}
+
+		switch strings.ToLower(choice) {
+		case "r", "resume":
+			continue
+		case "b", "rollback":
+			if rollbackErr := runner.Rollback(b); rollbackErr != nil {
+				return rollbackErr
+			}
+			return fmt.Errorf("rolled back after: %w", err)
+		default:
+			printBatchHint(b)
+			return err
+		}
+	}
+}
+
+// checkUnfinishedBatch offers to resume or roll back a batch left unfinished by an earlier run
+// of the same command on the same ticket. It returns that batch if the user resumed or rolled it
+// back, in which case the command has no new plan to make; a resumed batch is Finished.
+func checkUnfinishedBatch(
+	runner *batch.Runner, store *batch.Store, reader *bufio.Reader, name string,
+) (*batch.Batch, error) {
+	if store == nil {
+		return nil, nil
+	}
+	b, err := store.Load(name)
+	if err != nil || b == nil {
+		return nil, err
+	}
+
+	fmt.Printf("An earlier run left %d of %d ticket(s) created", len(b.CreatedKeys()), len(b.Items))
+	if b.Error != "" {
+		fmt.Printf(" (%s)", b.Error)
+	}
+	fmt.Println(".")
+	choice, err := prompt.Line(reader, "unfinished_batch",
+		"[r]esume it, roll [b]ack its tickets, or [c]ontinue with a new plan? ")
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(choice) {
+	case "r", "resume":
+		return b, runBatch(runner, store, reader, b)
+	case "b", "rollback":
+		return b, runner.Rollback(b)
+	default:
+		return nil, nil
+	}
+}
+
+func printBatchHint(b *batch.Batch) {
+	fmt.Printf("Batch saved. Run 'jira utils batches resume %s' or 'jira utils batches rollback %s' later.\n",
+		b.Name, b.Name)
+}
+
+var batchesCmd = &cobra.Command{
+	Use:   "batches",
+	Short: "List unfinished ticket batches",
+	Long: `List batches of tickets (from 'jira decompose' or 'jira accept') that failed partway.
+An unfinished batch can be resumed from the failed item or rolled back.`,
+	Args: cobra.NoArgs,
+	RunE: runBatchesList,
+}
+
+var batchesResumeCmd = &cobra.Command{
+	Use:   "resume NAME",
+	Short: "Create the remaining tickets of an unfinished batch",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runBatchesResume,
+}
+
+var batchesRollbackCmd = &cobra.Command{
+	Use:   "rollback NAME",
+	Short: "Delete (or close) the tickets an unfinished batch created",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runBatchesRollback,
+}
+
+func runBatchesList(_ *cobra.Command, _ []string) error {
+	batches, err := batch.NewStore(batch.GetBatchesDir(GetConfigDir())).List()
+	if err != nil {
+		return err
+	}
+
+	if len(batches) == 0 {
+		fmt.Println("No unfinished batches.")
+		return nil
+	}
+
+	for _, b := range batches {
+		fmt.Printf("%-24s  %d of %d ticket(s) created  updated %s\n",
+			b.Name, len(b.CreatedKeys()), len(b.Items), b.UpdatedAt.Local().Format("2006-01-02 15:04"))
+		if b.Error != "" {
+			fmt.Printf("    %s\n", b.Error)
+		}
+	}
+
+	return nil
+}
+
+// loadBatch loads a batch for the batches subcommands, with a runner for it
+func loadBatch(name string) (*batch.Batch, *batch.Runner, *batch.Store, error) {
+	configDir := GetConfigDir()
+	store := batch.NewStore(batch.GetBatchesDir(configDir))
+	b, err := store.Load(name)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if b == nil {
+		return nil, nil, nil, fmt.Errorf("batch %s not found", name)
+	}
+
+	client, err := newJiraClient(configDir)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	runStore := newBatchStore(configDir)
+	return b, batch.NewRunner(client, runStore), runStore, nil
+}
+
+func runBatchesResume(_ *cobra.Command, args []string) error {
+	b, runner, store, err := loadBatch(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := runBatch(runner, store, bufio.NewReader(os.Stdin), b); err != nil {
+		return err
+	}
+	fmt.Printf("Batch %s complete: %s\n", b.Name, strings.Join(b.CreatedKeys(), ", "))
+	return nil
+}
+
+func runBatchesRollback(_ *cobra.Command, args []string) error {
+	b, runner, _, err := loadBatch(args[0])
+	if err != nil {
+		return err
+	}
+
+	// Rolling back deletes the tickets, so say which and ask first (a dry run only plans it)
+	if keys := b.CreatedKeys(); len(keys) > 0 && !dryRunFlag {
+		fmt.Printf("Rolling back batch %s deletes: %s\n", b.Name, strings.Join(keys, ", "))
+		question := fmt.Sprintf("Delete %d ticket(s)? [y/N] ", len(keys))
+		ok, err := prompt.Confirm(bufio.NewReader(os.Stdin), "rollback_batch", question, false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Nothing was deleted.")
+			return nil
+		}
+	}
+
+	if err := runner.Rollback(b); err != nil {
+		return err
+	}
+	fmt.Printf("Rolled back batch %s.\n", b.Name)
+	return nil
+}
+
+func init() {
+	batchesCmd.AddCommand(batchesResumeCmd, batchesRollbackCmd)
+	utilsCmd.AddCommand(batchesCmd)
+}
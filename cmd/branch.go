@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/git"
+	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/review"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// defaultBranchPattern names branches when branch_pattern isn't configured
+const defaultBranchPattern = "{type}/{key}-{slug}"
+
+var (
+	branchBaseFlag  string
+	branchStartFlag bool
+	branchPrintFlag bool
+)
+
+var branchCmd = &cobra.Command{
+	Use:   "branch TICKET_ID",
+	Short: "Create and check out a git branch for a ticket",
+	Long: `Create a git branch named after a ticket and check it out, or check it out
+if it already exists. The name comes from branch_pattern in the config
+(default: {type}/{key}-{slug}), e.g. bug/ENG-123-crash-on-start:
+
+  {type}     the issue type in lower case, or its entry in branch_types
+  {key}      the ticket key
+  {project}  the project key
+  {slug}     the summary in lower case, with words joined by dashes
+
+Commands that take a ticket ID (describe, estimate, review, decompose, assign and
+accept) use the ticket named by the current branch when none is given.
+
+With --dry-run, neither git nor the ticket is changed: the branch that would be
+created or checked out is printed instead.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBranch,
+}
+
+func runBranch(_ *cobra.Command, args []string) error {
+	configDir := GetConfigDir()
+	cfg, err := config.LoadConfig(config.GetConfigPath(configDir))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	client, err := newJiraClient(configDir)
+	if err != nil {
+		return err
+	}
+
+	ticketID := normalizeTicketID(strings.ToUpper(args[0]), cfg.DefaultProject)
+	issue, err := client.GetIssue(ticketID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch ticket: %w", err)
+	}
+
+	name := ticketBranchName(cfg, issue)
+	if branchPrintFlag {
+		fmt.Println(name)
+		return nil
+	}
+
+	repo := &git.Repo{}
+	switch {
+	case dryRunFlag && repo.BranchExists(name):
+		fmt.Printf("Would switch to existing branch %s\n", name)
+	case dryRunFlag:
+		fmt.Printf("Would create and switch to branch %s\n", name)
+	case repo.BranchExists(name):
+		if err := repo.Checkout(name); err != nil {
+			return err
+		}
+		fmt.Printf("Switched to existing branch %s\n", name)
+	default:
+		if err := repo.CreateBranch(name, branchBaseFlag); err != nil {
+			return err
+		}
+		fmt.Printf("Created and switched to branch %s\n", name)
+	}
+
+	if branchStartFlag {
+		switch {
+		case strings.EqualFold(issue.Fields.Status.Name, "In Progress"):
+			fmt.Printf("%s is already In Progress\n", issue.Key)
+		case review.TransitionToInProgress(client, issue.Key):
+			fmt.Printf("Moved %s to In Progress\n", issue.Key)
+		default:
+			fmt.Printf("Warning: %s can't be moved to In Progress from %s\n", issue.Key, issue.Fields.Status.Name)
+		}
+	}
+	return nil
+}
+
+// ticketBranchName names the branch for a ticket from the configured pattern
+func ticketBranchName(cfg *config.Config, issue *jira.Issue) string {
+	pattern := cfg.BranchPattern
+	if pattern == "" {
+		pattern = defaultBranchPattern
+	}
+	branchType := issue.Fields.IssueType.Name
+	if mapped, ok := cfg.BranchTypes[branchType]; ok {
+		branchType = mapped
+	}
+	return git.BranchName(pattern, branchType, jira.ProjectKey(issue.Key), issue.Key, issue.Fields.Summary)
+}
+
+// branchTicket returns the ticket named by the current git branch, or "" outside a repository,
+// on a detached HEAD, on a branch without a ticket key, or with --no-branch
+func branchTicket() string {
+	if noBranchFlag {
+		return ""
+	}
+	branch, err := (&git.Repo{}).CurrentBranch()
+	if err != nil {
+		return ""
+	}
+	return jira.FindTicketKey(branch)
+}
+
+// ticketArg returns the ticket given as the first argument, or else the one named by the current
+// git branch, for commands whose ticket argument is optional for that reason
+func ticketArg(args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	key := branchTicket()
+	if key == "" {
+		return "", errors.New("no ticket given, and the current git branch doesn't name one")
+	}
+	fmt.Printf("Using %s from the current git branch\n", key)
+	return key, nil
+}
+
+// queueTicketArgs returns args for commands that work through a queue of tickets when none
+// is given (review, estimate): with no arguments and none of the command's own flags,
+// the ticket named by the current git branch is used instead
+func queueTicketArgs(cmd *cobra.Command, args []string) []string {
+	if len(args) > 0 {
+		return args
+	}
+
+	flagSet := false
+	cmd.LocalNonPersistentFlags().VisitAll(func(flag *pflag.Flag) {
+		flagSet = flagSet || flag.Changed
+	})
+	if flagSet {
+		return args
+	}
+
+	if key := branchTicket(); key != "" {
+		fmt.Printf("Using %s from the current git branch (--no-branch for the queue)\n", key)
+		return []string{key}
+	}
+	return args
+}
+
+func init() {
+	branchCmd.Flags().StringVar(&branchBaseFlag, "base", "", "Create the branch from this ref instead of HEAD")
+	branchCmd.Flags().BoolVar(&branchStartFlag, "start", false, "Also move the ticket to In Progress")
+	branchCmd.Flags().BoolVar(&branchPrintFlag, "print", false, "Print the branch name without creating it")
+	rootCmd.AddCommand(branchCmd)
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/jira"
+
+	"github.com/spf13/cobra"
+)
+
+// inTestRepo runs the test from a new git repository with branch checked out
+func inTestRepo(t *testing.T, branch string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q", "-b", branch},
+		{"-c", "user.email=test@example.com", "-c", "user.name=Test", "commit", "-q", "--allow-empty", "-m", "Initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+	}
+
+	previous, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(previous) })
+}
+
+func TestTicketBranchName(t *testing.T) {
+	issue := &jira.Issue{Key: "ENG-12"}
+	issue.Fields.Summary = "Crash on start"
+	issue.Fields.IssueType.Name = "Bug"
+
+	if got := ticketBranchName(&config.Config{}, issue); got != "bug/ENG-12-crash-on-start" {
+		t.Errorf("Expected the default pattern, got %q", got)
+	}
+
+	cfg := &config.Config{BranchPattern: "{type}/{key}", BranchTypes: map[string]string{"Bug": "fix"}}
+	if got := ticketBranchName(cfg, issue); got != "fix/ENG-12" {
+		t.Errorf("Expected the mapped type, got %q", got)
+	}
+}
+
+func TestTicketArgFromBranch(t *testing.T) {
+	inTestRepo(t, "bug/ENG-12-crash-on-start")
+
+	if key, err := ticketArg([]string{"OPS-1"}); err != nil || key != "OPS-1" {
+		t.Errorf("Expected the argument, got %q (err %v)", key, err)
+	}
+	if key, err := ticketArg(nil); err != nil || key != "ENG-12" {
+		t.Errorf("Expected ENG-12 from the branch, got %q (err %v)", key, err)
+	}
+
+	noBranchFlag = true
+	defer func() { noBranchFlag = false }()
+	if _, err := ticketArg(nil); err == nil {
+		t.Error("Expected an error with --no-branch")
+	}
+}
+
+func TestTicketArgWithoutKey(t *testing.T) {
+	inTestRepo(t, "main")
+
+	if _, err := ticketArg(nil); err == nil {
+		t.Error("Expected an error on a branch without a ticket")
+	}
+}
+
+func TestQueueTicketArgs(t *testing.T) {
+	inTestRepo(t, "ENG-7")
+
+	cmd := &cobra.Command{Use: "review"}
+	cmd.Flags().Bool("unassigned", false, "")
+
+	if got := queueTicketArgs(cmd, nil); len(got) != 1 || got[0] != "ENG-7" {
+		t.Errorf("Expected ENG-7 from the branch, got %v", got)
+	}
+
+	if err := cmd.Flags().Set("unassigned", "true"); err != nil {
+		t.Fatal(err)
+	}
+	if got := queueTicketArgs(cmd, nil); len(got) != 0 {
+		t.Errorf("Expected the queue when a flag is set, got %v", got)
+	}
+}
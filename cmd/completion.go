@@ -0,0 +1,384 @@
+package cmd
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/jira"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// completionTimeout bounds the Jira request made while completing, so the shell never waits long
+const completionTimeout = 1500 * time.Millisecond
+
+// completionTicketsTTL is how long the user's open tickets are completed from the cache
+// before they are fetched again
+const completionTicketsTTL = 10 * time.Minute
+
+// completionTicketLimit is how many open tickets are kept for completion
+const completionTicketLimit = 50
+
+// defaultIssueTypes are offered for --type along with the types seen in the cache
+var defaultIssueTypes = []string{"Story", "Task", "Bug", "Epic", "Sub-task"}
+
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// flagCompletions maps flag names to their completion, wherever the flag is defined
+var flagCompletions = map[string]completionFunc{
+	"project":   completeProjects,
+	"type":      completeIssueTypes,
+	"parent":    completeParents,
+	"ticket":    completeTickets,
+	"component": completeComponents,
+	"sprint":    completeSprints,
+	"release":   completeReleases,
+	"assignee":  completeUsers,
+	"state":     completeStatuses,
+	"status":    completeStatuses,
+}
+
+// registerCompletions adds dynamic completion to every command under cmd: ticket keys for
+// commands taking a TICKET_ID, and values for the flags in flagCompletions
+func registerCompletions(cmd *cobra.Command) {
+	if cmd.ValidArgsFunction == nil && strings.Contains(cmd.Use, "TICKET_ID") {
+		cmd.ValidArgsFunction = completeTicketArg
+	}
+
+	cmd.LocalNonPersistentFlags().VisitAll(func(flag *pflag.Flag) {
+		if complete, ok := flagCompletions[flag.Name]; ok {
+			// Only fails if the flag already has a completion, which is then kept
+			_ = cmd.RegisterFlagCompletionFunc(flag.Name, complete)
+		}
+	})
+
+	for _, child := range cmd.Commands() {
+		registerCompletions(child)
+	}
+}
+
+// completionSources are what completions are drawn from: the config, the recent selections
+// in the state, the journal of changes and the cache
+type completionSources struct {
+	configDir string
+	cfg       *config.Config
+	state     *config.State
+	cache     *jira.Cache
+	journal   []jira.JournalEntry
+}
+
+func loadCompletionSources() *completionSources {
+	s := &completionSources{configDir: GetConfigDir(), cfg: &config.Config{}, state: &config.State{}}
+	if cfg, err := config.LoadConfig(config.GetConfigPath(s.configDir)); err == nil {
+		s.cfg = cfg
+	}
+	if state, err := config.LoadState(config.GetStatePath(s.configDir)); err == nil {
+		s.state = state
+	}
+	s.cache = jira.NewCache(jira.GetCachePath(s.configDir))
+	if err := s.cache.Load(); err != nil {
+		_ = err // Ignore - complete from the other sources
+	}
+	if entries, err := jira.NewJournal(jira.GetJournalPath(s.configDir)).List(); err == nil {
+		s.journal = entries
+	}
+	return s
+}
+
+// refreshTickets fetches the user's open tickets into the cache if it is stale,
+// giving up after completionTimeout
+func (s *completionSources) refreshTickets() {
+	if !GetNoCache() && time.Since(s.cache.TicketsFetchedAt) < completionTicketsTTL {
+		return
+	}
+
+	tickets, err := withTimeout(completionTimeout, func() ([]jira.CachedTicket, error) {
+		return fetchOpenTickets(s.configDir, s.cfg)
+	})
+	if err != nil {
+		return // Complete from what is cached
+	}
+	if err := s.cache.SetTickets(tickets, time.Now()); err != nil {
+		_ = err // Ignore - cache saving is optional
+	}
+}
+
+// fetchOpenTickets returns the unresolved tickets assigned to the user, most recently updated first
+func fetchOpenTickets(configDir string, cfg *config.Config) ([]jira.CachedTicket, error) {
+	client, err := jira.NewClient(configDir, true)
+	if err != nil {
+		return nil, err
+	}
+	jql := jira.ApplyTicketFilter("assignee = currentUser() AND resolution = Unresolved ORDER BY updated DESC",
+		GetTicketFilter(cfg))
+	issues, err := client.SearchTickets(jql)
+	if err != nil {
+		return nil, err
+	}
+
+	tickets := make([]jira.CachedTicket, 0, minInt(len(issues), completionTicketLimit))
+	for i := range issues {
+		if len(tickets) == completionTicketLimit {
+			break
+		}
+		tickets = append(tickets, jira.CachedTicket{
+			Key:            issues[i].Key,
+			Summary:        issues[i].Fields.Summary,
+			Type:           issues[i].Fields.IssueType.Name,
+			Status:         issues[i].Fields.Status.Name,
+			StatusCategory: issues[i].Fields.Status.StatusCategory.Key,
+		})
+	}
+	return tickets, nil
+}
+
+// errTimedOut is returned by withTimeout when fetch takes too long
+var errTimedOut = errors.New("timed out")
+
+// withTimeout runs fetch, giving up on it after timeout
+func withTimeout[T any](timeout time.Duration, fetch func() (T, error)) (T, error) {
+	type result struct {
+		value T
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		value, err := fetch()
+		done <- result{value, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-time.After(timeout):
+		var zero T
+		return zero, errTimedOut
+	}
+}
+
+// tickets returns completions for ticket keys, with their summaries where known:
+// recent parents, then tickets recently changed with this tool, then the user's open tickets
+func (s *completionSources) tickets() []string {
+	summaries := map[string]string{}
+	for _, ticket := range s.cache.Tickets {
+		summaries[ticket.Key] = ticket.Summary
+	}
+
+	keys := []string{}
+	for i := len(s.state.RecentParentTickets) - 1; i >= 0; i-- {
+		keys = append(keys, s.state.RecentParentTickets[i])
+	}
+	for i := len(s.journal) - 1; i >= 0; i-- {
+		keys = append(keys, s.journal[i].Ticket)
+	}
+	for _, ticket := range s.cache.Tickets {
+		keys = append(keys, ticket.Key)
+	}
+
+	completions := []string{}
+	for _, key := range unique(keys) {
+		completions = append(completions, withDescription(key, summaries[key]))
+	}
+	return completions
+}
+
+func completeTicketArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeTickets(cmd, args, toComplete)
+}
+
+func completeTickets(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	s := loadCompletionSources()
+	s.refreshTickets()
+	return matching(s.tickets(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeParents offers recent parents first, then open epics, then the other tickets
+func completeParents(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	s := loadCompletionSources()
+	s.refreshTickets()
+
+	rank := map[string]int{}
+	for _, ticket := range s.cache.Tickets {
+		if strings.EqualFold(ticket.Type, "Epic") {
+			rank[ticket.Key] = 1
+		}
+	}
+	for _, key := range s.state.RecentParentTickets {
+		rank[key] = 2
+	}
+
+	tickets := s.tickets()
+	sort.SliceStable(tickets, func(i, j int) bool {
+		keyI, _, _ := strings.Cut(tickets[i], "\t")
+		keyJ, _, _ := strings.Cut(tickets[j], "\t")
+		return rank[keyI] > rank[keyJ]
+	})
+	return matching(tickets, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeProjects offers the default project and the projects of known tickets and components
+func completeProjects(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return matching(loadCompletionSources().projects(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// projects returns the known project keys: the default project, then the projects of known tickets,
+// then projects with cached components
+func (s *completionSources) projects() []string {
+	projects := []string{s.cfg.DefaultProject}
+	for _, key := range s.tickets() {
+		if i := strings.Index(key, "-"); i > 0 {
+			projects = append(projects, key[:i])
+		}
+	}
+	components := []string{}
+	for project := range s.cache.Components {
+		components = append(components, project)
+	}
+	sort.Strings(components)
+	projects = append(projects, components...)
+	return unique(projects)
+}
+
+func completeIssueTypes(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	s := loadCompletionSources()
+	types := []string{s.cfg.DefaultTaskType}
+	for _, ticket := range s.cache.Tickets {
+		types = append(types, ticket.Type)
+	}
+	types = append(types, defaultIssueTypes...)
+	return matching(unique(types), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeStatuses offers the statuses of the user's tickets and of transitions made with this tool
+func completeStatuses(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	s := loadCompletionSources()
+	s.refreshTickets()
+	statuses := []string{}
+	for _, ticket := range s.cache.Tickets {
+		statuses = append(statuses, ticket.Status)
+	}
+	for i := range s.journal {
+		if s.journal[i].Operation != "TransitionTicket" {
+			continue
+		}
+		for _, values := range []map[string]interface{}{s.journal[i].Before, s.journal[i].After} {
+			if status, ok := values["status"].(string); ok {
+				statuses = append(statuses, status)
+			}
+		}
+	}
+	sort.Strings(statuses)
+	return matching(unique(statuses), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeComponents offers the cached components of --project, or of the default project
+func completeComponents(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	s := loadCompletionSources()
+	project := s.cfg.DefaultProject
+	if flag := cmd.Flags().Lookup("project"); flag != nil && flag.Value.String() != "" {
+		project = strings.ToUpper(flag.Value.String())
+	}
+
+	names := recentFirst(s.state.RecentComponents)
+	descriptions := map[string]string{}
+	for _, component := range s.cache.Components[project] {
+		names = append(names, component.Name)
+		descriptions[component.Name] = component.Description
+	}
+
+	completions := []string{}
+	for _, name := range unique(names) {
+		completions = append(completions, withDescription(name, descriptions[name]))
+	}
+	return matching(completions, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeSprints(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	s := loadCompletionSources()
+	names := append([]string{"active", "next"}, recentFirst(s.state.RecentSprints)...)
+	for _, sprint := range s.cache.Sprints {
+		names = append(names, sprint.Name)
+	}
+	return matching(unique(names), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeReleases(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	s := loadCompletionSources()
+	names := recentFirst(s.state.RecentReleases)
+	for _, release := range s.cache.Releases {
+		if !release.Released {
+			names = append(names, release.Name)
+		}
+	}
+	return matching(unique(names), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeUsers(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	s := loadCompletionSources()
+	names := recentFirst(s.state.RecentAssignees)
+	queries := make([]string, 0, len(s.cache.Users))
+	for query := range s.cache.Users {
+		queries = append(queries, query)
+	}
+	sort.Strings(queries)
+	for _, query := range queries {
+		for _, user := range s.cache.Users[query] {
+			if user.Name != "" {
+				names = append(names, user.Name)
+			} else if user.EmailAddress != "" {
+				names = append(names, user.EmailAddress)
+			}
+		}
+	}
+	return matching(unique(names), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// recentFirst returns a recent list from the state (kept oldest first) newest first
+func recentFirst(recent []string) []string {
+	reversed := make([]string, 0, len(recent))
+	for i := len(recent) - 1; i >= 0; i-- {
+		reversed = append(reversed, recent[i])
+	}
+	return reversed
+}
+
+// withDescription adds a description that shells show next to the completion
+func withDescription(value, description string) string {
+	if description == "" {
+		return value
+	}
+	return value + "\t" + description
+}
+
+// unique drops empty and repeated values, keeping the first of each
+func unique(values []string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, value := range values {
+		if value != "" && !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// matching returns the completions whose value starts with toComplete, ignoring case
+func matching(completions []string, toComplete string) []string {
+	prefix := strings.ToLower(toComplete)
+	result := []string{}
+	for _, completion := range completions {
+		value, _, _ := strings.Cut(completion, "\t")
+		if strings.HasPrefix(strings.ToLower(value), prefix) {
+			result = append(result, completion)
+		}
+	}
+	return result
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/jira"
+
+	"github.com/spf13/cobra"
+)
+
+// useCompletionConfig points the config directory at a temporary one with recent parents,
+// a journal and cached open tickets (fresh, so nothing is fetched)
+func useCompletionConfig(t *testing.T) {
+	dir := t.TempDir()
+	previous := configDir
+	configDir = dir
+	t.Cleanup(func() { configDir = previous })
+
+	state := &config.State{RecentParentTickets: []string{"ENG-1", "ENG-5"}, RecentComponents: []string{"UI"}}
+	if err := config.SaveState(state, config.GetStatePath(dir)); err != nil {
+		t.Fatalf("SaveState failed: %v", err)
+	}
+
+	journal := jira.NewJournal(jira.GetJournalPath(dir))
+	for _, entry := range []jira.JournalEntry{
+		{Operation: "TransitionTicket", Ticket: "OPS-7",
+			Before: map[string]interface{}{"status": "New"}, After: map[string]interface{}{"status": "In Progress"}},
+		{Operation: "AddComment", Ticket: "ENG-5"},
+	} {
+		entry := entry
+		if err := journal.Append(&entry); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	cache := jira.NewCache(jira.GetCachePath(dir))
+	cache.Components["ENG"] = []jira.Component{{Name: "Backend", Description: "Server side"}, {Name: "UI"}}
+	if err := cache.SetTickets([]jira.CachedTicket{
+		{Key: "ENG-9", Summary: "Crash on start", Type: "Bug", Status: "Blocked"},
+		{Key: "ENG-12", Summary: "Onboarding", Type: "Epic", Status: "Open"},
+	}, time.Now()); err != nil {
+		t.Fatalf("SetTickets failed: %v", err)
+	}
+}
+
+func TestCompleteTickets(t *testing.T) {
+	useCompletionConfig(t)
+
+	got, directive := completeTicketArg(nil, nil, "eng")
+	want := "ENG-5 ENG-1 ENG-9\tCrash on start ENG-12\tOnboarding"
+	if strings.Join(got, " ") != want || directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("Expected %q, got %q", want, strings.Join(got, " "))
+	}
+
+	if got, _ := completeTicketArg(nil, []string{"ENG-1"}, ""); len(got) != 0 {
+		t.Errorf("Expected no completions after the ticket, got %v", got)
+	}
+
+	got, _ = completeParents(nil, nil, "")
+	if len(got) != 5 || got[2] != "ENG-12\tOnboarding" {
+		t.Errorf("Expected recent parents, then the epic, got %q", got)
+	}
+}
+
+func TestCompleteFlagValues(t *testing.T) {
+	useCompletionConfig(t)
+
+	if got, _ := completeProjects(nil, nil, ""); strings.Join(got, " ") != "ENG OPS" {
+		t.Errorf("Expected ENG and OPS, got %v", got)
+	}
+	if got, _ := completeStatuses(nil, nil, ""); strings.Join(got, ",") != "Blocked,In Progress,New,Open" {
+		t.Errorf("Expected cached and journal statuses, got %v", got)
+	}
+	if got, _ := completeIssueTypes(nil, nil, "e"); strings.Join(got, ",") != "Epic" {
+		t.Errorf("Expected Epic, got %v", got)
+	}
+
+	cmd := &cobra.Command{Use: "create"}
+	cmd.Flags().String("project", "", "")
+	if err := cmd.Flags().Set("project", "eng"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := completeComponents(cmd, nil, ""); strings.Join(got, ",") != "UI,Backend\tServer side" {
+		t.Errorf("Expected the recent component first, got %q", got)
+	}
+}
+
+func TestRegisterCompletions(t *testing.T) {
+	root := &cobra.Command{Use: "jira"}
+	show := &cobra.Command{Use: "show TICKET_ID", Run: func(*cobra.Command, []string) {}}
+	create := &cobra.Command{Use: "create [SUMMARY]", Run: func(*cobra.Command, []string) {}}
+	create.Flags().String("project", "", "")
+	create.Flags().String("title", "", "")
+	root.AddCommand(show, create)
+
+	registerCompletions(root)
+
+	if show.ValidArgsFunction == nil || create.ValidArgsFunction != nil {
+		t.Error("Expected ticket completion only for the command taking a TICKET_ID")
+	}
+	if _, ok := create.GetFlagCompletionFunc("project"); !ok {
+		t.Error("Expected completion for --project")
+	}
+	if _, ok := create.GetFlagCompletionFunc("title"); ok {
+		t.Error("Expected no completion for --title")
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	start := time.Now()
+	_, err := withTimeout(20*time.Millisecond, func() (int, error) {
+		<-release
+		return 1, nil
+	})
+	if err == nil || time.Since(start) > time.Second {
+		t.Errorf("Expected a timeout, got %v after %v", err, time.Since(start))
+	}
+
+	if value, err := withTimeout(time.Second, func() (int, error) { return 2, nil }); err != nil || value != 2 {
+		t.Errorf("Expected 2, got %d (err %v)", value, err)
+	}
+}
@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -11,15 +13,25 @@ import (
 	"github.com/beekhof/jira-tool/pkg/editor"
 	"github.com/beekhof/jira-tool/pkg/gemini"
 	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/prompt"
 	"github.com/beekhof/jira-tool/pkg/qa"
+	"github.com/beekhof/jira-tool/pkg/tui"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	projectFlag string
-	typeFlag    string
-	parentFlag  string
+	projectFlag      string
+	typeFlag         string
+	parentFlag       string
+	createResumeFlag bool
+
+	createDescriptionFileFlag string
+	createNoAIFlag            bool
+	createAssigneeFlag        string
+	createSprintFlag          string
+	createReleaseFlag         string
+	createComponentFlag       []string
 )
 
 var createCmd = &cobra.Command{
@@ -32,12 +44,28 @@ You can create a spike ticket by using "spike" as the first word:
   jira-tool create spike research authentication options
 
 This is equivalent to:
-  jira-tool create "SPIKE: research authentication options"`,
-	Args: cobra.MinimumNArgs(1),
+  jira-tool create "SPIKE: research authentication options"
+
+If the description Q&A is interrupted, continue it with:
+  jira-tool create --resume [TICKET_ID]
+
+To create a ticket from a script, give everything with flags:
+  jira-tool create --parent ENG-100 --description-file desc.md --assignee jane@example.com \
+    --sprint active --component Backend "Add rate limiting"`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if createResumeFlag {
+			return cobra.MaximumNArgs(1)(cmd, args)
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
 	RunE: runCreate,
 }
 
 func runCreate(_ *cobra.Command, args []string) error {
+	if createResumeFlag {
+		return runCreateResume(args)
+	}
+
 	summary := normalizeSummary(args)
 
 	configDir := GetConfigDir()
@@ -52,7 +80,26 @@ func runCreate(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	client, err := jira.NewClient(configDir, GetNoCache())
+	// Read the description and resolve the targets first, so a missing file or a typo
+	// fails before the ticket exists
+	description := ""
+	if createDescriptionFileFlag != "" {
+		if description, err = readDescriptionFile(createDescriptionFileFlag); err != nil {
+			return err
+		}
+	}
+
+	client, err := newJiraClient(configDir)
+	if err != nil {
+		return err
+	}
+
+	targets, err := resolveTargets(client, cfg, project, ticketTargets{
+		Assignee:   createAssigneeFlag,
+		Sprint:     createSprintFlag,
+		Release:    createReleaseFlag,
+		Components: createComponentFlag,
+	})
 	if err != nil {
 		return err
 	}
@@ -73,6 +120,21 @@ func runCreate(_ *cobra.Command, args []string) error {
 
 	updateRecentParentTickets(configDir, parentKey, ticketKey)
 
+	if err := targets.apply(client, []string{ticketKey}); err != nil {
+		return err
+	}
+
+	if createDescriptionFileFlag != "" {
+		if err := client.UpdateTicketDescription(ticketKey, description); err != nil {
+			return err
+		}
+		fmt.Printf("Updated %s with description.\n", ticketKey)
+		return nil
+	}
+	if createNoAIFlag {
+		return nil
+	}
+
 	if err := handleDescriptionGeneration(client, reader, cfg, configDir, summary, taskType, ticketKey); err != nil {
 		return err
 	}
@@ -80,6 +142,21 @@ func runCreate(_ *cobra.Command, args []string) error {
 	return nil
 }
 
+// readDescriptionFile reads a description from a file ("-" for stdin)
+func readDescriptionFile(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read description: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
 func normalizeSummary(args []string) string {
 	summary := strings.Join(args, " ")
 	if len(args) > 0 && strings.EqualFold(args[0], "spike") {
@@ -119,6 +196,10 @@ func handleParentSelection(
 	if parentFlag != "" {
 		return handleParentFlag(client, parentFlag)
 	}
+	if err := prompt.Require("parent", "Parent ticket"); err != nil {
+		fmt.Println("Creating without a parent ticket: stdin is not a terminal (use --parent to set one).")
+		return "", false, nil
+	}
 
 	return handleInteractiveParentSelection(client, reader, cfg, project, configPath)
 }
@@ -201,13 +282,11 @@ func detectAndPromptEpicLinkField(
 }
 
 func promptForEpicLinkFieldID(reader *bufio.Reader, cfg *config.Config, configPath string) (string, error) {
-	fmt.Print("Epic Link field not detected. " +
+	fieldIDInput, err := prompt.Line(reader, "epic_link_field", "Epic Link field not detected. "+
 		"Please enter the custom field ID (e.g., customfield_10011) or press Enter to skip: ")
-	fieldIDInput, err := reader.ReadString('\n')
 	if err != nil {
 		return "", fmt.Errorf("failed to read input: %w", err)
 	}
-	fieldIDInput = strings.TrimSpace(fieldIDInput)
 	if fieldIDInput == "" {
 		return "", fmt.Errorf("Epic Link field ID required for Epic parent")
 	}
@@ -242,14 +321,11 @@ func handleDescriptionGeneration(
 	client jira.JiraClient, reader *bufio.Reader, cfg *config.Config,
 	configDir, summary, taskType, ticketKey string,
 ) error {
-	fmt.Print("Would you like to use Gemini to generate the description? [y/N] ")
-	response, err := reader.ReadString('\n')
+	useAI, err := prompt.Offer(reader, "use_ai", "Would you like to use Gemini to generate the description? [y/N] ")
 	if err != nil {
 		return fmt.Errorf("failed to read input: %w", err)
 	}
-	response = strings.TrimSpace(strings.ToLower(response))
-
-	if response != "y" && response != "yes" {
+	if !useAI {
 		return nil
 	}
 
@@ -260,19 +336,64 @@ func generateAndUpdateDescription(
 	client jira.JiraClient, reader *bufio.Reader, cfg *config.Config,
 	configDir, summary, taskType, ticketKey string,
 ) error {
-	geminiClient, err := gemini.NewClient(configDir)
+	session := qa.NewSession(createSessionCommand, summary, summary, taskType, "",
+		client, ticketKey, cfg.EpicLinkFieldID)
+	return runDescriptionSession(client, reader, cfg, configDir, session, false)
+}
+
+// runCreateResume continues the description Q&A for a ticket created earlier
+// If no ticket is given, the most recent unfinished create session is resumed
+func runCreateResume(args []string) error {
+	configDir := GetConfigDir()
+	cfg, err := config.LoadConfig(config.GetConfigPath(configDir))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	ticketKey := ""
+	if len(args) > 0 {
+		ticketKey = normalizeTicketID(args[0], cfg.DefaultProject)
+	}
+
+	client, err := newJiraClient(configDir)
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	store := qa.NewSessionStore(qa.GetSessionsDir(configDir))
+	session, generateNow, err := loadResumeSession(reader, store, createSessionCommand, ticketKey)
 	if err != nil {
 		return err
 	}
+	if session == nil {
+		if ticketKey != "" {
+			return fmt.Errorf("no unfinished create session for %s", ticketKey)
+		}
+		return fmt.Errorf("no unfinished create session found")
+	}
 
-	answerInputMethod := cfg.AnswerInputMethod
-	if answerInputMethod == "" {
-		answerInputMethod = defaultInputMethod
+	return runDescriptionSession(client, reader, cfg, configDir, session, generateNow)
+}
+
+// runDescriptionSession runs (or resumes) the description Q&A for a newly created
+// ticket and updates the ticket with the result
+func runDescriptionSession(
+	client jira.JiraClient, reader *bufio.Reader, cfg *config.Config,
+	configDir string, session *qa.Session, generateNow bool,
+) error {
+	ticketKey := session.TicketKey
+	geminiClient, err := gemini.NewClient(configDir, GetNoCache())
+	if err != nil {
+		return err
 	}
+	geminiClient.SetTicketKey(ticketKey)
 
-	description, err := qa.RunQnAFlow(
-		geminiClient, summary, cfg.MaxQuestions, summary, taskType, "",
-		client, ticketKey, cfg.EpicLinkFieldID, answerInputMethod)
+	store := qa.NewSessionStore(qa.GetSessionsDir(configDir))
+	description, err := runQnASession(geminiClient, cfg, store, session, generateNow)
+	if errors.Is(err, gemini.ErrBudgetExceeded) {
+		description, err = writeDescriptionManually(err, "")
+	}
 	if err != nil {
 		return err
 	}
@@ -281,13 +402,11 @@ func generateAndUpdateDescription(
 	fmt.Println("---")
 	fmt.Println(description)
 	fmt.Println("---")
-	fmt.Print("\nUpdate ticket with this description? [Y/n/e(dit)] ")
 
-	confirm, err := reader.ReadString('\n')
+	confirm, err := prompt.Choose(reader, "update_description", "\nUpdate ticket with this description? [Y/n/e(dit)] ")
 	if err != nil {
 		return fmt.Errorf("failed to read input: %w", err)
 	}
-	confirm = strings.TrimSpace(strings.ToLower(confirm))
 
 	if confirm == "e" || confirm == "edit" {
 		editedDescription, err := editor.OpenInEditor(description)
@@ -298,26 +417,25 @@ func generateAndUpdateDescription(
 	}
 
 	if confirm == "n" || confirm == "no" {
+		finishSession(store, session)
 		return nil
 	}
 
 	if err := client.UpdateTicketDescription(ticketKey, description); err != nil {
 		return err
 	}
+	finishSession(store, session)
 	fmt.Printf("Updated %s with description.\n", ticketKey)
 
 	return promptForReview(client, reader, cfg, ticketKey)
 }
 
 func promptForReview(client jira.JiraClient, reader *bufio.Reader, cfg *config.Config, ticketKey string) error {
-	fmt.Print("\nWould you like to review this ticket? [y/N] ")
-	reviewResponse, err := reader.ReadString('\n')
+	review, err := prompt.Offer(reader, "review", "\nWould you like to review this ticket? [y/N] ")
 	if err != nil {
 		return fmt.Errorf("failed to read input: %w", err)
 	}
-	reviewResponse = strings.TrimSpace(strings.ToLower(reviewResponse))
-
-	if reviewResponse != "y" && reviewResponse != "yes" {
+	if !review {
 		return nil
 	}
 
@@ -354,6 +472,10 @@ func selectParentTicket(
 
 	validRecentTickets := getValidRecentTickets(client, state.RecentParentTickets)
 
+	if tui.Pickable("parent") {
+		return pickParentTicket(client, cfg, projectKey, validRecentTickets)
+	}
+
 	showRecent := len(validRecentTickets) > 0
 	if showRecent {
 		displayRecentParentTickets(client, validRecentTickets)
@@ -375,6 +497,50 @@ func selectParentTicket(
 	return processParentTicketSelection(client, reader, cfg, projectKey, choice, validRecentTickets, showRecent)
 }
 
+// pickParentTicket chooses a parent ticket with the picker: recent parents first, then the
+// project's epics and tickets with subtasks. A ticket key that isn't listed can be typed in
+func pickParentTicket(
+	client jira.JiraClient, cfg *config.Config, projectKey string, validRecentTickets []string,
+) (string, error) {
+	items := []tui.PickItem{}
+	for _, ticketKey := range validRecentTickets {
+		item := tui.PickItem{Label: ticketKey}
+		if issue, err := client.GetIssue(ticketKey); err == nil {
+			item.Detail = fmt.Sprintf("[%s] %s", issue.Fields.IssueType.Name, issue.Fields.Summary)
+		}
+		items = append(items, item)
+	}
+
+	fmt.Println("Loading parent tickets...")
+	jql := jira.ApplyTicketFilter(fmt.Sprintf("project = %s ORDER BY updated DESC", projectKey), GetTicketFilter(cfg))
+	issues, err := client.SearchTickets(jql)
+	if err != nil {
+		return "", fmt.Errorf("failed to search tickets: %w", err)
+	}
+	validIssues := filterValidParentIssues(client, cfg, issues)
+	for i := range validIssues {
+		issue := &validIssues[i]
+		items = append(items, tui.PickItem{
+			Label:  issue.Key,
+			Detail: fmt.Sprintf("[%s] %s", issue.Fields.IssueType.Name, issue.Fields.Summary),
+		})
+	}
+
+	picker := tui.NewPicker("Select parent ticket", tui.PinRecent(items, validRecentTickets), false)
+	chosen, err := tui.Pick(picker)
+	if err != nil {
+		return "", err
+	}
+	if len(chosen) > 0 {
+		return chosen[0].Label, nil
+	}
+
+	if ticketKey := validateDirectTicketKey(client, strings.ToUpper(picker.Query())); ticketKey != "" {
+		return ticketKey, nil
+	}
+	return "", fmt.Errorf("no parent ticket matching %q", picker.Query())
+}
+
 func getValidRecentTickets(client jira.JiraClient, recentTickets []string) []string {
 	if len(recentTickets) == 0 {
 		return []string{}
@@ -460,12 +626,11 @@ func minInt(a, b int) int {
 }
 
 func readParentTicketChoice(reader *bufio.Reader) (string, error) {
-	fmt.Print("> ")
-	choice, err := reader.ReadString('\n')
+	choice, err := prompt.Line(reader, "parent", "> ")
 	if err != nil {
 		return "", fmt.Errorf("failed to read input: %w", err)
 	}
-	return strings.TrimSpace(choice), nil
+	return choice, nil
 }
 
 func validateDirectTicketKey(client jira.JiraClient, choice string) string {
@@ -520,13 +685,11 @@ func selectFromAllParentTickets(
 	}
 
 	displayParentTicketList(validIssues[:minInt(20, len(validIssues))])
-	fmt.Print("> ")
 
-	choice2, err := reader.ReadString('\n')
+	choice2, err := prompt.Line(reader, "parent_other", "> ")
 	if err != nil {
 		return "", fmt.Errorf("failed to read input: %w", err)
 	}
-	choice2 = strings.TrimSpace(choice2)
 	selected2, err := strconv.Atoi(choice2)
 	if err != nil {
 		return "", fmt.Errorf("invalid selection: %s", choice2)
@@ -562,13 +725,11 @@ func reviewTicket(client jira.JiraClient, reader *bufio.Reader, cfg *config.Conf
 		fmt.Printf("\n=== %s - %s ===\n", issue.Key, issue.Fields.Summary)
 		fmt.Printf("Priority: %s | Assignee: %s | Status: %s\n",
 			getPriorityName(issue), getAssigneeName(issue), issue.Fields.Status.Name)
-		fmt.Print("Action? [a(ssign), t(riage), e(stimate), d(one)] > ")
-
-		action, err := reader.ReadString('\n')
+		action, err := prompt.Line(reader, "review_action", "Action? [a(ssign), t(riage), e(stimate), d(one)] > ")
 		if err != nil {
 			return fmt.Errorf("failed to read input: %w", err)
 		}
-		action = strings.TrimSpace(strings.ToLower(action))
+		action = strings.ToLower(action)
 
 		switch action {
 		case "a", "assign":
@@ -616,6 +777,11 @@ func reviewTicket(client jira.JiraClient, reader *bufio.Reader, cfg *config.Conf
 		default:
 			fmt.Println("Invalid action. Use 'a' for assign, 't' for triage, 'e' for estimate, or 'd' for done.")
 		}
+
+		// Without a terminal the one action from the answers file is all there is
+		if !prompt.Interactive() {
+			return nil
+		}
 	}
 }
 
@@ -623,5 +789,16 @@ func init() {
 	createCmd.Flags().StringVarP(&projectFlag, "project", "p", "", "Project key (overrides default_project)")
 	createCmd.Flags().StringVarP(&typeFlag, "type", "t", "", "Task type (overrides default_task_type)")
 	createCmd.Flags().StringVarP(&parentFlag, "parent", "P", "", "Parent ticket key (Epic or parent ticket)")
+	createCmd.Flags().BoolVar(&createResumeFlag, "resume", false,
+		"Resume the unfinished description Q&A for a created ticket (default: the most recent)")
+	createCmd.Flags().StringVar(&createDescriptionFileFlag, "description-file", "",
+		"Set the description from a file (- for stdin) instead of generating it")
+	createCmd.Flags().BoolVar(&createNoAIFlag, "no-ai", false, "Don't offer to generate the description")
+	createCmd.Flags().StringVar(&createAssigneeFlag, "assignee", "", "Assign the ticket to this user (email or name)")
+	createCmd.Flags().StringVar(&createSprintFlag, "sprint", "",
+		"Add the ticket to this sprint (name, ID, \"active\" or \"next\")")
+	createCmd.Flags().StringVar(&createReleaseFlag, "release", "",
+		"Add the ticket to this unreleased version (name or ID)")
+	createCmd.Flags().StringSliceVar(&createComponentFlag, "component", nil, "Set the ticket's components (repeatable)")
 	rootCmd.AddCommand(createCmd)
 }
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 
-	"github.com/beekhof/jira-tool/pkg/jira"
 	"github.com/spf13/cobra"
 )
 
@@ -15,7 +14,7 @@ var debugCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	RunE: func(_ *cobra.Command, args []string) error {
 		configDir := GetConfigDir()
-		client, err := jira.NewClient(configDir, GetNoCache())
+		client, err := newJiraClient(configDir)
 		if err != nil {
 			return err
 		}
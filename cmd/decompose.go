@@ -8,25 +8,31 @@ import (
 	"strings"
 	"time"
 
+	"github.com/beekhof/jira-tool/pkg/batch"
 	"github.com/beekhof/jira-tool/pkg/config"
 	"github.com/beekhof/jira-tool/pkg/editor"
 	"github.com/beekhof/jira-tool/pkg/gemini"
 	"github.com/beekhof/jira-tool/pkg/jira"
 	"github.com/beekhof/jira-tool/pkg/parser"
+	"github.com/beekhof/jira-tool/pkg/prompt"
 
 	"github.com/spf13/cobra"
 )
 
 var maxPointsFlag int
 
+// decomposeBatchCommand names the ticket batches created by decompose
+const decomposeBatchCommand = "decompose"
+
 var decomposeCmd = &cobra.Command{
 	Use:   "decompose [TICKET_ID]",
 	Short: "Decompose a ticket into smaller child tickets",
 	Long: `Decompose an existing ticket into child tickets with story point estimates
 no larger than a specified value. Child ticket type depends on the parent ticket type.
 Any existing child tickets are considered in the plan, and you can edit the breakdown
-before tickets are created in Jira.`,
-	Args: cobra.ExactArgs(1),
+before tickets are created in Jira. If creating them fails partway, you can resume from
+the failed ticket or roll back the tickets created so far.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runDecompose,
 }
 
@@ -43,8 +49,12 @@ func runDecompose(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	ticketID := normalizeTicketID(args[0], cfg.DefaultProject)
-	client, err := jira.NewClient(configDir, GetNoCache())
+	ticket, err := ticketArg(args)
+	if err != nil {
+		return err
+	}
+	ticketID := normalizeTicketID(ticket, cfg.DefaultProject)
+	client, err := newJiraClient(configDir)
 	if err != nil {
 		return err
 	}
@@ -57,6 +67,20 @@ func runDecompose(_ *cobra.Command, args []string) error {
 
 	reader := bufio.NewReader(os.Stdin)
 
+	// Offer to finish or undo a batch an earlier run left behind
+	batchStore := newBatchStore(configDir)
+	runner := batch.NewRunner(client, batchStore)
+	earlier, err := checkUnfinishedBatch(runner, batchStore, reader, batch.NameFor(decomposeBatchCommand, ticketID))
+	if err != nil {
+		return err
+	}
+	if earlier != nil {
+		if earlier.Finished {
+			fmt.Printf("\nCreated tickets: %s\n", strings.Join(earlier.CreatedKeys(), ", "))
+		}
+		return nil
+	}
+
 	// Get story point limit
 	maxPoints, err := getMaxStoryPoints(maxPointsFlag, cfg, reader)
 	if err != nil {
@@ -85,10 +109,11 @@ func runDecompose(_ *cobra.Command, args []string) error {
 	}
 
 	// Generate plan with Gemini
-	geminiClient, err := gemini.NewClient(configDir)
+	geminiClient, err := gemini.NewClient(configDir, GetNoCache())
 	if err != nil {
 		return fmt.Errorf("failed to create Gemini client: %w", err)
 	}
+	geminiClient.SetTicketKey(ticketID)
 
 	planText, err := gemini.GenerateDecompositionPlan(
 		geminiClient, cfg,
@@ -131,25 +156,20 @@ func runDecompose(_ *cobra.Command, args []string) error {
 	}
 
 	// Final confirmation
-	if !confirmFinalCreation(reader) {
-		return nil
+	if create, err := confirmFinalCreation(reader); err != nil || !create {
+		return err
 	}
 
 	// Create tickets
 	parentIsEpic := jira.IsEpic(parentTicket)
+	oldStoryPoints := int(parentTicket.Fields.StoryPoints)
 	createdKeys, err := createChildTickets(
-		client, cfg, plan, ticketID, parentIsEpic, childType, configDir,
+		client, batchStore, reader, cfg, plan, ticketID, parentIsEpic, childType, existingChildren,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create tickets: %w", err)
 	}
 
-	// Update parent story points
-	oldStoryPoints := int(parentTicket.Fields.StoryPoints)
-	if err := updateParentStoryPoints(client, cfg, ticketID, plan, existingChildren); err != nil {
-		fmt.Printf("Warning: Failed to update parent story points: %v\n", err)
-	}
-
 	// Calculate new story points
 	newStoryPoints := calculateTotalStoryPoints(plan, existingChildren)
 
@@ -170,12 +190,10 @@ func getMaxStoryPoints(flagValue int, cfg *config.Config, reader *bufio.Reader)
 		return cfg.DefaultMaxDecomposePoints, nil
 	}
 
-	fmt.Print("Maximum story points per child ticket (default: 5): ")
-	input, err := reader.ReadString('\n')
+	input, err := prompt.Line(reader, "max_points", "Maximum story points per child ticket (default: 5): ")
 	if err != nil {
 		return 0, err
 	}
-	input = strings.TrimSpace(input)
 
 	if input == "" {
 		return 5, nil
@@ -233,18 +251,15 @@ func handleConfirmationAndEditing(
 	return plan, nil
 }
 
-func confirmFinalCreation(reader *bufio.Reader) bool {
-	fmt.Print("\nCreate these tickets? [Y/n] ")
-	finalConfirm, err := reader.ReadString('\n')
+func confirmFinalCreation(reader *bufio.Reader) (bool, error) {
+	create, err := prompt.Confirm(reader, "create_tickets", "\nCreate these tickets? [Y/n] ", true)
 	if err != nil {
-		return false
+		return false, err
 	}
-	finalConfirm = strings.TrimSpace(strings.ToLower(finalConfirm))
-	if finalConfirm == "n" || finalConfirm == "no" {
+	if !create {
 		fmt.Println("Canceled.")
-		return false
 	}
-	return true
+	return create, nil
 }
 
 func detectAndFilterDuplicates(
@@ -332,13 +347,11 @@ func confirmDecompositionPlan(
 	reader *bufio.Reader, plan *parser.DecompositionPlan,
 ) (confirmed, shouldEdit bool, err error) {
 	newCount, _, _, _ := calculatePlanSummary(plan)
-	fmt.Printf("Create these %d tickets? [Y/n/e(dit)/s(how)] ", newCount)
-
-	choice, err := reader.ReadString('\n')
+	choice, err := prompt.Choose(reader, "confirm_plan",
+		fmt.Sprintf("Create these %d tickets? [Y/n/e(dit)/s(how)] ", newCount))
 	if err != nil {
 		return false, false, err
 	}
-	choice = strings.TrimSpace(strings.ToLower(choice))
 
 	switch choice {
 	case "y", "yes", "":
@@ -427,49 +440,50 @@ func validateEditedPlan(plan *parser.DecompositionPlan, maxPoints int) error {
 	return nil
 }
 
+// createChildTickets creates the new tickets of a plan as one batch, then updates the parent's
+// story points to the total of all its children
 func createChildTickets(
-	client jira.JiraClient, cfg *config.Config, plan *parser.DecompositionPlan,
-	parentKey string, parentIsEpic bool, childType string, _ string,
+	client jira.JiraClient, store *batch.Store, reader *bufio.Reader, cfg *config.Config,
+	plan *parser.DecompositionPlan, parentKey string, parentIsEpic bool, childType string,
+	existingChildren []jira.ChildTicketInfo,
 ) ([]string, error) {
-	var createdKeys []string
 	project := cfg.DefaultProject
 
-	for i, ticket := range plan.NewTickets {
-		fmt.Printf("Creating ticket %d of %d...\n", i+1, len(plan.NewTickets))
-
-		var ticketKey string
-		var err error
-
-		if parentIsEpic {
-			epicLinkFieldID := cfg.EpicLinkFieldID
-			if epicLinkFieldID == "" {
-				// Try to detect
-				epicLinkFieldID, err = client.DetectEpicLinkField(project)
-				if err != nil || epicLinkFieldID == "" {
-					return createdKeys, fmt.Errorf("Epic Link field not configured and could not be detected")
-				}
+	epicLinkFieldID := ""
+	if parentIsEpic {
+		epicLinkFieldID = cfg.EpicLinkFieldID
+		if epicLinkFieldID == "" {
+			// Try to detect
+			detected, err := client.DetectEpicLinkField(project)
+			if err != nil || detected == "" {
+				return nil, fmt.Errorf("Epic Link field not configured and could not be detected")
 			}
-			ticketKey, err = client.CreateTicketWithEpicLink(project, childType, ticket.Summary, parentKey, epicLinkFieldID)
-		} else {
-			ticketKey, err = client.CreateTicketWithParent(project, childType, ticket.Summary, parentKey)
-		}
-
-		if err != nil {
-			fmt.Printf("Warning: Failed to create ticket \"%s\": %v\n", ticket.Summary, err)
-			continue
+			epicLinkFieldID = detected
 		}
+	}
 
-		// Set story points
-		if ticket.StoryPoints > 0 {
-			if err := client.UpdateTicketPoints(ticketKey, ticket.StoryPoints); err != nil {
-				fmt.Printf("Warning: Failed to set story points for %s: %v\n", ticketKey, err)
-			}
+	items := make([]batch.Item, len(plan.NewTickets))
+	for i, ticket := range plan.NewTickets {
+		items[i] = batch.Item{
+			Type:          childType,
+			Summary:       ticket.Summary,
+			StoryPoints:   ticket.StoryPoints,
+			Parent:        parentKey,
+			EpicLinkField: epicLinkFieldID,
 		}
+	}
 
-		createdKeys = append(createdKeys, ticketKey)
+	b := batch.New(decomposeBatchCommand, parentKey, project, items)
+	if cfg.StoryPointsFieldID == "" {
+		fmt.Println("Warning: Story points field not configured - the parent's story points won't be updated")
+	} else {
+		b.SourcePoints = calculateTotalStoryPoints(plan, existingChildren)
 	}
 
-	return createdKeys, nil
+	if err := runBatch(batch.NewRunner(client, store), store, reader, b); err != nil {
+		return nil, err
+	}
+	return b.CreatedKeys(), nil
 }
 
 func calculateTotalStoryPoints(
@@ -488,18 +502,6 @@ func calculateTotalStoryPoints(
 	return total
 }
 
-func updateParentStoryPoints(
-	client jira.JiraClient, cfg *config.Config, parentKey string,
-	plan *parser.DecompositionPlan, existingChildren []jira.ChildTicketInfo,
-) error {
-	if cfg.StoryPointsFieldID == "" {
-		return fmt.Errorf("story points field not configured")
-	}
-
-	totalPoints := calculateTotalStoryPoints(plan, existingChildren)
-	return client.UpdateTicketPoints(parentKey, totalPoints)
-}
-
 func displayCreationSummary(
 	createdKeys []string, plan *parser.DecompositionPlan,
 	parentKey string, oldStoryPoints, newStoryPoints int,
@@ -2,32 +2,39 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/beekhof/jira-tool/pkg/config"
 	"github.com/beekhof/jira-tool/pkg/editor"
 	"github.com/beekhof/jira-tool/pkg/gemini"
 	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/prompt"
 	"github.com/beekhof/jira-tool/pkg/qa"
 
 	"github.com/spf13/cobra"
 )
 
+var describeResumeFlag bool
+
 var describeCmd = &cobra.Command{
 	Use:   "describe [TICKET_ID]",
 	Short: "Generate or update a ticket description using AI",
 	Long: `Generate or update a Jira ticket description using an interactive Q&A flow with Gemini AI.
 The ticket ID should be in the format PROJECT-NUMBER (e.g., ENG-123).
 If no project prefix is provided, the default project will be used.
+Without a ticket ID, the one named by the current git branch is used.
 
 This command will:
 1. Fetch the ticket details
 2. Run an interactive Q&A session to gather information
 3. Generate a description based on your answers
-4. Ask for confirmation before updating the ticket`,
-	Args: cobra.ExactArgs(1),
+4. Ask for confirmation before updating the ticket
+
+Answers are saved after each question. If the session is interrupted, run the
+command again with --resume to continue it or to generate from the saved answers.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runDescribe,
 }
 
@@ -42,10 +49,14 @@ func runDescribe(_ *cobra.Command, args []string) error {
 	}
 
 	// Normalize ticket ID (add default project if needed)
-	ticketID := normalizeTicketID(args[0], cfg.DefaultProject)
+	key, err := ticketArg(args)
+	if err != nil {
+		return err
+	}
+	ticketID := normalizeTicketID(key, cfg.DefaultProject)
 
 	// Create Jira client
-	client, err := jira.NewClient(configDir, GetNoCache())
+	client, err := newJiraClient(configDir)
 	if err != nil {
 		return err
 	}
@@ -70,10 +81,11 @@ func runDescribe(_ *cobra.Command, args []string) error {
 	issueTypeName := ticket.Fields.IssueType.Name
 
 	// Initialize Gemini client
-	geminiClient, err := gemini.NewClient(configDir)
+	geminiClient, err := gemini.NewClient(configDir, GetNoCache())
 	if err != nil {
 		return fmt.Errorf("failed to initialize Gemini client: %w", err)
 	}
+	geminiClient.SetTicketKey(ticketID)
 
 	// Get existing description if available
 	existingDesc, err := client.GetTicketDescription(ticketID)
@@ -81,19 +93,19 @@ func runDescribe(_ *cobra.Command, args []string) error {
 		existingDesc = "" // Continue with empty description if unavailable
 	}
 
-	// Run Q&A flow
-	answerInputMethod := cfg.AnswerInputMethod
-	if answerInputMethod == "" {
-		answerInputMethod = defaultInputMethod
+	reader := bufio.NewReader(os.Stdin)
+	store := qa.NewSessionStore(qa.GetSessionsDir(configDir))
+	session, generateNow, err := describeSession(reader, store, client, cfg, ticketID, ticketSummary, issueTypeName,
+		existingDesc)
+	if err != nil {
+		return err
 	}
 
-	fmt.Printf("\nGenerating description for %s: %s\n", ticketID, ticketSummary)
-	fmt.Println("Answer the questions below to help generate a comprehensive description.")
-	fmt.Println()
-
-	description, err := qa.RunQnAFlow(
-		geminiClient, ticketSummary, cfg.MaxQuestions, ticketSummary, issueTypeName,
-		existingDesc, client, ticketID, cfg.EpicLinkFieldID, answerInputMethod)
+	// Run Q&A flow
+	description, err := runQnASession(geminiClient, cfg, store, session, generateNow)
+	if errors.Is(err, gemini.ErrBudgetExceeded) {
+		description, err = writeDescriptionManually(err, existingDesc)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to generate description: %w", err)
 	}
@@ -103,14 +115,11 @@ func runDescribe(_ *cobra.Command, args []string) error {
 	fmt.Println("---")
 	fmt.Println(description)
 	fmt.Println("---")
-	fmt.Print("\nUpdate ticket with this description? [Y/n/e(dit)] ")
 
-	reader := bufio.NewReader(os.Stdin)
-	confirm, err := reader.ReadString('\n')
+	confirm, err := prompt.Choose(reader, "update_description", "\nUpdate ticket with this description? [Y/n/e(dit)] ")
 	if err != nil {
 		return fmt.Errorf("failed to read input: %w", err)
 	}
-	confirm = strings.TrimSpace(strings.ToLower(confirm))
 
 	if confirm == "e" || confirm == "edit" {
 		// Open in editor
@@ -125,14 +134,51 @@ func runDescribe(_ *cobra.Command, args []string) error {
 		if err := client.UpdateTicketDescription(ticketID, description); err != nil {
 			return fmt.Errorf("failed to update ticket description: %w", err)
 		}
+		finishSession(store, session)
 		fmt.Printf("\n✓ Description updated for %s\n", ticketID)
 		return nil
 	}
 
+	finishSession(store, session)
 	fmt.Println("\nDescription not updated.")
 	return nil
 }
 
+// describeSession returns the saved session for the ticket when --resume is given,
+// otherwise (or if there is nothing to resume) starts a new one
+func describeSession(
+	reader *bufio.Reader, store *qa.SessionStore, client jira.JiraClient, cfg *config.Config,
+	ticketID, ticketSummary, issueTypeName, existingDesc string,
+) (*qa.Session, bool, error) {
+	if describeResumeFlag {
+		session, generateNow, err := loadResumeSession(reader, store, describeSessionCommand, ticketID)
+		if err != nil || session != nil {
+			return session, generateNow, err
+		}
+		fmt.Printf("No unfinished session for %s, starting a new one.\n", ticketID)
+	} else if existing, err := store.Load(qa.SessionID(describeSessionCommand, ticketID)); err == nil && existing != nil {
+		fmt.Printf("Note: an unfinished session for %s will be replaced. Use --resume to continue it instead.\n",
+			ticketID)
+	}
+
+	fmt.Printf("\nGenerating description for %s: %s\n", ticketID, ticketSummary)
+	fmt.Println("Answer the questions below to help generate a comprehensive description.")
+	fmt.Println()
+
+	session := qa.NewSession(describeSessionCommand, ticketSummary, ticketSummary, issueTypeName, existingDesc,
+		client, ticketID, cfg.EpicLinkFieldID)
+	return session, false, nil
+}
+
+// writeDescriptionManually falls back to the editor when AI generation was refused by the token budget
+func writeDescriptionManually(budgetErr error, existingDescription string) (string, error) {
+	fmt.Printf("\nWarning: %v\n", budgetErr)
+	fmt.Println("Opening editor to write the description manually...")
+	return editor.OpenInEditor(existingDescription)
+}
+
 func init() {
+	describeCmd.Flags().BoolVar(&describeResumeFlag, "resume", false,
+		"Resume the unfinished Q&A session for this ticket")
 	rootCmd.AddCommand(describeCmd)
 }
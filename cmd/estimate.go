@@ -8,14 +8,18 @@ import (
 	"strings"
 
 	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/estimate"
 	"github.com/beekhof/jira-tool/pkg/gemini"
 	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/prompt"
 
 	"github.com/spf13/cobra"
 )
 
 const actionToggle = "toggle"
 
+var estimateTUIFlag bool
+
 var estimateCmd = &cobra.Command{
 	Use:   "estimate [TICKET_ID]",
 	Short: "Estimate story points for a ticket",
@@ -23,17 +27,21 @@ var estimateCmd = &cobra.Command{
 The ticket ID should be in the format PROJECT-NUMBER (e.g., ENG-123).
 
 If no ticket ID is provided, shows a paginated list of tickets without story points
-where you can select multiple tickets to estimate.`,
+where you can select multiple tickets to estimate. With --tui the list is shown full screen,
+with the highlighted ticket's details beside it. On a git branch named after a ticket, with
+no flags, that ticket is estimated instead; use --no-branch for the list.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runEstimate,
 }
 
-func runEstimate(_ *cobra.Command, args []string) error {
+func runEstimate(cmd *cobra.Command, args []string) error {
+	args = queueTicketArgs(cmd, args)
+
 	// Get config directory
 	configDir := GetConfigDir()
 
 	// Create Jira client
-	client, err := jira.NewClient(configDir, GetNoCache())
+	client, err := newJiraClient(configDir)
 	if err != nil {
 		return err
 	}
@@ -61,6 +69,18 @@ func runEstimate(_ *cobra.Command, args []string) error {
 	return estimateMultipleTickets(client, cfg, storyPoints, configDir)
 }
 
+// printAIEstimate shows the AI estimate with its reasoning and the reference tickets used
+func printAIEstimate(points int, reasoning string, references []gemini.EstimateExample) {
+	fmt.Printf("\n🤖 AI Estimate: %d story points\n", points)
+	if reasoning != "" {
+		fmt.Printf("   Reasoning: %s\n", reasoning)
+	}
+	if len(references) > 0 {
+		fmt.Printf("   Compared with: %s\n", estimate.FormatReferences(references))
+	}
+	fmt.Println()
+}
+
 // estimateSingleTicket estimates a single ticket
 func estimateSingleTicket(
 	client jira.JiraClient, cfg *config.Config, ticketID string,
@@ -81,7 +101,6 @@ func estimateSingleTicket(
 	}
 
 	ticket := issues[0]
-	summary := ticket.Fields.Summary
 	description, err := client.GetTicketDescription(ticketID)
 	if err != nil {
 		// Description might be empty, that's okay
@@ -89,23 +108,24 @@ func estimateSingleTicket(
 	}
 
 	// Get Gemini estimate
+	var suggestedPoints int
 	fmt.Println("Getting AI story point estimate...")
-	geminiClient, err := gemini.NewClient(configDir)
+	geminiClient, err := gemini.NewClient(configDir, GetNoCache())
 	if err != nil {
 		// If Gemini fails, continue with manual selection
 		fmt.Printf("Warning: Could not initialize Gemini client: %v\n", err)
 		fmt.Println("Continuing with manual selection...")
 	} else {
-		estimate, reasoning, err := geminiClient.EstimateStoryPoints(summary, description, storyPoints)
+		geminiClient.SetTicketKey(ticketID)
+		aiPoints, reasoning, references, err := estimate.EstimateWithReferences(
+			geminiClient, client, &ticket, description, storyPoints,
+			estimate.ReferenceCount(cfg.EstimateReferenceCount))
 		if err != nil {
 			fmt.Printf("Warning: Could not get AI estimate: %v\n", err)
 			fmt.Println("Continuing with manual selection...")
 		} else {
-			fmt.Printf("\n🤖 AI Estimate: %d story points\n", estimate)
-			if reasoning != "" {
-				fmt.Printf("   Reasoning: %s\n", reasoning)
-			}
-			fmt.Println()
+			printAIEstimate(aiPoints, reasoning, references)
+			suggestedPoints = aiPoints
 		}
 	}
 
@@ -117,13 +137,12 @@ func estimateSingleTicket(
 		fmt.Printf("[%s] %d\n", letter, points)
 	}
 	fmt.Println("Or enter a number directly")
-	fmt.Print("> ")
 
-	input, err := reader.ReadString('\n')
+	input, err := prompt.Line(reader, "points", "> ")
 	if err != nil {
 		return fmt.Errorf("failed to read input: %w", err)
 	}
-	input = strings.TrimSpace(strings.ToLower(input))
+	input = strings.ToLower(input)
 
 	var points int
 	// Try to parse as number first
@@ -150,6 +169,7 @@ func estimateSingleTicket(
 	if err := client.UpdateTicketPoints(ticketID, points); err != nil {
 		return err
 	}
+	estimate.RecordEstimate(configDir, &ticket, suggestedPoints, points)
 
 	fmt.Printf("Updated %s with %d story points.\n", ticketID, points)
 	return nil
@@ -210,6 +230,13 @@ func runEstimatePagination(
 	client jira.JiraClient, cfg *config.Config, issues []jira.Issue,
 	storyPoints []int, configDir string,
 ) error {
+	if !prompt.Interactive() {
+		return fmt.Errorf("choosing tickets to estimate needs a terminal; give a TICKET_ID instead")
+	}
+	if estimateTUIFlag {
+		return runEstimateTUI(client, cfg, issues, storyPoints, configDir)
+	}
+
 	pageSize := cfg.ReviewPageSize
 	if pageSize <= 0 {
 		pageSize = 10
@@ -389,7 +416,7 @@ func toggleEstimateTicketSelection(selected map[string]bool, issues []jira.Issue
 // estimateSelectedTickets estimates each selected ticket one by one
 func estimateSelectedTickets(
 	client jira.JiraClient,
-	_ *config.Config,
+	cfg *config.Config,
 	allIssues []jira.Issue,
 	selected map[string]bool,
 	storyPoints []int,
@@ -411,7 +438,7 @@ func estimateSelectedTickets(
 	fmt.Printf("\nEstimating %d ticket(s)...\n\n", len(selectedTickets))
 
 	reader := bufio.NewReader(os.Stdin)
-	geminiClient, err := gemini.NewClient(configDir)
+	geminiClient, err := gemini.NewClient(configDir, GetNoCache())
 	if err != nil {
 		fmt.Printf("Warning: Could not initialize Gemini client: %v\n", err)
 		fmt.Println("Continuing without AI estimates...")
@@ -422,24 +449,24 @@ func estimateSelectedTickets(
 		ticket := &selectedTickets[i]
 		fmt.Printf("=== [%d/%d] %s - %s ===\n", i+1, len(selectedTickets), ticket.Key, ticket.Fields.Summary)
 
-		summary := ticket.Fields.Summary
 		description, err := client.GetTicketDescription(ticket.Key)
 		if err != nil {
 			description = ""
 		}
 
 		// Get Gemini estimate if available
+		var suggestedPoints int
 		if geminiClient != nil {
 			fmt.Println("Getting AI story point estimate...")
-			estimate, reasoning, err := geminiClient.EstimateStoryPoints(summary, description, storyPoints)
+			geminiClient.SetTicketKey(ticket.Key)
+			aiPoints, reasoning, references, err := estimate.EstimateWithReferences(
+				geminiClient, client, ticket, description, storyPoints,
+				estimate.ReferenceCount(cfg.EstimateReferenceCount))
 			if err != nil {
 				fmt.Printf("Warning: Could not get AI estimate: %v\n", err)
 			} else {
-				fmt.Printf("\n🤖 AI Estimate: %d story points\n", estimate)
-				if reasoning != "" {
-					fmt.Printf("   Reasoning: %s\n", reasoning)
-				}
-				fmt.Println()
+				printAIEstimate(aiPoints, reasoning, references)
+				suggestedPoints = aiPoints
 			}
 		}
 
@@ -450,13 +477,12 @@ func estimateSelectedTickets(
 			fmt.Printf("[%s] %d\n", letter, points)
 		}
 		fmt.Println("Or enter a number directly")
-		fmt.Print("> ")
 
-		input, err := reader.ReadString('\n')
+		input, err := prompt.Line(reader, "points", "> ")
 		if err != nil {
 			return fmt.Errorf("failed to read input: %w", err)
 		}
-		input = strings.TrimSpace(strings.ToLower(input))
+		input = strings.ToLower(input)
 
 		var points int
 		// Try to parse as number first
@@ -487,6 +513,7 @@ func estimateSelectedTickets(
 			fmt.Printf("Error updating %s: %v\n", ticket.Key, err)
 			continue
 		}
+		estimate.RecordEstimate(configDir, ticket, suggestedPoints, points)
 
 		fmt.Printf("Updated %s with %d story points.\n\n", ticket.Key, points)
 	}
@@ -496,5 +523,7 @@ func estimateSelectedTickets(
 }
 
 func init() {
+	estimateCmd.Flags().BoolVar(&estimateTUIFlag, "tui", false,
+		"Show the tickets full screen with a detail pane")
 	rootCmd.AddCommand(estimateCmd)
 }
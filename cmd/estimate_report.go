@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/estimate"
+
+	"github.com/spf13/cobra"
+)
+
+var offlineReportFlag bool
+
+var estimateReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Compare AI estimates with chosen points and actual cycle time",
+	Long: `Show how AI story point suggestions compare with the points the team chose,
+broken down by issue type and component, and how chosen points relate to the
+actual cycle time of completed tickets.
+
+Estimates are recorded locally whenever points are set with 'estimate' or 'review'.
+Cycle times are fetched from Jira for tickets that have since been completed,
+unless --offline is given.`,
+	Args: cobra.NoArgs,
+	RunE: runEstimateReport,
+}
+
+func runEstimateReport(_ *cobra.Command, _ []string) error {
+	configDir := GetConfigDir()
+	cfg, err := config.LoadConfig(config.GetConfigPath(configDir))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	historyPath := estimate.GetHistoryPath(configDir)
+	history, err := estimate.LoadHistory(historyPath)
+	if err != nil {
+		return err
+	}
+
+	if len(history.Records) == 0 {
+		fmt.Println("No estimates recorded yet. Estimates are recorded when points are set with 'estimate' or 'review'.")
+		return nil
+	}
+
+	if !offlineReportFlag {
+		client, err := newJiraClient(configDir)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Checking Jira for completed tickets...")
+		if updated := history.UpdateCycleTimes(client); updated > 0 {
+			if err := estimate.SaveHistory(history, historyPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	scale := cfg.StoryPointOptions
+	if len(scale) == 0 {
+		scale = []int{1, 2, 3, 5, 8, 13, 21, 34}
+	}
+	report := estimate.BuildReport(history, scale)
+	printEstimateReport(&report)
+
+	return nil
+}
+
+func printEstimateReport(report *estimate.Report) {
+	fmt.Printf("\nEstimates recorded: %d (%d with an AI suggestion)\n", report.Total, report.WithAI)
+
+	if report.WithAI > 0 {
+		fmt.Println("\nAI agreement")
+		fmt.Printf("  Exact match:         %5.1f%% (%d/%d)\n",
+			report.AgreementRate()*100, report.Agreed, report.WithAI)
+		fmt.Printf("  Within one step:     %5.1f%% (%d/%d)\n",
+			report.WithinOneStepRate()*100, report.WithinOneStep, report.WithAI)
+		fmt.Printf("  Mean absolute error: %5.2f points\n", report.MeanAbsError)
+		fmt.Printf("  Mean bias (AI - chosen): %+.2f points\n", report.MeanDiff)
+
+		printGroupBias("By issue type", report.ByIssueType)
+		printGroupBias("By component", report.ByComponent)
+	}
+
+	fmt.Println("\nChosen points vs. actual cycle time")
+	if len(report.CycleTimes) == 0 {
+		fmt.Println("  No completed tickets yet")
+		return
+	}
+	fmt.Printf("  %6s  %7s  %11s  %9s\n", "Points", "Tickets", "Median days", "Mean days")
+	for _, ct := range report.CycleTimes {
+		fmt.Printf("  %6d  %7d  %11.1f  %9.1f\n", ct.Points, ct.Count, ct.MedianDays, ct.MeanDays)
+	}
+}
+
+func printGroupBias(title string, groups []estimate.GroupBias) {
+	fmt.Printf("\n%s\n", title)
+	fmt.Printf("  %-20s  %7s  %6s  %9s\n", "", "Tickets", "Agree", "Bias")
+	for _, g := range groups {
+		fmt.Printf("  %-20s  %7d  %5.0f%%  %+9.2f\n",
+			truncateSummary(g.Name, 20), g.Count, float64(g.Agreed)/float64(g.Count)*100, g.MeanDiff)
+	}
+}
+
+func init() {
+	estimateReportCmd.Flags().BoolVar(&offlineReportFlag, "offline", false,
+		"Don't fetch cycle times for newly completed tickets from Jira")
+	estimateCmd.AddCommand(estimateReportCmd)
+}
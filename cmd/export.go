@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/plan"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormatFlag string
+	exportOutputFlag string
+	exportForceFlag  bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [TICKET_ID]",
+	Short: "Export an epic and its children to an editable Markdown or YAML file",
+	Long: `Export a ticket (usually an Epic) with its children and their sub-tasks to a plan file:
+summaries, points, status, assignee and descriptions, with children in rank order.
+
+Edit the file (rename, re-estimate, reorder, add children) and push the edits back with
+'jira sync FILE'. The file also records the tickets as exported, so that sync can tell
+your edits from changes made in Jira since.
+
+The file is KEY.md (or KEY.yaml with --format yaml) unless --output is given ('-' for stdout).`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExport,
+}
+
+func runExport(_ *cobra.Command, args []string) error {
+	if exportFormatFlag != plan.FormatMarkdown && exportFormatFlag != plan.FormatYAML {
+		return fmt.Errorf("invalid --format %q (expected md or yaml)", exportFormatFlag)
+	}
+
+	configDir := GetConfigDir()
+	cfg, err := config.LoadConfig(config.GetConfigPath(configDir))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	ticket, err := ticketArg(args)
+	if err != nil {
+		return err
+	}
+	key := normalizeTicketID(ticket, cfg.DefaultProject)
+
+	output := exportOutputFlag
+	if output == "" {
+		output = key + "." + exportFormatFlag
+	}
+	if output != "-" && !exportForceFlag {
+		if _, err := os.Stat(output); err == nil {
+			return fmt.Errorf("%s already exists; use --force to replace it, or 'jira sync %s' to push its edits", output, output)
+		}
+	}
+
+	client, err := newJiraClient(configDir)
+	if err != nil {
+		return err
+	}
+
+	p, err := plan.Export(client, key, cfg.EpicLinkFieldID, exportFormatFlag)
+	if err != nil {
+		return err
+	}
+
+	if output == "-" {
+		data, err := p.Bytes()
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := p.Save(output); err != nil {
+		return err
+	}
+	count := 0
+	p.Walk(func(_, _ *plan.Item, _ int) { count++ })
+	fmt.Printf("Exported %d ticket(s) to %s\n", count, output)
+	return nil
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&exportFormatFlag, "format", plan.FormatMarkdown, "File format: md or yaml")
+	exportCmd.Flags().StringVarP(&exportOutputFlag, "output", "o", "", "File to write (default: KEY.md or KEY.yaml; '-' for stdout)")
+	exportCmd.Flags().BoolVar(&exportForceFlag, "force", false, "Replace the file if it exists")
+	_ = exportCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(
+		[]string{plan.FormatMarkdown, plan.FormatYAML}, cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/prompt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyTicketFlag string
+	historyLimitFlag  int
+	undoSinceFlag     string
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the changes this tool made in Jira",
+	Long: `Show the local journal of changes this tool made in Jira, most recent last.
+Every change is recorded in journal.jsonl in the config directory, with the values
+it replaced, so it can be reverted with 'jira utils undo'.`,
+	Args: cobra.NoArgs,
+	RunE: runHistory,
+}
+
+var undoCmd = &cobra.Command{
+	Use:   "undo [N]",
+	Short: "Revert the most recent changes this tool made in Jira",
+	Long: `Revert the N most recent changes (default 1) from the journal, newest first,
+or every change made since --since (a duration such as 2h or 3d, or a date/time).
+
+Field changes are reverted by writing back the previous values, transitions by
+transitioning back to the previous status (if the workflow allows it), and created
+tickets are deleted (if you have permission to delete them). Comments, links, sprint
+moves and deletions can't be undone and are skipped.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUndo,
+}
+
+func runHistory(_ *cobra.Command, _ []string) error {
+	configDir := GetConfigDir()
+	entries, err := jira.NewJournal(jira.GetJournalPath(configDir)).List()
+	if err != nil {
+		return err
+	}
+
+	ticketFilter := ""
+	if historyTicketFlag != "" {
+		defaultProject := ""
+		if cfg, err := config.LoadConfig(config.GetConfigPath(configDir)); err == nil {
+			defaultProject = cfg.DefaultProject
+		}
+		ticketFilter = normalizeTicketID(historyTicketFlag, defaultProject)
+	}
+
+	undone := jira.UndoneIDs(entries)
+	type numbered struct {
+		index int
+		entry *jira.JournalEntry
+	}
+	var matches []numbered
+	for i := range entries {
+		if ticketFilter != "" && !strings.EqualFold(entries[i].Ticket, ticketFilter) {
+			continue
+		}
+		matches = append(matches, numbered{index: i + 1, entry: &entries[i]})
+	}
+
+	if len(matches) == 0 {
+		fmt.Println("No changes recorded.")
+		return nil
+	}
+	if historyLimitFlag > 0 && len(matches) > historyLimitFlag {
+		matches = matches[len(matches)-historyLimitFlag:]
+	}
+
+	for _, m := range matches {
+		marker := ""
+		switch {
+		case undone[m.entry.ID]:
+			marker = "  [undone]"
+		case m.entry.Reverts == "" && !jira.CanRevert(m.entry):
+			marker = "  [can't undo]"
+		}
+		fmt.Printf("#%-4d %s  %-10s %-24s %s%s\n",
+			m.index, m.entry.Timestamp.Local().Format("2006-01-02 15:04"),
+			m.entry.Ticket, m.entry.Operation, m.entry.Summary(), marker)
+		if m.entry.Command != "" {
+			fmt.Printf("      %s\n", m.entry.Command)
+		}
+	}
+
+	return nil
+}
+
+func runUndo(_ *cobra.Command, args []string) error {
+	configDir := GetConfigDir()
+	journal := jira.NewJournal(jira.GetJournalPath(configDir))
+	entries, err := journal.List()
+	if err != nil {
+		return err
+	}
+
+	selected, err := selectUndoEntries(jira.Undoable(entries), args, undoSinceFlag, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		fmt.Println("Nothing to undo.")
+		return nil
+	}
+
+	fmt.Println("Changes to undo:")
+	for i := range selected {
+		fmt.Printf("  %s  %-10s %-24s %s\n", selected[i].Timestamp.Local().Format("2006-01-02 15:04"),
+			selected[i].Ticket, selected[i].Operation, selected[i].Summary())
+	}
+
+	confirmed, err := prompt.Confirm(bufio.NewReader(os.Stdin), "undo",
+		fmt.Sprintf("Undo %d change(s)? [y/N] ", len(selected)), false)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Println("Nothing undone.")
+		return nil
+	}
+
+	// Reverts go through the plain client: they are journaled as undos below,
+	// not as new changes that could themselves be undone
+	client, err := jira.NewClient(configDir, GetNoCache())
+	if err != nil {
+		return err
+	}
+	if dryRunFlag {
+		client = withDryRun(client)
+	}
+
+	failed := 0
+	for i := range selected {
+		entry := &selected[i]
+		if err := jira.Revert(client, entry); err != nil {
+			fmt.Printf("✗ %s %s: %v\n", entry.Ticket, entry.Operation, err)
+			failed++
+			continue
+		}
+		fmt.Printf("✓ %s %s undone\n", entry.Ticket, entry.Operation)
+		if dryRunFlag {
+			continue
+		}
+		if err := journal.RecordRevert(entry, commandLine()); err != nil {
+			fmt.Printf("Warning: Could not record undo in the journal: %v\n", err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d change(s) could not be undone", failed, len(selected))
+	}
+	return nil
+}
+
+// selectUndoEntries picks the changes to undo from undoable (most recent first):
+// the N most recent, or all of them since --since
+func selectUndoEntries(
+	undoable []jira.JournalEntry, args []string, since string, now time.Time,
+) ([]jira.JournalEntry, error) {
+	if since != "" {
+		if len(args) > 0 {
+			return nil, errors.New("give either a number of changes or --since, not both")
+		}
+		cutoff, err := parseSince(since, now)
+		if err != nil {
+			return nil, err
+		}
+		selected := []jira.JournalEntry{}
+		for i := range undoable {
+			if !undoable[i].Timestamp.Before(cutoff) {
+				selected = append(selected, undoable[i])
+			}
+		}
+		return selected, nil
+	}
+
+	count := 1
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid number of changes: %s", args[0])
+		}
+		count = n
+	}
+	if count > len(undoable) {
+		count = len(undoable)
+	}
+	return undoable[:count], nil
+}
+
+// parseSince parses --since as a duration back from now (e.g. 90m, 2h, 3d) or a local date/time
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if strings.HasSuffix(value, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(value, "d")); err == nil && days >= 0 {
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf(
+		"invalid --since %q: use a duration (e.g. 2h, 3d) or a date (YYYY-MM-DD [HH:MM])", value)
+}
+
+func init() {
+	historyCmd.Flags().StringVar(&historyTicketFlag, "ticket", "", "Only show changes to this ticket")
+	historyCmd.Flags().IntVarP(&historyLimitFlag, "limit", "n", 20, "Show at most this many changes (0 for all)")
+	undoCmd.Flags().StringVar(&undoSinceFlag, "since", "",
+		"Undo every change since a duration ago (e.g. 2h, 3d) or a date (YYYY-MM-DD [HH:MM])")
+	utilsCmd.AddCommand(historyCmd, undoCmd)
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"2h", now.Add(-2 * time.Hour)},
+		{"90m", now.Add(-90 * time.Minute)},
+		{"3d", now.AddDate(0, 0, -3)},
+		{"2024-03-01", time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)},
+		{"2024-03-01 09:30", time.Date(2024, 3, 1, 9, 30, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.input, now)
+		if err != nil {
+			t.Errorf("parseSince(%q) failed: %v", tt.input, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseSince(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	if _, err := parseSince("yesterday", now); err == nil {
+		t.Error("Expected an error for an invalid --since")
+	}
+}
+
+func TestSelectUndoEntries(t *testing.T) {
+	now := time.Now()
+	undoable := []jira.JournalEntry{
+		{ID: "c", Timestamp: now.Add(-10 * time.Minute)},
+		{ID: "b", Timestamp: now.Add(-2 * time.Hour)},
+		{ID: "a", Timestamp: now.Add(-48 * time.Hour)},
+	}
+
+	selected, err := selectUndoEntries(undoable, nil, "", now)
+	if err != nil || len(selected) != 1 || selected[0].ID != "c" {
+		t.Errorf("Expected only the most recent change by default, got %+v (err %v)", selected, err)
+	}
+
+	selected, err = selectUndoEntries(undoable, []string{"5"}, "", now)
+	if err != nil || len(selected) != 3 {
+		t.Errorf("Expected all 3 changes, got %+v (err %v)", selected, err)
+	}
+
+	selected, err = selectUndoEntries(undoable, nil, "3h", now)
+	if err != nil || len(selected) != 2 {
+		t.Errorf("Expected 2 changes in the last 3 hours, got %+v (err %v)", selected, err)
+	}
+
+	if _, err := selectUndoEntries(undoable, []string{"2"}, "3h", now); err == nil {
+		t.Error("Expected an error when both N and --since are given")
+	}
+	if _, err := selectUndoEntries(undoable, []string{"0"}, "", now); err == nil {
+		t.Error("Expected an error for N < 1")
+	}
+}
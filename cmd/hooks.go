@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/beekhof/jira-tool/pkg/git"
+	"github.com/beekhof/jira-tool/pkg/hooks"
+	"github.com/beekhof/jira-tool/pkg/jira"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// hookTicketTTL is how long a ticket looked up by the commit-msg hook is trusted from the cache
+	hookTicketTTL = time.Hour
+	// hookLookupTimeout is how long the commit-msg hook waits for Jira before only checking the key
+	hookLookupTimeout = 5 * time.Second
+)
+
+var (
+	hooksOfflineFlag  bool
+	hooksPrependFlag  bool
+	hooksPrefixFlag   string
+	hooksProjectsFlag []string
+	hooksForceFlag    bool
+)
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Git hooks that keep commits traceable to Jira",
+	Long: `Install and run git hooks that check commits refer to Jira tickets.
+
+'jira hooks install' adds a commit-msg hook to the current repository that runs
+'jira hooks check-commit' on every commit message.`,
+}
+
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the commit-msg hook in the current repository",
+	Long: `Install a commit-msg hook in the current git repository that runs
+'jira hooks check-commit' with the flags given here. A commit-msg hook that wasn't
+installed by this command is only replaced with --force.`,
+	Args: cobra.NoArgs,
+	RunE: runHooksInstall,
+}
+
+var hooksCheckCommitCmd = &cobra.Command{
+	Use:   "check-commit FILE",
+	Short: "Check a commit message names an open ticket",
+	Long: `Check the commit message in FILE names a ticket in a known project, or else take the
+ticket from the current branch's name. The ticket is looked up (from the cache when it
+was looked up in the last hour) and must exist and not be done. If Jira can't be
+reached, only the key is checked.
+
+Known projects are the --project flags, or else the default project and the projects
+of recently used and cached tickets. With --offline, Jira isn't contacted at all.
+
+With --prepend, a ticket taken from the branch is added in front of the subject, as
+--prefix with {key} and {summary} filled in. Merge, fixup and squash commits aren't checked.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runHooksCheckCommit,
+}
+
+func runHooksInstall(_ *cobra.Command, _ []string) error {
+	hooksDir, err := (&git.Repo{}).HooksDir()
+	if err != nil {
+		return err
+	}
+
+	path, err := hooks.InstallCommitMsg(hooksDir, checkCommitCommand(), hooksForceFlag)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Installed %s\n", path)
+	return nil
+}
+
+// checkCommitCommand is the command line the installed hook runs, with the install flags
+func checkCommitCommand() string {
+	executable := "jira"
+	if _, err := exec.LookPath(executable); err != nil {
+		if path, err := os.Executable(); err == nil {
+			executable = path
+		}
+	}
+
+	args := []string{shellQuote(executable)}
+	if configDir != "" {
+		args = append(args, "--config-dir", shellQuote(configDir))
+	}
+	args = append(args, "hooks", "check-commit")
+	if hooksOfflineFlag {
+		args = append(args, "--offline")
+	}
+	if hooksPrependFlag {
+		args = append(args, "--prepend")
+		if hooksPrefixFlag != hooks.DefaultPrefix {
+			args = append(args, "--prefix", shellQuote(hooksPrefixFlag))
+		}
+	}
+	for _, project := range hooksProjectsFlag {
+		args = append(args, "--project", shellQuote(project))
+	}
+	return strings.Join(args, " ")
+}
+
+// shellQuote quotes a word for sh if it needs it
+func shellQuote(word string) string {
+	if word != "" && strings.Trim(word, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:{}") == "" {
+		return word
+	}
+	return "'" + strings.ReplaceAll(word, "'", `'\''`) + "'"
+}
+
+func runHooksCheckCommit(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read commit message: %w", err)
+	}
+
+	sources := loadCompletionSources()
+	check := &hooks.CommitCheck{
+		Projects: hooksProjectsFlag,
+		Branch:   branchTicket(),
+	}
+	if len(check.Projects) == 0 {
+		check.Projects = sources.projects()
+	}
+	if !hooksOfflineFlag {
+		check.Lookup = hookTicketLookup(sources.cache)
+	}
+	if hooksPrependFlag {
+		check.Prefix = hooksPrefixFlag
+	}
+
+	result, err := check.Check(string(data))
+	if err != nil {
+		// The hook's output is the message; usage would only hide it
+		cmd.SilenceUsage = true
+		return err
+	}
+
+	if result.Message != string(data) {
+		if err := os.WriteFile(args[0], []byte(result.Message), 0600); err != nil {
+			return fmt.Errorf("failed to update commit message: %w", err)
+		}
+	}
+	if result.FromBranch {
+		fmt.Fprintf(os.Stderr, "Using %s from the current git branch\n", result.Key)
+	}
+	return nil
+}
+
+// hookTicketLookup looks tickets up in the cache, or else in Jira, caching what it finds
+// If Jira can't be reached the ticket is taken on trust, so commits aren't blocked by an outage
+func hookTicketLookup(cache *jira.Cache) func(key string) (*hooks.Ticket, error) {
+	return func(key string) (*hooks.Ticket, error) {
+		if !GetNoCache() {
+			if cached, ok := cache.Ticket(key, hookTicketTTL); ok {
+				return &hooks.Ticket{
+					Key: cached.Key, Summary: cached.Summary, Status: cached.Status, StatusCategory: cached.StatusCategory,
+				}, nil
+			}
+		}
+
+		client, err := newJiraClient(GetConfigDir())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: can't check %s in Jira (%v); only the key was checked\n", key, err)
+			return &hooks.Ticket{Key: key}, nil
+		}
+		issue, err := withTimeout(hookLookupTimeout, func() (*jira.Issue, error) { return client.GetIssue(key) })
+		if err != nil {
+			var urlErr *url.Error
+			if errors.Is(err, errTimedOut) || errors.As(err, &urlErr) {
+				fmt.Fprintf(os.Stderr, "Warning: can't reach Jira (%v); only the key of %s was checked\n", err, key)
+				return &hooks.Ticket{Key: key}, nil
+			}
+			return nil, fmt.Errorf("can't find %s in Jira: %w", key, err)
+		}
+
+		ticket := jira.CachedTicket{
+			Key: issue.Key, Summary: issue.Fields.Summary, Type: issue.Fields.IssueType.Name,
+			Status: issue.Fields.Status.Name, StatusCategory: issue.Fields.Status.StatusCategory.Key,
+		}
+		// Caching only saves a lookup next time, so failing to save doesn't matter
+		_ = cache.SetCheckedTicket(ticket, time.Now())
+		return &hooks.Ticket{
+			Key: ticket.Key, Summary: ticket.Summary, Status: ticket.Status, StatusCategory: ticket.StatusCategory,
+		}, nil
+	}
+}
+
+func init() {
+	for _, c := range []*cobra.Command{hooksInstallCmd, hooksCheckCommitCmd} {
+		c.Flags().BoolVar(&hooksOfflineFlag, "offline", false,
+			"Only check the key's pattern and project, without contacting Jira")
+		c.Flags().BoolVar(&hooksPrependFlag, "prepend", false,
+			"Put the branch's ticket in front of the subject when the message doesn't name one")
+		c.Flags().StringVar(&hooksPrefixFlag, "prefix", hooks.DefaultPrefix,
+			"With --prepend, what to put in front of the subject; {key} and {summary} are filled in")
+		c.Flags().StringSliceVar(&hooksProjectsFlag, "project", nil,
+			"Accept keys of this project (repeatable; default: known projects)")
+	}
+	hooksInstallCmd.Flags().BoolVar(&hooksForceFlag, "force", false, "Replace an existing commit-msg hook")
+
+	hooksCmd.AddCommand(hooksInstallCmd)
+	hooksCmd.AddCommand(hooksCheckCommitCmd)
+	rootCmd.AddCommand(hooksCmd)
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beekhof/jira-tool/pkg/hooks"
+	"github.com/beekhof/jira-tool/pkg/jira"
+
+	"github.com/spf13/cobra"
+)
+
+// checkCommit runs check-commit on message and returns the message afterwards
+func checkCommit(t *testing.T, message string) (string, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	if err := os.WriteFile(path, []byte(message), 0600); err != nil {
+		t.Fatal(err)
+	}
+	err := runHooksCheckCommit(&cobra.Command{}, []string{path})
+	data, readErr := os.ReadFile(path)
+	if readErr != nil {
+		t.Fatal(readErr)
+	}
+	return string(data), err
+}
+
+func TestHooksCheckCommit(t *testing.T) {
+	inTestRepo(t, "feature/ENG-12-onboarding")
+	useCompletionConfig(t)
+
+	cache := jira.NewCache(jira.GetCachePath(configDir))
+	if err := cache.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.SetCheckedTicket(jira.CachedTicket{Key: "ENG-3", Status: "Closed", StatusCategory: jira.StatusCategoryDone}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both tickets are in the cache, so Jira isn't needed
+	if _, err := checkCommit(t, "ENG-9: Fix the crash\n"); err != nil {
+		t.Errorf("Expected a cached open ticket to pass, got %v", err)
+	}
+	if _, err := checkCommit(t, "ENG-3: Reopen\n"); err == nil || !strings.Contains(err.Error(), "ENG-3 is Closed") {
+		t.Errorf("Expected a closed ticket to fail, got %v", err)
+	}
+
+	hooksPrependFlag, hooksPrefixFlag = true, "{key} {summary}: "
+	defer func() { hooksPrependFlag, hooksPrefixFlag = false, hooks.DefaultPrefix }()
+	if message, err := checkCommit(t, "Add the welcome page\n"); err != nil || message != "ENG-12 Onboarding: Add the welcome page\n" {
+		t.Errorf("Expected the branch's ticket prepended, got %q (err %v)", message, err)
+	}
+
+	hooksOfflineFlag = true
+	defer func() { hooksOfflineFlag = false }()
+	if _, err := checkCommit(t, "OPS-77: Rotate certs\n"); err != nil {
+		t.Errorf("Expected a known project to pass offline, got %v", err)
+	}
+	noBranchFlag = true
+	defer func() { noBranchFlag = false }()
+	if _, err := checkCommit(t, "XYZ-1: Something else\n"); !errors.Is(err, hooks.ErrNoTicket) {
+		t.Errorf("Expected ErrNoTicket for an unknown project, got %v", err)
+	}
+}
+
+func TestHooksInstall(t *testing.T) {
+	inTestRepo(t, "main")
+	hooksOfflineFlag, hooksProjectsFlag = true, []string{"ENG", "OPS"}
+	defer func() { hooksOfflineFlag, hooksProjectsFlag = false, nil }()
+
+	if err := runHooksInstall(nil, nil); err != nil {
+		t.Fatalf("install failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(".git", "hooks", "commit-msg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `hooks check-commit --offline --project ENG --project OPS "$1"`) {
+		t.Errorf("Expected the install flags in the hook, got:\n%s", data)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	for word, want := range map[string]string{
+		"/usr/bin/jira":     "/usr/bin/jira",
+		"{key}: ":           "'{key}: '",
+		"/home/o'neil/jira": `'/home/o'\''neil/jira'`,
+	} {
+		if got := shellQuote(word); got != want {
+			t.Errorf("shellQuote(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
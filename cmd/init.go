@@ -14,6 +14,7 @@ import (
 	"github.com/beekhof/jira-tool/pkg/config"
 	"github.com/beekhof/jira-tool/pkg/credentials"
 	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/prompt"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
@@ -87,20 +88,20 @@ func promptBasicConfig(
 	reader *bufio.Reader, existingCfg *config.Config, configDir string,
 ) (jiraURL, jiraToken, geminiKey string, err error) {
 	jiraURL, err = promptWithDefault(
-		reader, "Jira URL (e.g., https://your-company.atlassian.net)", existingCfg,
+		reader, "jira_url", "Jira URL (e.g., https://your-company.atlassian.net)", existingCfg,
 		func(c *config.Config) string { return c.JiraURL })
 	if err != nil {
 		return "", "", "", fmt.Errorf("failed to read Jira URL: %w", err)
 	}
 
 	jiraToken, err = promptPassword(
-		"Jira API Token (press Enter to keep existing)", credentials.JiraServiceKey, configDir)
+		"jira_token", "Jira API Token (press Enter to keep existing)", credentials.JiraServiceKey, configDir)
 	if err != nil {
 		return "", "", "", fmt.Errorf("failed to read Jira token: %w", err)
 	}
 
 	geminiKey, err = promptPassword(
-		"Gemini API Key (press Enter to keep existing)", credentials.GeminiServiceKey, configDir)
+		"gemini_key", "Gemini API Key (press Enter to keep existing)", credentials.GeminiServiceKey, configDir)
 	if err != nil {
 		return "", "", "", fmt.Errorf("failed to read Gemini key: %w", err)
 	}
@@ -109,39 +110,42 @@ func promptBasicConfig(
 }
 
 func promptWithDefault(
-	reader *bufio.Reader, promptText string, existingCfg *config.Config,
+	reader *bufio.Reader, name, promptText string, existingCfg *config.Config,
 	getValue func(*config.Config) string,
 ) (string, error) {
-	prompt := promptText
+	question := promptText
 	if existingCfg != nil {
 		if value := getValue(existingCfg); value != "" {
-			prompt = fmt.Sprintf("%s [%s]", prompt, value)
+			question = fmt.Sprintf("%s [%s]", question, value)
 		}
 	}
-	fmt.Printf("%s: ", prompt)
-	input, err := reader.ReadString('\n')
+	input, err := prompt.Line(reader, name, question+": ")
 	if err != nil {
 		return "", err
 	}
-	input = strings.TrimSpace(input)
 	if input == "" && existingCfg != nil {
 		return getValue(existingCfg), nil
 	}
 	return input, nil
 }
 
-func promptPassword(promptText, serviceKey, configDir string) (string, error) {
+func promptPassword(name, promptText, serviceKey, configDir string) (string, error) {
 	fmt.Print(promptText + ": ")
-	tokenBytes, err := term.ReadPassword(syscall.Stdin)
-	if err != nil {
-		return "", err
+	var token string
+	if value, ok := prompt.Answer(name); ok {
+		// Not echoed, unlike other answers
+		token = strings.TrimSpace(value)
+	} else if prompt.Interactive() {
+		tokenBytes, err := term.ReadPassword(syscall.Stdin)
+		if err != nil {
+			return "", err
+		}
+		token = string(tokenBytes)
 	}
-	token := string(tokenBytes)
 	fmt.Println()
 	if token == "" {
-		token, err = credentials.GetSecret(serviceKey, "", configDir)
-		if err != nil {
-			token = ""
+		if existing, err := credentials.GetSecret(serviceKey, "", configDir); err == nil {
+			token = existing
 		}
 	}
 	return token, nil
@@ -151,14 +155,14 @@ func promptProjectConfig(
 	reader *bufio.Reader, existingCfg *config.Config,
 ) (defaultProject, defaultTaskType string, err error) {
 	defaultProject, err = promptWithDefault(
-		reader, "Default Project Key (e.g., ENG)", existingCfg,
+		reader, "default_project", "Default Project Key (e.g., ENG)", existingCfg,
 		func(c *config.Config) string { return c.DefaultProject })
 	if err != nil {
 		return "", "", fmt.Errorf("failed to read default project: %w", err)
 	}
 
 	defaultTaskType, err = promptWithDefault(
-		reader, "Default Task Type (e.g., Task)", existingCfg,
+		reader, "default_task_type", "Default Task Type (e.g., Task)", existingCfg,
 		func(c *config.Config) string { return c.DefaultTaskType })
 	if err != nil {
 		return "", "", fmt.Errorf("failed to read default task type: %w", err)
@@ -320,10 +324,9 @@ func promptAdvancedSettings(
 }
 
 func promptDescriptionQuality(reader *bufio.Reader, cfg, existingCfg *config.Config) error {
-	fmt.Print("\nDescription minimum length (characters) [default: 128]: ")
-	descLenInput, err := reader.ReadString('\n')
+	descLenInput, err := prompt.Line(reader, "description_min_length",
+		"\nDescription minimum length (characters) [default: 128]: ")
 	if err == nil {
-		descLenInput = strings.TrimSpace(descLenInput)
 		if descLenInput != "" {
 			if descLen, err := strconv.Atoi(descLenInput); err == nil && descLen > 0 {
 				cfg.DescriptionMinLength = descLen
@@ -335,10 +338,9 @@ func promptDescriptionQuality(reader *bufio.Reader, cfg, existingCfg *config.Con
 		}
 	}
 
-	fmt.Print("Enable AI description quality check? [y/N]: ")
-	aiCheckInput, err := reader.ReadString('\n')
+	aiCheckInput, err := prompt.Line(reader, "description_quality_ai", "Enable AI description quality check? [y/N]: ")
 	if err == nil {
-		aiCheckInput = strings.TrimSpace(strings.ToLower(aiCheckInput))
+		aiCheckInput = strings.ToLower(aiCheckInput)
 		if aiCheckInput == "y" || aiCheckInput == "yes" {
 			cfg.DescriptionQualityAI = true
 		} else if existingCfg != nil {
@@ -355,10 +357,9 @@ func promptSeveritySettings(
 	reader *bufio.Reader, cfg, existingCfg *config.Config,
 	defaultProject, configDir string,
 ) error {
-	fmt.Print("\nSeverity field ID [auto-detect/enter manually/skip]: ")
-	severityInput, err := reader.ReadString('\n')
+	severityInput, err := prompt.Line(reader, "severity_field_id",
+		"\nSeverity field ID [auto-detect/enter manually/skip]: ")
 	if err == nil {
-		severityInput = strings.TrimSpace(severityInput)
 		if severityInput == "" || strings.EqualFold(severityInput, "skip") {
 			if existingCfg != nil && existingCfg.SeverityFieldID != "" {
 				cfg.SeverityFieldID = existingCfg.SeverityFieldID
@@ -411,11 +412,10 @@ func detectSeverityField(cfg, existingCfg *config.Config, defaultProject, config
 }
 
 func promptSeverityValues(reader *bufio.Reader, cfg, existingCfg *config.Config) error {
-	fmt.Print("\nSeverity values (comma-separated, e.g., 'Low,Medium,High,Critical' " +
-		"or 'skip' to use Jira API values only): ")
-	severityValuesInput, err := reader.ReadString('\n')
+	severityValuesInput, err := prompt.Line(reader, "severity_values",
+		"\nSeverity values (comma-separated, e.g., 'Low,Medium,High,Critical' "+
+			"or 'skip' to use Jira API values only): ")
 	if err == nil {
-		severityValuesInput = strings.TrimSpace(severityValuesInput)
 		if severityValuesInput != "" && !strings.EqualFold(severityValuesInput, "skip") {
 			values := strings.Split(severityValuesInput, ",")
 			cfg.SeverityValues = make([]string, 0, len(values))
@@ -435,10 +435,8 @@ func promptSeverityValues(reader *bufio.Reader, cfg, existingCfg *config.Config)
 }
 
 func promptBoardID(reader *bufio.Reader, cfg, existingCfg *config.Config) error {
-	fmt.Print("\nDefault board ID (optional, press Enter to skip): ")
-	boardIDInput, err := reader.ReadString('\n')
+	boardIDInput, err := prompt.Line(reader, "default_board_id", "\nDefault board ID (optional, press Enter to skip): ")
 	if err == nil {
-		boardIDInput = strings.TrimSpace(boardIDInput)
 		if boardIDInput != "" {
 			if boardID, err := strconv.Atoi(boardIDInput); err == nil && boardID > 0 {
 				cfg.DefaultBoardID = boardID
@@ -455,14 +453,12 @@ func promptBoardID(reader *bufio.Reader, cfg, existingCfg *config.Config) error
 }
 
 func promptAnswerInputMethod(reader *bufio.Reader, cfg, existingCfg *config.Config) error {
-	prompt := "Answer input method [readline/editor/readline_with_preview]"
+	question := "Answer input method [readline/editor/readline_with_preview]"
 	if existingCfg != nil && existingCfg.AnswerInputMethod != "" {
-		prompt = fmt.Sprintf("%s [%s]", prompt, existingCfg.AnswerInputMethod)
+		question = fmt.Sprintf("%s [%s]", question, existingCfg.AnswerInputMethod)
 	}
-	fmt.Printf("\n%s: ", prompt)
-	answerInputMethodInput, err := reader.ReadString('\n')
+	answerInputMethodInput, err := prompt.Line(reader, "answer_input_method", "\n"+question+": ")
 	if err == nil {
-		answerInputMethodInput = strings.TrimSpace(answerInputMethodInput)
 		if answerInputMethodInput != "" {
 			validMethods := map[string]bool{
 				"readline":              true,
@@ -490,10 +486,9 @@ func promptAnswerInputMethod(reader *bufio.Reader, cfg, existingCfg *config.Conf
 }
 
 func promptTicketFilter(reader *bufio.Reader, cfg, existingCfg *config.Config) error {
-	fmt.Print("\nTicket filter (JQL to append to all ticket queries, optional, press Enter to skip): ")
-	filterInput, err := reader.ReadString('\n')
+	filterInput, err := prompt.Line(reader, "ticket_filter",
+		"\nTicket filter (JQL to append to all ticket queries, optional, press Enter to skip): ")
 	if err == nil {
-		filterInput = strings.TrimSpace(filterInput)
 		if filterInput != "" {
 			cfg.TicketFilter = filterInput
 		} else if existingCfg != nil && existingCfg.TicketFilter != "" {
@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/git"
+	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/prompt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	linkPRTitleFlag       string
+	linkPRStateFlag       string
+	linkPRBranchFlag      bool
+	linkPRBaseFlag        string
+	linkPRDescriptionFlag string
+	linkPRCommitsFlag     bool
+)
+
+var linkPRCmd = &cobra.Command{
+	Use:   "link-pr [TICKET_ID] URL",
+	Short: "Link a pull request, merge request or commit to tickets",
+	Long: `Add a link to a pull/merge request, commit or any other URL to a ticket. The link is
+shown on the ticket in Jira, by 'jira show' and by 'jira status --links'. The title is
+worked out from the URL (e.g. org/repo#12) unless --title is given, and --state
+marks a pull request as open, merged or closed. Linking the same URL again updates it.
+
+Without a ticket ID, the tickets are found by their keys in:
+  --branch            the current git branch's name and its commits since --base
+  --description FILE  a pull request description ('-' for stdin)
+and otherwise in the current branch's name. With --branch and --commits, each commit is
+also linked to the tickets it names.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runLinkPR,
+}
+
+func runLinkPR(_ *cobra.Command, args []string) error {
+	configDir := GetConfigDir()
+	cfg, err := config.LoadConfig(config.GetConfigPath(configDir))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	linkURL := args[len(args)-1]
+	link, err := jira.NewRemoteLink(linkURL, linkPRTitleFlag, linkPRStateFlag)
+	if err != nil {
+		return err
+	}
+
+	var keys []string
+	var commits []git.Commit
+	if len(args) == 2 {
+		keys = []string{normalizeTicketID(strings.ToUpper(args[0]), cfg.DefaultProject)}
+	} else {
+		keys, commits, err = scanTicketKeys()
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			return errors.New("no ticket keys found; give a ticket ID, --branch or --description")
+		}
+
+		fmt.Printf("Found %s\n", strings.Join(keys, ", "))
+		confirmed, err := prompt.Confirm(bufio.NewReader(os.Stdin), "link_tickets",
+			fmt.Sprintf("Link %s to them? [Y/n] ", link.Object.Title), true)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return nil
+		}
+	}
+
+	client, err := newJiraClient(configDir)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, key := range keys {
+		if err := client.AddRemoteLink(key, link); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to link %s: %v\n", key, err)
+			failed++
+			continue
+		}
+		fmt.Printf("Linked %s to %s\n", key, link.Object.Title)
+	}
+
+	if linkPRCommitsFlag {
+		failed += linkCommits(client, linkURL, commits, keys)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d link(s) failed", failed)
+	}
+	return nil
+}
+
+// scanTicketKeys finds ticket keys in the places chosen by the flags, or else in the current branch's
+// name, returning them in the order found with the commits scanned
+func scanTicketKeys() ([]string, []git.Commit, error) {
+	var texts []string
+	var commits []git.Commit
+
+	if linkPRBranchFlag {
+		repo := &git.Repo{}
+		branch, err := repo.CurrentBranch()
+		if err != nil {
+			return nil, nil, err
+		}
+		commits, err = repo.Log(linkPRBaseFlag, "")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read the commits since %s: %w", linkPRBaseFlag, err)
+		}
+		texts = append(texts, branch)
+		for i := range commits {
+			texts = append(texts, commits[i].Message())
+		}
+	}
+
+	if linkPRDescriptionFlag != "" {
+		description, err := readDescription(linkPRDescriptionFlag)
+		if err != nil {
+			return nil, nil, err
+		}
+		texts = append(texts, description)
+	}
+
+	if !linkPRBranchFlag && linkPRDescriptionFlag == "" {
+		if key := branchTicket(); key != "" {
+			texts = append(texts, key)
+		}
+	}
+
+	return jira.FindTicketKeys(strings.Join(texts, "\n")), commits, nil
+}
+
+// readDescription reads a pull request description from a file, or from stdin for "-"
+func readDescription(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read the description: %w", err)
+	}
+	return string(data), nil
+}
+
+// linkCommits links each commit to the tickets it names among keys, returning the number of failures
+func linkCommits(client jira.JiraClient, prURL string, commits []git.Commit, keys []string) int {
+	wanted := map[string]bool{}
+	for _, key := range keys {
+		wanted[key] = true
+	}
+
+	failed := 0
+	for i := range commits {
+		commit := &commits[i]
+		commitLink, err := jira.NewRemoteLink(commitURL(prURL, commit.Hash), "", "")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Can't link commit %s: %v\n", commit.ShortHash(), err)
+			failed++
+			continue
+		}
+		commitLink.Object.Summary = commit.Subject
+
+		for _, key := range jira.FindTicketKeys(commit.Message()) {
+			if !wanted[key] {
+				continue
+			}
+			if err := client.AddRemoteLink(key, commitLink); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to link commit %s to %s: %v\n", commit.ShortHash(), key, err)
+				failed++
+				continue
+			}
+			fmt.Printf("Linked %s to commit %s\n", key, commit.ShortHash())
+		}
+	}
+	return failed
+}
+
+// commitURL returns the URL of a commit in the repository of a pull/merge request URL,
+// e.g. https://github.com/org/repo/commit/HASH for https://github.com/org/repo/pull/12
+func commitURL(prURL, hash string) string {
+	for _, marker := range []string{"/pull/", "/pulls/", "/merge_requests/"} {
+		if i := strings.Index(prURL, marker); i >= 0 {
+			return prURL[:i] + "/commit/" + hash
+		}
+	}
+	if i := strings.Index(prURL, "/pull-requests/"); i >= 0 {
+		return prURL[:i] + "/commits/" + hash
+	}
+	return strings.TrimRight(prURL, "/") + "/commit/" + hash
+}
+
+func init() {
+	linkPRCmd.Flags().StringVar(&linkPRTitleFlag, "title", "", "Title of the link (default: worked out from the URL)")
+	linkPRCmd.Flags().StringVar(&linkPRStateFlag, "state", "", "State of the pull request: open, merged or closed")
+	linkPRCmd.Flags().BoolVar(&linkPRBranchFlag, "branch", false,
+		"Link the tickets named by the current branch and its commits")
+	linkPRCmd.Flags().StringVar(&linkPRBaseFlag, "base", "main", "With --branch, the branch the commits are based on")
+	linkPRCmd.Flags().StringVar(&linkPRDescriptionFlag, "description", "",
+		"Link the tickets named in this pull request description ('-' for stdin)")
+	linkPRCmd.Flags().BoolVar(&linkPRCommitsFlag, "commits", false, "With --branch, also link each commit to its tickets")
+	_ = linkPRCmd.RegisterFlagCompletionFunc("state", cobra.FixedCompletions(
+		[]string{jira.RemoteLinkOpen, jira.RemoteLinkMerged, jira.RemoteLinkClosed}, cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.AddCommand(linkPRCmd)
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCommitURL(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/org/repo/pull/12":                    "https://github.com/org/repo/commit/abc",
+		"https://gitlab.com/group/project/-/merge_requests/5":    "https://gitlab.com/group/project/-/commit/abc",
+		"https://bitbucket.org/org/repo/pull-requests/3":         "https://bitbucket.org/org/repo/commits/abc",
+		"https://git.example.com/org/repo/":                      "https://git.example.com/org/repo/commit/abc",
+		"https://gitea.example.com/org/repo/pulls/8/files?x=y#z": "https://gitea.example.com/org/repo/commit/abc",
+	}
+	for prURL, want := range tests {
+		if got := commitURL(prURL, "abc"); got != want {
+			t.Errorf("commitURL(%q) = %q, want %q", prURL, got, want)
+		}
+	}
+}
+
+func TestScanTicketKeys(t *testing.T) {
+	inTestRepo(t, "main")
+	for _, args := range [][]string{
+		{"checkout", "-q", "-b", "ENG-4-login"},
+		{"-c", "user.email=test@example.com", "-c", "user.name=Test", "commit", "-q", "--allow-empty",
+			"-m", "Fix login\n\nRefs ENG-5"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+	}
+
+	description := filepath.Join(t.TempDir(), "pr.md")
+	if err := os.WriteFile(description, []byte("Closes OPS-2 and ENG-5"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	linkPRBranchFlag, linkPRBaseFlag, linkPRDescriptionFlag = true, "main", description
+	defer func() { linkPRBranchFlag, linkPRBaseFlag, linkPRDescriptionFlag = false, "main", "" }()
+
+	keys, commits, err := scanTicketKeys()
+	if err != nil {
+		t.Fatalf("scanTicketKeys failed: %v", err)
+	}
+	if strings.Join(keys, ",") != "ENG-4,ENG-5,OPS-2" || len(commits) != 1 {
+		t.Errorf("Expected the branch, commit and description keys, got %v and %d commits", keys, len(commits))
+	}
+
+	linkPRBranchFlag, linkPRDescriptionFlag = false, ""
+	if keys, _, _ := scanTicketKeys(); strings.Join(keys, ",") != "ENG-4" {
+		t.Errorf("Expected the branch's ticket, got %v", keys)
+	}
+}
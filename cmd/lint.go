@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/lint"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintRulesFlag     string
+	lintOutputFlag    string
+	lintFailOnFlag    string
+	lintListRulesFlag bool
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [JQL]",
+	Short: "Check tickets against the definition of ready",
+	Long: `Check tickets for hygiene problems such as missing descriptions, components or
+story points, unassigned tickets in an active sprint, epics with unestimated children,
+spikes without a timebox and closed tickets with open children.
+
+Tickets are selected with JQL. Without a query, unresolved tickets in the default project
+and tickets resolved in the last week are checked.
+
+Rules are configured in ~/.jira-tool/lint.yaml (or --rules), with a severity per rule
+and optional per-project overrides. Exits with a non-zero status if any finding is at
+least as severe as --fail-on (default: error), so it can run in CI.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runLint,
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	configDir := GetConfigDir()
+
+	rulesPath := lintRulesFlag
+	if rulesPath == "" {
+		rulesPath = lint.GetRulesPath(configDir)
+	}
+	ruleSet, err := lint.LoadRuleSet(rulesPath)
+	if err != nil {
+		return err
+	}
+
+	if lintListRulesFlag {
+		printLintRules(ruleSet)
+		return nil
+	}
+
+	failOn, err := lint.ParseSeverity(lintFailOnFlag)
+	if err != nil {
+		return fmt.Errorf("invalid --fail-on: %w", err)
+	}
+	if lintOutputFlag != "table" && lintOutputFlag != "json" {
+		return fmt.Errorf("invalid --output %q (expected table or json)", lintOutputFlag)
+	}
+
+	cfg, err := config.LoadConfig(config.GetConfigPath(configDir))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	client, err := newJiraClient(configDir)
+	if err != nil {
+		return err
+	}
+
+	var jql string
+	if len(args) > 0 {
+		jql = args[0]
+	} else {
+		if cfg.DefaultProject == "" {
+			return fmt.Errorf("default_project not configured. Please run 'jira init' or pass a JQL query")
+		}
+		jql = fmt.Sprintf("project = %s AND (resolution = Unresolved OR resolved >= -7d) ORDER BY key",
+			cfg.DefaultProject)
+	}
+	jql = jira.ApplyTicketFilter(jql, GetTicketFilter(cfg))
+
+	issues, err := client.SearchTickets(jql)
+	if err != nil {
+		return fmt.Errorf("failed to search tickets: %w", err)
+	}
+
+	result, err := lint.Run(lint.NewContext(client, cfg, issues), ruleSet, issues)
+	if err != nil {
+		return err
+	}
+
+	if lintOutputFlag == "json" {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal lint results: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		printLintResult(result)
+	}
+
+	if failOn != lint.SeverityOff && result.Failed(failOn) {
+		// The findings have been reported; only the exit status is needed
+		cmd.SilenceUsage = true
+		return fmt.Errorf("lint found %d error(s), %d warning(s)",
+			result.Count(lint.SeverityError), result.Count(lint.SeverityWarning))
+	}
+
+	return nil
+}
+
+func printLintResult(result *lint.Result) {
+	if len(result.Findings) == 0 {
+		fmt.Printf("Checked %d tickets: no problems found\n", result.Checked)
+		return
+	}
+
+	fmt.Printf("%-12s %-8s %-28s %s\n", "KEY", "SEVERITY", "RULE", "MESSAGE")
+	fmt.Println(strings.Repeat("-", 90))
+	for _, f := range result.Findings {
+		fmt.Printf("%-12s %-8s %-28s %s\n", f.Key, f.Severity, f.Rule, f.Message)
+	}
+
+	fmt.Printf("\nChecked %d tickets: %d error(s), %d warning(s), %d info\n", result.Checked,
+		result.Count(lint.SeverityError), result.Count(lint.SeverityWarning), result.Count(lint.SeverityInfo))
+}
+
+func printLintRules(ruleSet *lint.RuleSet) {
+	fmt.Printf("%-28s %-8s %s\n", "RULE", "SEVERITY", "DESCRIPTION")
+	for _, rule := range lint.Rules {
+		rc := ruleSet.Effective(rule, "")
+		fmt.Printf("%-28s %-8s %s\n", rule.ID, rc.Severity, rule.Description)
+	}
+	if len(ruleSet.Projects) > 0 {
+		fmt.Println("\nProject overrides are configured for:", strings.Join(sortedProjects(ruleSet), ", "))
+	}
+}
+
+func sortedProjects(ruleSet *lint.RuleSet) []string {
+	projects := make([]string, 0, len(ruleSet.Projects))
+	for project := range ruleSet.Projects {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+	return projects
+}
+
+func init() {
+	lintCmd.Flags().StringVar(&lintRulesFlag, "rules", "", "Rules file (default: lint.yaml in the config directory)")
+	lintCmd.Flags().StringVarP(&lintOutputFlag, "output", "o", "table", "Output format: table or json")
+	lintCmd.Flags().StringVar(&lintFailOnFlag, "fail-on", "error",
+		"Exit non-zero if any finding is at least this severe (error, warning, info or off)")
+	lintCmd.Flags().BoolVar(&lintListRulesFlag, "list-rules", false, "List the rules and their severities")
+	rootCmd.AddCommand(lintCmd)
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/gemini"
+	"github.com/beekhof/jira-tool/pkg/git"
+	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/releasenotes"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	releaseNotesProjectFlag   string
+	releaseNotesFromFlag      string
+	releaseNotesToFlag        string
+	releaseNotesOutputFlag    string
+	releaseNotesSummarizeFlag bool
+)
+
+var releaseNotesCmd = &cobra.Command{
+	Use:   "release-notes [VERSION]",
+	Short: "Generate release notes for a release",
+	Long: `Generate release notes from the tickets in a release (fix version), grouped by
+issue type and component, as Markdown or HTML. Without a version, the nearest
+unreleased one is used.
+
+With --from, the commits between --from and --to (default HEAD) in the current git
+repository are matched to tickets by the keys in their messages. Each ticket lists
+its commits, and tickets without commits, commits without tickets and commits for
+tickets outside the release are listed at the end.
+
+With --summarize, Gemini writes a short summary of each group for users.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runReleaseNotes,
+}
+
+func runReleaseNotes(_ *cobra.Command, args []string) error {
+	if releaseNotesOutputFlag != releasenotes.FormatMarkdown && releaseNotesOutputFlag != releasenotes.FormatHTML {
+		return fmt.Errorf("invalid --output %q (expected markdown or html)", releaseNotesOutputFlag)
+	}
+
+	configDir := GetConfigDir()
+	cfg, err := config.LoadConfig(config.GetConfigPath(configDir))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	client, err := newJiraClient(configDir)
+	if err != nil {
+		return err
+	}
+
+	projectKey := strings.ToUpper(releaseNotesProjectFlag)
+	if projectKey == "" {
+		projectKey = cfg.DefaultProject
+	}
+	if projectKey == "" {
+		return fmt.Errorf("default_project not configured. Please run 'jira init' or use --project")
+	}
+
+	var release jira.ReleaseParsed
+	if len(args) > 0 {
+		release, err = findRelease(client, projectKey, args[0])
+	} else {
+		release, err = selectReleaseForStatus(client, projectKey)
+	}
+	if err != nil {
+		return err
+	}
+
+	issues, err := client.GetIssuesForRelease(release.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch tickets for %s: %w", release.Name, err)
+	}
+
+	var commits []git.Commit
+	crossReference := releaseNotesFromFlag != ""
+	if crossReference {
+		commits, err = (&git.Repo{}).Log(releaseNotesFromFlag, releaseNotesToFlag)
+		if err != nil {
+			return fmt.Errorf("failed to read the git log: %w", err)
+		}
+	}
+
+	notes := releasenotes.Build(release.Name, issues, commits, crossReference)
+	notes.BaseURL = cfg.JiraURL
+
+	if releaseNotesSummarizeFlag && len(notes.Groups) > 0 {
+		geminiClient, err := gemini.NewClient(configDir, GetNoCache())
+		if err != nil {
+			return fmt.Errorf("failed to create Gemini client: %w", err)
+		}
+		if err := releasenotes.Summarize(notes, geminiClient.SummarizeReleaseGroup); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+
+	return releasenotes.Render(os.Stdout, notes, releaseNotesOutputFlag)
+}
+
+// findRelease returns the project's release with the given name or ID
+func findRelease(client jira.JiraClient, projectKey, version string) (jira.ReleaseParsed, error) {
+	releases, err := client.GetReleases(projectKey)
+	if err != nil {
+		return jira.ReleaseParsed{}, err
+	}
+	for _, release := range releases {
+		if strings.EqualFold(release.Name, version) || release.ID == version {
+			return release, nil
+		}
+	}
+	return jira.ReleaseParsed{}, fmt.Errorf("release %q not found in %s", version, projectKey)
+}
+
+func init() {
+	releaseNotesCmd.Flags().StringVar(&releaseNotesProjectFlag, "project", "", "Project of the release (default: default_project)")
+	releaseNotesCmd.Flags().StringVar(&releaseNotesFromFlag, "from", "",
+		"Match tickets to the commits after this git ref (e.g. the previous release's tag)")
+	releaseNotesCmd.Flags().StringVar(&releaseNotesToFlag, "to", "", "Last git ref of the release, with --from (default: HEAD)")
+	releaseNotesCmd.Flags().StringVarP(&releaseNotesOutputFlag, "output", "o", releasenotes.FormatMarkdown,
+		"Output format: markdown or html")
+	releaseNotesCmd.Flags().BoolVar(&releaseNotesSummarizeFlag, "summarize", false,
+		"Summarize each group for users with Gemini")
+	rootCmd.AddCommand(releaseNotesCmd)
+}
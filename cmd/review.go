@@ -8,33 +8,50 @@ import (
 	"strings"
 
 	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/estimate"
 	"github.com/beekhof/jira-tool/pkg/gemini"
 	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/prompt"
 	"github.com/beekhof/jira-tool/pkg/review"
+	"github.com/beekhof/jira-tool/pkg/tui"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	needsDetailFlag bool
-	unassignedFlag  bool
-	untriagedFlag   bool
-	pageSizeFlag    int
-	noPagingFlag    bool
+	needsDetailFlag   bool
+	unassignedFlag    bool
+	untriagedFlag     bool
+	pageSizeFlag      int
+	noPagingFlag      bool
+	reviewResumeFlag  bool
+	reviewSessionFlag string
+	reviewTUIFlag     bool
 )
 
 var reviewCmd = &cobra.Command{
 	Use:   "review [TICKET_ID]",
 	Short: "Review and triage tickets",
 	Long: `Review tickets interactively. You can review a specific ticket by ID,
-or review a queue of tickets based on filters.`,
+or review a queue of tickets based on filters. On a git branch named after a ticket,
+with no flags, that ticket is reviewed instead; use --no-branch for the queue.
+
+Reviews of a queue are saved as a session after every change, including the steps
+done or skipped on each ticket. Continue the most recent one with --resume, or a
+named one with --session NAME. Sessions can be handed to someone else with
+'jira utils review-sessions export' and 'import'.
+
+With --tui the queue is shown full screen, with the highlighted ticket's details
+beside the list and keys to run the whole review or a single step.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runReview,
 }
 
-func runReview(_ *cobra.Command, args []string) error {
+func runReview(cmd *cobra.Command, args []string) error {
+	args = queueTicketArgs(cmd, args)
+
 	configDir := GetConfigDir()
-	client, err := jira.NewClient(configDir, GetNoCache())
+	client, err := newJiraClient(configDir)
 	if err != nil {
 		return err
 	}
@@ -44,9 +61,25 @@ func runReview(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	if err := review.ValidateWorkflow(&cfg.ReviewWorkflow); err != nil {
+		return err
+	}
 
 	filter := GetTicketFilter(cfg)
-	issues, err := fetchReviewTickets(client, cfg, args, filter)
+	reader := bufio.NewReader(os.Stdin)
+
+	if len(args) == 1 {
+		if reviewResumeFlag || reviewSessionFlag != "" {
+			return fmt.Errorf("--resume and --session apply to reviews of a ticket queue, not a single ticket")
+		}
+		issues, err := fetchSingleTicket(client, cfg, args[0], filter)
+		if err != nil {
+			return err
+		}
+		return handleSingleTicketReview(client, reader, cfg, &issues[0], configDir)
+	}
+
+	rs, issues, err := openReviewSession(client, cfg, configDir, filter)
 	if err != nil {
 		return err
 	}
@@ -56,21 +89,11 @@ func runReview(_ *cobra.Command, args []string) error {
 		return nil
 	}
 
-	reader := bufio.NewReader(os.Stdin)
-	if len(issues) == 1 {
+	if len(issues) == 1 && !rs.resumed {
 		return handleSingleTicketReview(client, reader, cfg, &issues[0], configDir)
 	}
 
-	return handleMultipleTicketsReview(client, reader, cfg, issues, configDir)
-}
-
-func fetchReviewTickets(
-	client jira.JiraClient, cfg *config.Config, args []string, filter string,
-) ([]jira.Issue, error) {
-	if len(args) == 1 {
-		return fetchSingleTicket(client, cfg, args[0], filter)
-	}
-	return fetchTicketsByFlags(client, cfg, filter)
+	return handleMultipleTicketsReview(client, reader, cfg, issues, configDir, rs)
 }
 
 func fetchSingleTicket(client jira.JiraClient, cfg *config.Config, ticketArg, filter string) ([]jira.Issue, error) {
@@ -87,12 +110,6 @@ func fetchSingleTicket(client jira.JiraClient, cfg *config.Config, ticketArg, fi
 	return issues, nil
 }
 
-func fetchTicketsByFlags(client jira.JiraClient, cfg *config.Config, filter string) ([]jira.Issue, error) {
-	jql := buildReviewJQL(cfg)
-	jql = jira.ApplyTicketFilter(jql, filter)
-	return client.SearchTickets(jql)
-}
-
 func buildReviewJQL(cfg *config.Config) string {
 	var jqlParts []string
 	project := cfg.DefaultProject
@@ -131,14 +148,16 @@ func handleSingleTicketReview(
 	client jira.JiraClient, reader *bufio.Reader, cfg *config.Config,
 	issue *jira.Issue, configDir string,
 ) error {
-	geminiClient, err := gemini.NewClient(configDir)
+	geminiClient, err := gemini.NewClient(configDir, GetNoCache())
 	if err != nil {
 		fmt.Printf("Warning: Could not initialize Gemini client: %v\n", err)
 		fmt.Println("Continuing without AI features...")
 		geminiClient = nil
 	}
 
-	if err := review.ProcessTicketWorkflow(client, geminiClient, reader, cfg, issue, configDir); err != nil {
+	defaults := reviewStepDefaults(client, cfg, configDir, issue)
+	err = review.ProcessTicketWorkflow(client, geminiClient, reader, cfg, issue, configDir, defaults, nil)
+	if err != nil {
 		return fmt.Errorf("workflow error: %w", err)
 	}
 	return nil
@@ -146,14 +165,25 @@ func handleSingleTicketReview(
 
 func handleMultipleTicketsReview(
 	client jira.JiraClient, reader *bufio.Reader, cfg *config.Config,
-	issues []jira.Issue, configDir string,
+	issues []jira.Issue, configDir string, rs *reviewSession,
 ) error {
+	if !prompt.Interactive() {
+		return fmt.Errorf("choosing tickets to review needs a terminal; give a TICKET_ID instead")
+	}
+
+	if reviewTUIFlag {
+		return runReviewTUI(client, initializeGeminiClient(configDir), reader, cfg, issues, configDir, rs)
+	}
+
 	pageSize := calculatePageSize(cfg)
 	geminiClient := initializeGeminiClient(configDir)
-	selected := make(map[string]bool)
-	actedOn := make(map[string]bool)
-	currentPage := 0
+	selected, actedOn := rs.restoreSelection()
 	totalPages := (len(issues) + pageSize - 1) / pageSize
+	currentPage := rs.session.Page
+	if currentPage >= totalPages {
+		currentPage = totalPages - 1
+	}
+	defer rs.close()
 
 	for {
 		start := currentPage * pageSize
@@ -180,7 +210,7 @@ func handleMultipleTicketsReview(
 			if geminiClient == nil {
 				geminiClient = initializeGeminiClient(configDir)
 			}
-			return reviewSelectedTickets(client, geminiClient, reader, cfg, issues, selected, actedOn, configDir)
+			return reviewSelectedTickets(client, geminiClient, reader, cfg, issues, selected, actedOn, configDir, rs)
 		}
 		if action == "toggle" {
 			ticketNum, err := strconv.Atoi(input)
@@ -189,6 +219,7 @@ func handleMultipleTicketsReview(
 			}
 		}
 		currentPage = newPage
+		rs.save(selected, actedOn, currentPage)
 	}
 }
 
@@ -207,7 +238,7 @@ func calculatePageSize(cfg *config.Config) int {
 }
 
 func initializeGeminiClient(configDir string) gemini.GeminiClient {
-	geminiClient, err := gemini.NewClient(configDir)
+	geminiClient, err := gemini.NewClient(configDir, GetNoCache())
 	if err != nil {
 		fmt.Printf("Warning: Could not initialize Gemini client: %v\n", err)
 		fmt.Println("Continuing without AI features...")
@@ -345,6 +376,7 @@ func reviewSelectedTickets(
 	allIssues []jira.Issue,
 	selected, actedOn map[string]bool,
 	configDir string,
+	rs *reviewSession,
 ) error {
 	// Get list of selected tickets
 	selectedTickets := []jira.Issue{}
@@ -365,15 +397,16 @@ func reviewSelectedTickets(
 		ticket := &selectedTickets[i]
 		fmt.Printf("=== [%d/%d] %s - %s ===\n", i+1, len(selectedTickets), ticket.Key, ticket.Fields.Summary)
 
-		if err := review.ProcessTicketWorkflow(client, geminiClient, reader, cfg, ticket, configDir); err != nil {
+		defaults := reviewStepDefaults(client, cfg, configDir, ticket)
+		progress := rs.progress(ticket.Key, selected, actedOn)
+		err := review.ProcessTicketWorkflow(client, geminiClient, reader, cfg, ticket, configDir, defaults, progress)
+		if err != nil {
 			fmt.Printf("Error in workflow for %s: %v\n", ticket.Key, err)
-			fmt.Print("Continue with next ticket? [Y/n] ")
-			response, readErr := reader.ReadString('\n')
+			next, readErr := prompt.Confirm(reader, "continue_review", "Continue with next ticket? [Y/n] ", true)
 			if readErr != nil {
 				return fmt.Errorf("failed to read response: %w", readErr)
 			}
-			response = strings.TrimSpace(strings.ToLower(response))
-			if response == "n" || response == "no" {
+			if !next {
 				return fmt.Errorf("review canceled")
 			}
 			continue
@@ -382,6 +415,7 @@ func reviewSelectedTickets(
 		// Mark as acted on and clear selection
 		actedOn[ticket.Key] = true
 		selected[ticket.Key] = false
+		rs.save(selected, actedOn, rs.session.Page)
 		fmt.Printf("✓ Completed review for %s\n\n", ticket.Key)
 	}
 
@@ -429,6 +463,9 @@ func handleAssign(client jira.JiraClient, reader *bufio.Reader, _ *config.Config
 func selectUserForAssignmentInReview(
 	client jira.JiraClient, reader *bufio.Reader, recent []string,
 ) (jira.User, string, error) {
+	if tui.Pickable("user") {
+		return review.PickUser(client, recent)
+	}
 	if len(recent) > 0 {
 		user, identifier, err := selectUserFromRecentInReview(client, reader, recent)
 		if err == nil && user.AccountID != "" {
@@ -447,13 +484,11 @@ func selectUserFromRecentInReview(
 		fmt.Printf("[%d] %s\n", i+1, userID)
 	}
 	fmt.Printf("[%d] Other...\n", len(recent)+1)
-	fmt.Print("> ")
 
-	choice, err := reader.ReadString('\n')
+	choice, err := prompt.Line(reader, "assignee", "> ")
 	if err != nil {
 		return jira.User{}, "", err
 	}
-	choice = strings.TrimSpace(choice)
 	selected, err := strconv.Atoi(choice)
 	if err != nil {
 		return jira.User{}, "", fmt.Errorf("invalid selection: %s", choice)
@@ -475,12 +510,10 @@ func selectUserFromRecentInReview(
 }
 
 func selectUserFromSearchInReview(client jira.JiraClient, reader *bufio.Reader) (jira.User, string, error) {
-	fmt.Print("Search for user: ")
-	query, err := reader.ReadString('\n')
+	query, err := prompt.Line(reader, "user_search", "Search for user: ")
 	if err != nil {
 		return jira.User{}, "", err
 	}
-	query = strings.TrimSpace(query)
 
 	users, err := client.SearchUsers(query)
 	if err != nil {
@@ -495,13 +528,11 @@ func selectUserFromSearchInReview(client jira.JiraClient, reader *bufio.Reader)
 	for i, user := range users {
 		fmt.Printf("[%d] %s (%s) [AccountID: %s]\n", i+1, user.DisplayName, user.Name, user.AccountID)
 	}
-	fmt.Print("Select user number: ")
 
-	choice, err := reader.ReadString('\n')
+	choice, err := prompt.Line(reader, "user", "Select user number: ")
 	if err != nil {
 		return jira.User{}, "", err
 	}
-	choice = strings.TrimSpace(choice)
 	selected, err := strconv.Atoi(choice)
 	if err != nil {
 		return jira.User{}, "", fmt.Errorf("invalid selection: %s", choice)
@@ -530,13 +561,11 @@ func handleTriage(client jira.JiraClient, reader *bufio.Reader, ticketID string)
 	for i, p := range priorities {
 		fmt.Printf("[%d] %s\n", i+1, p.Name)
 	}
-	fmt.Print("> ")
 
-	choice, err := reader.ReadString('\n')
+	choice, err := prompt.Line(reader, "priority", "> ")
 	if err != nil {
 		return err
 	}
-	choice = strings.TrimSpace(choice)
 	selected, err := strconv.Atoi(choice)
 	if err != nil {
 		return fmt.Errorf("invalid selection: %s", choice)
@@ -572,7 +601,6 @@ func handleEstimate(client jira.JiraClient, reader *bufio.Reader, cfg *config.Co
 	}
 
 	ticket := issues[0]
-	summary := ticket.Fields.Summary
 	description, err := client.GetTicketDescription(ticketID)
 	if err != nil {
 		// Description might be empty, that's okay
@@ -580,24 +608,25 @@ func handleEstimate(client jira.JiraClient, reader *bufio.Reader, cfg *config.Co
 	}
 
 	// Get Gemini estimate
+	var suggestedPoints int
 	fmt.Println("Getting AI story point estimate...")
 	configDir := GetConfigDir()
-	geminiClient, err := gemini.NewClient(configDir)
+	geminiClient, err := gemini.NewClient(configDir, GetNoCache())
 	if err != nil {
 		// If Gemini fails, continue with manual selection
 		fmt.Printf("Warning: Could not initialize Gemini client: %v\n", err)
 		fmt.Println("Continuing with manual selection...")
 	} else {
-		estimate, reasoning, err := geminiClient.EstimateStoryPoints(summary, description, storyPoints)
+		geminiClient.SetTicketKey(ticketID)
+		aiPoints, reasoning, references, err := estimate.EstimateWithReferences(
+			geminiClient, client, &ticket, description, storyPoints,
+			estimate.ReferenceCount(cfg.EstimateReferenceCount))
 		if err != nil {
 			fmt.Printf("Warning: Could not get AI estimate: %v\n", err)
 			fmt.Println("Continuing with manual selection...")
 		} else {
-			fmt.Printf("\n🤖 AI Estimate: %d story points\n", estimate)
-			if reasoning != "" {
-				fmt.Printf("   Reasoning: %s\n", reasoning)
-			}
-			fmt.Println()
+			printAIEstimate(aiPoints, reasoning, references)
+			suggestedPoints = aiPoints
 		}
 	}
 
@@ -608,13 +637,12 @@ func handleEstimate(client jira.JiraClient, reader *bufio.Reader, cfg *config.Co
 		fmt.Printf("[%s] %d\n", letter, points)
 	}
 	fmt.Println("Or enter a number directly")
-	fmt.Print("> ")
 
-	input, err := reader.ReadString('\n')
+	input, err := prompt.Line(reader, "points", "> ")
 	if err != nil {
 		return fmt.Errorf("failed to read input: %w", err)
 	}
-	input = strings.TrimSpace(strings.ToLower(input))
+	input = strings.ToLower(input)
 
 	var points int
 	// Try to parse as number first
@@ -637,7 +665,11 @@ func handleEstimate(client jira.JiraClient, reader *bufio.Reader, cfg *config.Co
 		return fmt.Errorf("invalid input: %s (use a letter or number)", input)
 	}
 
-	return client.UpdateTicketPoints(ticketID, points)
+	if err := client.UpdateTicketPoints(ticketID, points); err != nil {
+		return err
+	}
+	estimate.RecordEstimate(configDir, &ticket, suggestedPoints, points)
+	return nil
 }
 
 func init() {
@@ -646,5 +678,10 @@ func init() {
 	reviewCmd.Flags().BoolVar(&untriagedFlag, "untriaged", false, "Show only untriaged tickets")
 	reviewCmd.Flags().IntVar(&pageSizeFlag, "page-size", 0, "Number of tickets per page (0 = use config default)")
 	reviewCmd.Flags().BoolVar(&noPagingFlag, "no-paging", false, "Disable paging and show all tickets at once")
+	reviewCmd.Flags().BoolVar(&reviewResumeFlag, "resume", false, "Continue the most recent review session")
+	reviewCmd.Flags().StringVar(&reviewSessionFlag, "session", "",
+		"Continue the named review session, or start one with this name")
+	reviewCmd.Flags().BoolVar(&reviewTUIFlag, "tui", false,
+		"Show the ticket queue full screen with a detail pane and inline actions")
 	rootCmd.AddCommand(reviewCmd)
 }
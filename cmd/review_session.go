@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/review"
+
+	"github.com/spf13/cobra"
+)
+
+// reviewSession ties a multi-ticket review to its saved session
+type reviewSession struct {
+	store   *review.SessionStore
+	session *review.Session
+	resumed bool
+}
+
+// openReviewSession resumes the session chosen with --resume or --session, or starts a new
+// one from the review filters, and returns it with the tickets in its queue
+func openReviewSession(
+	client jira.JiraClient, cfg *config.Config, configDir, filter string,
+) (*reviewSession, []jira.Issue, error) {
+	store := review.NewSessionStore(review.GetSessionsDir(configDir))
+
+	var session *review.Session
+	var err error
+	switch {
+	case reviewSessionFlag != "":
+		session, err = store.Load(reviewSessionFlag)
+	case reviewResumeFlag:
+		session, err = store.Latest()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if session != nil {
+		fmt.Printf("Resuming review session %s (%d of %d ticket(s) left",
+			session.Name, session.Remaining(), len(session.Keys))
+		if session.UpdatedBy != "" {
+			fmt.Printf(", last saved by %s", session.UpdatedBy)
+		}
+		fmt.Println(")")
+		issues, err := fetchSessionTickets(client, session.Keys)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &reviewSession{store: store, session: session, resumed: true}, issues, nil
+	}
+	if reviewResumeFlag && reviewSessionFlag == "" {
+		return nil, nil, fmt.Errorf("no review session to resume")
+	}
+
+	jql := jira.ApplyTicketFilter(buildReviewJQL(cfg), filter)
+	issues, err := client.SearchTickets(jql)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	name := reviewSessionFlag
+	if name == "" {
+		name = review.DefaultSessionName(time.Now())
+	}
+	keys := make([]string, len(issues))
+	for i := range issues {
+		keys[i] = issues[i].Key
+	}
+	return &reviewSession{store: store, session: review.NewSession(name, jql, keys)}, issues, nil
+}
+
+// fetchSessionTickets fetches the tickets in a session's queue, in queue order
+// Tickets that no longer exist (or can't be seen) are left out
+func fetchSessionTickets(client jira.JiraClient, keys []string) ([]jira.Issue, error) {
+	byKey := map[string]jira.Issue{}
+	const batchSize = 100
+	for start := 0; start < len(keys); start += batchSize {
+		end := start + batchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		issues, err := client.SearchTickets(fmt.Sprintf("key in (%s)", strings.Join(keys[start:end], ", ")))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch session tickets: %w", err)
+		}
+		for i := range issues {
+			byKey[issues[i].Key] = issues[i]
+		}
+	}
+
+	issues := []jira.Issue{}
+	for _, key := range keys {
+		if issue, ok := byKey[key]; ok {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// restoreSelection returns the selected and reviewed tickets saved in the session
+func (rs *reviewSession) restoreSelection() (selected, actedOn map[string]bool) {
+	selected = make(map[string]bool)
+	actedOn = make(map[string]bool)
+	for _, key := range rs.session.Selected {
+		selected[key] = true
+	}
+	for _, key := range rs.session.ActedOn {
+		actedOn[key] = true
+	}
+	return selected, actedOn
+}
+
+// save writes the current selection, reviewed tickets and page to the session
+func (rs *reviewSession) save(selected, actedOn map[string]bool, page int) {
+	rs.session.Selected = rs.session.Selected[:0]
+	rs.session.ActedOn = rs.session.ActedOn[:0]
+	for _, key := range rs.session.Keys {
+		if selected[key] {
+			rs.session.Selected = append(rs.session.Selected, key)
+		}
+		if actedOn[key] {
+			rs.session.ActedOn = append(rs.session.ActedOn, key)
+		}
+	}
+	rs.session.Page = page
+
+	if err := rs.store.Save(rs.session); err != nil {
+		fmt.Printf("Warning: Could not save review session: %v\n", err)
+	}
+}
+
+// progress returns the step progress for a ticket, saving the session after each step
+func (rs *reviewSession) progress(key string, selected, actedOn map[string]bool) *review.TicketProgress {
+	progress := rs.session.Progress(key)
+	progress.OnUpdate = func() {
+		rs.save(selected, actedOn, rs.session.Page)
+	}
+	return progress
+}
+
+// close removes the session once every ticket has been reviewed, or says how to continue it
+func (rs *reviewSession) close() {
+	if rs.session.Remaining() == 0 {
+		if err := rs.store.Delete(rs.session.Name); err != nil {
+			_ = err // Ignore - a stale session only shows up in 'utils review-sessions'
+		}
+		return
+	}
+
+	saved, err := rs.store.Load(rs.session.Name)
+	if err != nil || saved == nil {
+		// Nothing was changed, so there is nothing to continue
+		return
+	}
+	fmt.Printf("Review session saved. Run 'jira review --session %s' to continue.\n", rs.session.Name)
+}
+
+var reviewSessionsCmd = &cobra.Command{
+	Use:   "review-sessions",
+	Short: "List saved review sessions",
+	Long: `List saved multi-ticket review sessions. Sessions are saved by 'jira review'
+after every change and can be continued with 'jira review --session NAME'.`,
+	Args: cobra.NoArgs,
+	RunE: runReviewSessionsList,
+}
+
+var reviewSessionsDiscardCmd = &cobra.Command{
+	Use:   "discard NAME",
+	Short: "Discard a saved review session",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runReviewSessionsDiscard,
+}
+
+var reviewSessionsExportCmd = &cobra.Command{
+	Use:   "export NAME FILE",
+	Short: "Write a review session to a file to hand it to someone else",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runReviewSessionsExport,
+}
+
+var reviewSessionsImportCmd = &cobra.Command{
+	Use:   "import FILE",
+	Short: "Import a review session exported by someone else",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runReviewSessionsImport,
+}
+
+var reviewSessionsImportName string
+
+func runReviewSessionsList(_ *cobra.Command, _ []string) error {
+	store := review.NewSessionStore(review.GetSessionsDir(GetConfigDir()))
+	sessions, err := store.List()
+	if err != nil {
+		return err
+	}
+
+	if len(sessions) == 0 {
+		fmt.Println("No saved review sessions.")
+		return nil
+	}
+
+	for _, session := range sessions {
+		fmt.Printf("%-24s  %d of %d ticket(s) left  updated %s",
+			session.Name, session.Remaining(), len(session.Keys), session.UpdatedAt.Local().Format("2006-01-02 15:04"))
+		if session.UpdatedBy != "" {
+			fmt.Printf(" by %s", session.UpdatedBy)
+		}
+		fmt.Println()
+		fmt.Printf("    jira review --session %s\n", session.Name)
+	}
+
+	return nil
+}
+
+func runReviewSessionsDiscard(_ *cobra.Command, args []string) error {
+	store := review.NewSessionStore(review.GetSessionsDir(GetConfigDir()))
+	session, err := store.Load(args[0])
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return fmt.Errorf("review session %s not found", args[0])
+	}
+
+	if err := store.Delete(session.Name); err != nil {
+		return err
+	}
+	fmt.Printf("Discarded review session %s.\n", session.Name)
+	return nil
+}
+
+func runReviewSessionsExport(_ *cobra.Command, args []string) error {
+	store := review.NewSessionStore(review.GetSessionsDir(GetConfigDir()))
+	session, err := store.Load(args[0])
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return fmt.Errorf("review session %s not found", args[0])
+	}
+
+	if err := review.WriteSession(session, args[1]); err != nil {
+		return err
+	}
+	fmt.Printf("Exported review session %s to %s.\n", session.Name, args[1])
+	return nil
+}
+
+func runReviewSessionsImport(_ *cobra.Command, args []string) error {
+	session, err := review.ReadSession(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read review session: %w", err)
+	}
+	if reviewSessionsImportName != "" {
+		session.Name = reviewSessionsImportName
+	}
+
+	store := review.NewSessionStore(review.GetSessionsDir(GetConfigDir()))
+	existing, err := store.Load(session.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("review session %s already exists; use --name to import it under another name", session.Name)
+	}
+
+	if err := store.Save(session); err != nil {
+		return err
+	}
+	fmt.Printf("Imported review session %s (%d of %d ticket(s) left).\n",
+		session.Name, session.Remaining(), len(session.Keys))
+	fmt.Printf("Run 'jira review --session %s' to continue.\n", session.Name)
+	return nil
+}
+
+func init() {
+	reviewSessionsImportCmd.Flags().StringVar(&reviewSessionsImportName, "name", "",
+		"Import under this name instead of the exported one")
+	reviewSessionsCmd.AddCommand(reviewSessionsDiscardCmd, reviewSessionsExportCmd, reviewSessionsImportCmd)
+	utilsCmd.AddCommand(reviewSessionsCmd)
+}
@@ -1,12 +1,17 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/gemini"
+	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/prompt"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var (
@@ -14,6 +19,13 @@ var (
 	noCache      bool
 	filterFlag   string
 	noFilterFlag bool
+	dryRunFlag   bool
+	dryRunOutput string
+	yesFlag      bool
+	answersFlag  string
+	noBranchFlag bool
+	// dryRunPlan collects the changes of a --dry-run; shared by all clients of the run
+	dryRunPlan *jira.DryRunPlan
 )
 
 var rootCmd = &cobra.Command{
@@ -21,11 +33,36 @@ var rootCmd = &cobra.Command{
 	Short: "A CLI tool to streamline Jira workflows",
 	Long: `jira-tool is a command-line tool that helps you manage Jira tickets
 more efficiently by integrating with Jira and Gemini APIs.`,
+	PersistentPreRunE: configurePrompts,
+}
+
+// configurePrompts decides how prompts are answered: from --answers and --yes, then at the
+// terminal. When stdin isn't a terminal, a prompt without an answer fails instead of blocking.
+func configurePrompts(_ *cobra.Command, _ []string) error {
+	var answers prompt.Answers
+	if answersFlag != "" {
+		var err error
+		answers, err = prompt.LoadAnswers(answersFlag)
+		if err != nil {
+			return err
+		}
+	}
+	prompt.Configure(answers, term.IsTerminal(int(os.Stdin.Fd())), yesFlag)
+	return nil
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
+// If the command used Gemini, a usage summary is printed once it finishes.
 func Execute() error {
-	return rootCmd.Execute()
+	registerCompletions(rootCmd)
+	err := rootCmd.Execute()
+	if planErr := reportDryRun(); planErr != nil && err == nil {
+		err = planErr
+	}
+	if summary := gemini.FormatUsageSummary(GetConfigDir()); summary != "" {
+		fmt.Fprintf(os.Stderr, "\n%s\n", summary)
+	}
+	return err
 }
 
 // GetConfigDir returns the configured config directory, or the default
@@ -40,6 +77,70 @@ func GetConfigDir() string {
 	return filepath.Join(homeDir, ".jira-tool")
 }
 
+// newJiraClient creates the Jira client for a command
+// Changes are recorded in the local journal so they can be undone with 'utils undo'.
+// With --dry-run, changes are recorded in the dry run plan instead of being made
+func newJiraClient(configDir string) (jira.JiraClient, error) {
+	client, err := jira.NewClient(configDir, GetNoCache())
+	if err != nil {
+		return nil, err
+	}
+	if dryRunFlag {
+		return withDryRun(client), nil
+	}
+	return jira.NewJournalingClient(client, jira.NewJournal(jira.GetJournalPath(configDir)), commandLine()), nil
+}
+
+// withDryRun wraps a client in the dry run plan shared by the whole run
+func withDryRun(client jira.JiraClient) jira.JiraClient {
+	if dryRunPlan == nil {
+		dryRunPlan = jira.NewDryRunPlan()
+		dryRunPlan.Log = os.Stderr
+	}
+	return jira.NewDryRunClient(client, dryRunPlan)
+}
+
+// commandLine returns the command being run, as recorded in the journal
+func commandLine() string {
+	return strings.Join(append([]string{"jira"}, os.Args[1:]...), " ")
+}
+
+// reportDryRun prints the changes a --dry-run would have made, and writes them as JSON
+// to --dry-run-output if set ("-" for stdout)
+func reportDryRun() error {
+	if dryRunPlan == nil {
+		return nil
+	}
+
+	if dryRunOutput != "" {
+		out := os.Stdout
+		if dryRunOutput != "-" {
+			file, err := os.Create(dryRunOutput)
+			if err != nil {
+				return fmt.Errorf("failed to create dry run output: %w", err)
+			}
+			defer file.Close()
+			out = file
+		}
+		if err := dryRunPlan.WriteJSON(out); err != nil {
+			return err
+		}
+		if dryRunOutput == "-" {
+			return nil
+		}
+	}
+
+	ops := dryRunPlan.Operations()
+	fmt.Fprintf(os.Stderr, "\nDry run: %d change(s) not made.\n", len(ops))
+	for i := range ops {
+		fmt.Fprintf(os.Stderr, "  %d. %s\n", i+1, ops[i].String())
+	}
+	if dryRunOutput != "" {
+		fmt.Fprintf(os.Stderr, "Plan written to %s\n", dryRunOutput)
+	}
+	return nil
+}
+
 // GetNoCache returns whether the --no-cache flag is set
 func GetNoCache() bool {
 	return noCache
@@ -88,5 +189,15 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&filterFlag, "filter", "", "JQL filter to append to all ticket queries")
 	rootCmd.PersistentFlags().BoolVar(&noFilterFlag, "no-filter", false,
 		"Bypass ticket filter (overrides --filter and config)")
+	rootCmd.PersistentFlags().BoolVar(&dryRunFlag, "dry-run", false,
+		"Don't change anything in Jira; print the changes that would be made")
+	rootCmd.PersistentFlags().StringVar(&dryRunOutput, "dry-run-output", "",
+		"With --dry-run, also write the planned changes as JSON to this file (- for stdout)")
+	rootCmd.PersistentFlags().BoolVarP(&yesFlag, "yes", "y", false,
+		"Answer yes to confirmations instead of asking")
+	rootCmd.PersistentFlags().StringVar(&answersFlag, "answers", "",
+		"YAML file of answers to prompts, by prompt name (for scripts)")
+	rootCmd.PersistentFlags().BoolVar(&noBranchFlag, "no-branch", false,
+		"Don't take the ticket from the current git branch when none is given")
 	// Commands register themselves in their own init() functions
 }
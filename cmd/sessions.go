@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/gemini"
+	"github.com/beekhof/jira-tool/pkg/prompt"
+	"github.com/beekhof/jira-tool/pkg/qa"
+
+	"github.com/spf13/cobra"
+)
+
+// Commands whose Q&A sessions can be resumed
+const (
+	describeSessionCommand = "describe"
+	createSessionCommand   = "create"
+	acceptSessionCommand   = "accept"
+)
+
+var sessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "List unfinished Q&A sessions",
+	Long: `List unfinished Q&A sessions from describe, create and accept.
+Answers are saved after each question, so a session interrupted by a terminal
+crash or a Gemini failure can be continued with --resume.`,
+	Args: cobra.NoArgs,
+	RunE: runSessionsList,
+}
+
+var sessionsDiscardCmd = &cobra.Command{
+	Use:   "discard SESSION_ID",
+	Short: "Discard an unfinished Q&A session",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSessionsDiscard,
+}
+
+func runSessionsList(_ *cobra.Command, _ []string) error {
+	store := qa.NewSessionStore(qa.GetSessionsDir(GetConfigDir()))
+	sessions, err := store.List()
+	if err != nil {
+		return err
+	}
+
+	if len(sessions) == 0 {
+		fmt.Println("No unfinished sessions.")
+		return nil
+	}
+
+	for _, session := range sessions {
+		fmt.Printf("%-24s  %d answer(s)  updated %s\n",
+			session.ID, session.AnsweredCount(), session.UpdatedAt.Local().Format("2006-01-02 15:04"))
+		fmt.Printf("    %s\n", sessionResumeCommand(session))
+	}
+
+	return nil
+}
+
+func runSessionsDiscard(_ *cobra.Command, args []string) error {
+	store := qa.NewSessionStore(qa.GetSessionsDir(GetConfigDir()))
+	session, err := store.Load(args[0])
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return fmt.Errorf("session %s not found", args[0])
+	}
+
+	if err := store.Delete(session.ID); err != nil {
+		return err
+	}
+	fmt.Printf("Discarded session %s.\n", session.ID)
+	return nil
+}
+
+// sessionResumeCommand returns the command line that resumes a session
+func sessionResumeCommand(session *qa.Session) string {
+	return fmt.Sprintf("jira %s %s --resume", session.Command, session.TicketKey)
+}
+
+// loadResumeSession loads the saved session for command and ticketKey (or the most
+// recent session for command if ticketKey is empty) and asks whether to continue
+// answering questions or generate straight away.
+// Returns a nil session if there is nothing to resume.
+func loadResumeSession(
+	reader *bufio.Reader, store *qa.SessionStore, command, ticketKey string,
+) (session *qa.Session, generateNow bool, err error) {
+	if ticketKey != "" {
+		session, err = store.Load(qa.SessionID(command, ticketKey))
+	} else {
+		session, err = store.Latest(command)
+	}
+	if err != nil || session == nil {
+		return nil, false, err
+	}
+
+	fmt.Printf("Resuming %s session for %s (%d question(s) answered)\n",
+		session.Command, session.TicketKey, session.AnsweredCount())
+	for _, entry := range session.History {
+		if strings.HasSuffix(entry, " - REJECTED") {
+			continue
+		}
+		fmt.Printf("  %s\n", entry)
+	}
+
+	response, err := prompt.Line(reader, "resume_action", "\nContinue answering questions or generate now? [C/g] ")
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read input: %w", err)
+	}
+	response = strings.ToLower(response)
+
+	return session, response == "g" || response == "generate", nil
+}
+
+// runQnASession runs the Q&A flow for a new or resumed session, saving answers as it goes
+// If generateNow is true, no further questions are asked
+func runQnASession(
+	geminiClient gemini.GeminiClient, cfg *config.Config, store *qa.SessionStore,
+	session *qa.Session, generateNow bool,
+) (string, error) {
+	var result string
+	var err error
+	if generateNow {
+		result, err = qa.GenerateFromSession(geminiClient, session)
+	} else {
+		answerInputMethod := cfg.AnswerInputMethod
+		if answerInputMethod == "" {
+			answerInputMethod = defaultInputMethod
+		}
+		result, err = qa.RunSessionQnAFlow(geminiClient, session, store, cfg.MaxQuestions, answerInputMethod)
+	}
+
+	if err != nil && session.AnsweredCount() > 0 {
+		fmt.Printf("\nYour answers have been saved. Run '%s' to continue.\n", sessionResumeCommand(session))
+	}
+
+	return result, err
+}
+
+// finishSession removes a session once its result has been used (or declined)
+func finishSession(store *qa.SessionStore, session *qa.Session) {
+	if err := store.Delete(session.ID); err != nil {
+		_ = err // Ignore - a stale session only shows up in 'utils sessions'
+	}
+}
+
+func init() {
+	sessionsCmd.AddCommand(sessionsDiscardCmd)
+	utilsCmd.AddCommand(sessionsCmd)
+}
@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/markup"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	showCommentsFlag int
+	showHistoryFlag  int
+	showOutputFlag   string
+)
+
+var showCmd = &cobra.Command{
+	Use:   "show TICKET_ID",
+	Short: "Show a ticket",
+	Long: `Show everything about a ticket: its fields, the description (rendered from
+Jira wiki markup or Markdown), children, links, attachments, the latest comments
+and the most recent changes.
+
+Use --comments and --history to choose how many comments and changes are shown
+(0 leaves them out), and --output json for a machine-readable view.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runShow,
+}
+
+// ticketView is what 'show' prints about a ticket
+type ticketView struct {
+	*jira.TicketDetails
+	Children []jira.ChildTicketInfo `json:"children,omitempty"`
+	// Comments are the latest ones, oldest first
+	Comments      []jira.Comment `json:"comments,omitempty"`
+	TotalComments int            `json:"total_comments"`
+	// RemoteLinks are links to pull requests, commits and other pages outside Jira
+	RemoteLinks []jira.RemoteLink `json:"remote_links,omitempty"`
+}
+
+func runShow(_ *cobra.Command, args []string) error {
+	if showOutputFlag != "text" && showOutputFlag != "json" {
+		return fmt.Errorf("invalid --output %q (expected text or json)", showOutputFlag)
+	}
+
+	configDir := GetConfigDir()
+	cfg, err := config.LoadConfig(config.GetConfigPath(configDir))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	client, err := newJiraClient(configDir)
+	if err != nil {
+		return err
+	}
+
+	ticketID := normalizeTicketID(strings.ToUpper(args[0]), cfg.DefaultProject)
+	view, err := loadTicketView(client, cfg, ticketID, showCommentsFlag, showHistoryFlag)
+	if err != nil {
+		return err
+	}
+
+	if showOutputFlag == "json" {
+		data, err := json.MarshalIndent(view, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal ticket: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printTicketView(os.Stdout, view, term.IsTerminal(int(os.Stdout.Fd())))
+	return nil
+}
+
+// loadTicketView fetches a ticket with its children, its latest comments and its latest changes
+func loadTicketView(
+	client jira.JiraClient, cfg *config.Config, key string, comments, history int,
+) (*ticketView, error) {
+	details, err := client.GetTicketDetails(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ticket: %w", err)
+	}
+	details.History = latest(details.History, history)
+
+	view := &ticketView{TicketDetails: details}
+
+	children, err := jira.GetChildTicketsDetailed(client, key, cfg.EpicLinkFieldID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch children: %w", err)
+	}
+	view.Children = children
+
+	// Remote links are extra, so the ticket is shown without them if they can't be fetched
+	if remoteLinks, err := client.GetRemoteLinks(key); err == nil {
+		view.RemoteLinks = remoteLinks
+	} else {
+		fmt.Fprintf(os.Stderr, "Warning: failed to fetch remote links of %s: %v\n", key, err)
+	}
+
+	if comments > 0 {
+		all, err := client.GetTicketComments(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch comments: %w", err)
+		}
+		view.TotalComments = len(all)
+		view.Comments = latest(all, comments)
+	}
+
+	return view, nil
+}
+
+// latest returns the last n items of a list kept oldest first
+func latest[T any](items []T, n int) []T {
+	if n <= 0 {
+		return nil
+	}
+	if len(items) > n {
+		return items[len(items)-n:]
+	}
+	return items
+}
+
+// printTicketView prints a ticket for reading; styled adds bold headings and rendered emphasis
+func printTicketView(w io.Writer, view *ticketView, styled bool) {
+	heading := func(title string) {
+		if styled {
+			title = "\x1b[1m" + title + "\x1b[0m"
+		}
+		fmt.Fprintf(w, "\n%s\n", title)
+	}
+
+	d := view.TicketDetails
+	summary := fmt.Sprintf("%s: %s", d.Key, d.Summary)
+	if styled {
+		summary = "\x1b[1m" + summary + "\x1b[0m"
+	}
+	fmt.Fprintln(w, summary)
+	fmt.Fprintln(w, d.URL)
+	fmt.Fprintln(w)
+
+	printShowFields(w, d)
+
+	heading("Description")
+	description := strings.TrimSpace(d.Description)
+	if description == "" {
+		fmt.Fprintln(w, "(no description)")
+	} else {
+		fmt.Fprintln(w, markup.Render(description, styled))
+	}
+
+	if len(view.Children) > 0 {
+		heading(fmt.Sprintf("Children (%d)", len(view.Children)))
+		for _, child := range view.Children {
+			points := ""
+			if child.StoryPoints > 0 {
+				points = fmt.Sprintf(" (%d pts)", child.StoryPoints)
+			}
+			fmt.Fprintf(w, "  %-12s %-10s %s%s\n", child.Key, child.Type, child.Summary, points)
+		}
+	}
+
+	if len(d.Links) > 0 {
+		heading(fmt.Sprintf("Links (%d)", len(d.Links)))
+		for _, link := range d.Links {
+			fmt.Fprintf(w, "  %-16s %-12s %-12s %s\n", link.Relation, link.Key, "["+link.Status+"]", link.Summary)
+		}
+	}
+
+	if len(view.RemoteLinks) > 0 {
+		heading(fmt.Sprintf("Pull requests and remote links (%d)", len(view.RemoteLinks)))
+		for i := range view.RemoteLinks {
+			fmt.Fprintf(w, "  %s\n", formatRemoteLink(&view.RemoteLinks[i]))
+		}
+	}
+
+	if len(d.Attachments) > 0 {
+		heading(fmt.Sprintf("Attachments (%d)", len(d.Attachments)))
+		for _, attachment := range d.Attachments {
+			fmt.Fprintf(w, "  %-40s %8s  %s\n", attachment.Filename, formatSize(attachment.Size),
+				formatJiraTime(attachment.Created))
+		}
+	}
+
+	if len(view.Comments) > 0 {
+		heading(fmt.Sprintf("Comments (latest %d of %d)", len(view.Comments), view.TotalComments))
+		for _, comment := range view.Comments {
+			fmt.Fprintf(w, "\n  %s, %s\n", comment.Author.DisplayName, formatJiraTime(comment.Created))
+			for _, line := range strings.Split(markup.Render(strings.TrimSpace(comment.Body), styled), "\n") {
+				fmt.Fprintf(w, "    %s\n", line)
+			}
+		}
+	}
+
+	if len(d.History) > 0 {
+		heading(fmt.Sprintf("History (latest %d)", len(d.History)))
+		for _, entry := range d.History {
+			fmt.Fprintf(w, "  %s  %-20s %s: %s → %s\n", entry.At.Local().Format("2006-01-02 15:04"),
+				entry.Author, entry.Field, orNone(entry.From), orNone(entry.To))
+		}
+	}
+}
+
+// formatRemoteLink formats a remote link as its title, its state (e.g. [merged]) and its URL
+func formatRemoteLink(link *jira.RemoteLink) string {
+	state := ""
+	if s := link.State(); s != "" {
+		state = "[" + s + "]"
+	}
+	return fmt.Sprintf("%-28s %-10s %s", link.Object.Title, state, link.Object.URL)
+}
+
+// printShowFields prints the header fields that have a value
+func printShowFields(w io.Writer, d *jira.TicketDetails) {
+	points := ""
+	if d.StoryPoints > 0 {
+		points = fmt.Sprintf("%g", d.StoryPoints)
+	}
+
+	fields := [][2]string{
+		{"Type", d.Type},
+		{"Status", d.Status},
+		{"Resolution", d.Resolution},
+		{"Priority", d.Priority},
+		{"Story points", points},
+		{"Assignee", orNone(d.Assignee)},
+		{"Reporter", d.Reporter},
+		{"Parent", d.Parent},
+		{"Components", strings.Join(d.Components, ", ")},
+		{"Labels", strings.Join(d.Labels, ", ")},
+		{"Fix versions", strings.Join(d.FixVersions, ", ")},
+		{"Created", formatJiraTime(d.Created)},
+		{"Updated", formatJiraTime(d.Updated)},
+	}
+
+	for _, field := range fields {
+		if field[1] != "" {
+			fmt.Fprintf(w, "%-14s%s\n", field[0]+":", field[1])
+		}
+	}
+}
+
+// formatJiraTime shortens a Jira timestamp such as 2025-01-06T09:00:00.000+0000 to 2025-01-06 09:00
+func formatJiraTime(timestamp string) string {
+	if len(timestamp) < 16 {
+		return timestamp
+	}
+	return strings.Replace(timestamp[:16], "T", " ", 1)
+}
+
+// formatSize returns a file size for display, e.g. 2.0 KB
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size) / unit
+	for _, suffix := range []string{"KB", "MB", "GB"} {
+		if value < unit || suffix == "GB" {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+		value /= unit
+	}
+	return ""
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
+func init() {
+	showCmd.Flags().IntVar(&showCommentsFlag, "comments", 5, "Number of latest comments to show (0 for none)")
+	showCmd.Flags().IntVar(&showHistoryFlag, "history", 10, "Number of latest changes to show (0 for none)")
+	showCmd.Flags().StringVarP(&showOutputFlag, "output", "o", "text", "Output format: text or json")
+	rootCmd.AddCommand(showCmd)
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+// showClient returns a fixed ticket with many comments and changes
+type showClient struct {
+	jira.JiraClient
+	linksErr error
+}
+
+func (c *showClient) GetTicketDetails(key string) (*jira.TicketDetails, error) {
+	details := &jira.TicketDetails{
+		Key: key, URL: "https://jira.example.com/browse/" + key, Summary: "Crash on start",
+		Type: "Bug", Status: "In Progress", StoryPoints: 3, Labels: []string{"crash", "ui"},
+		Description: "h2. Steps\n# Open *the* app",
+		Links:       []jira.IssueLink{{Relation: "is blocked by", Key: "OPS-3", Summary: "Infra", Status: "Done"}},
+		Attachments: []jira.Attachment{{Filename: "log.txt", Size: 2048, Created: "2025-01-06T09:00:00.000+0000"}},
+	}
+	for i := 1; i <= 4; i++ {
+		details.History = append(details.History, jira.ChangelogEntry{
+			Author: "Jane", At: time.Date(2025, 1, i, 9, 0, 0, 0, time.UTC), Field: "status", To: fmt.Sprintf("S%d", i),
+		})
+	}
+	return details, nil
+}
+
+func (c *showClient) GetIssue(_ string) (*jira.Issue, error) {
+	return nil, errors.New("children not needed")
+}
+
+func (c *showClient) GetTicketComments(_ string) ([]jira.Comment, error) {
+	comments := make([]jira.Comment, 6)
+	for i := range comments {
+		comments[i].Body = fmt.Sprintf("comment %d", i+1)
+		comments[i].Author.DisplayName = "Alex"
+	}
+	return comments, nil
+}
+
+func (c *showClient) GetRemoteLinks(_ string) ([]jira.RemoteLink, error) {
+	if c.linksErr != nil {
+		return nil, c.linksErr
+	}
+	link, err := jira.NewRemoteLink("https://github.com/org/repo/pull/7", "", jira.RemoteLinkMerged)
+	if err != nil {
+		return nil, err
+	}
+	return []jira.RemoteLink{*link}, nil
+}
+
+func TestShowTicket(t *testing.T) {
+	view, err := loadTicketView(&showClient{}, &config.Config{}, "ENG-1", 2, 3)
+	if err != nil {
+		t.Fatalf("loadTicketView failed: %v", err)
+	}
+	if len(view.Comments) != 2 || view.Comments[1].Body != "comment 6" || view.TotalComments != 6 {
+		t.Errorf("Expected the latest 2 of 6 comments, got %+v", view.Comments)
+	}
+	if len(view.History) != 3 || view.History[0].To != "S2" {
+		t.Errorf("Expected the latest 3 changes, got %+v", view.History)
+	}
+
+	var out bytes.Buffer
+	printTicketView(&out, view, false)
+	for _, want := range []string{
+		"ENG-1: Crash on start", "Story points: 3", "Labels:       crash, ui", "Steps\n1. Open the app",
+		"is blocked by    OPS-3", "log.txt", "2.0 KB", "Comments (latest 2 of 6)", "    comment 6",
+		"History (latest 3)", "status: (none) → S4", "Pull requests and remote links (1)",
+		"  org/repo#7                   [merged]   https://github.com/org/repo/pull/7",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "\x1b[") {
+		t.Error("Expected no escape codes in unstyled output")
+	}
+
+	view, err = loadTicketView(&showClient{}, &config.Config{}, "ENG-1", 0, 0)
+	if err != nil || view.Comments != nil || view.History != nil {
+		t.Errorf("Expected no comments or history, got %+v (%v)", view, err)
+	}
+
+	// Remote links are optional
+	view, err = loadTicketView(&showClient{linksErr: errors.New("forbidden")}, &config.Config{}, "ENG-1", 0, 0)
+	if err != nil || view.Summary != "Crash on start" || view.RemoteLinks != nil {
+		t.Errorf("Expected the ticket without remote links, got %+v (%v)", view, err)
+	}
+}
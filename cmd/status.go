@@ -13,7 +13,8 @@ import (
 )
 
 var (
-	nextFlag bool
+	nextFlag  bool
+	linksFlag bool
 )
 
 var statusCmd = &cobra.Command{
@@ -45,7 +46,7 @@ var spikesCmd = &cobra.Command{
 
 func runSprintStatus(_ *cobra.Command, _ []string) error {
 	configDir := GetConfigDir()
-	client, err := jira.NewClient(configDir, GetNoCache())
+	client, err := newJiraClient(configDir)
 	if err != nil {
 		return err
 	}
@@ -62,7 +63,7 @@ func runSprintStatus(_ *cobra.Command, _ []string) error {
 
 	stats := calculateSprintStats(issues)
 	displaySprintStatus(&selectedSprint, stats)
-	displaySprintIssues(issues)
+	displaySprintIssues(issues, fetchRemoteLinks(client, issues))
 
 	return nil
 }
@@ -234,7 +235,7 @@ func calculateOnTrackStatus(sprint *jira.SprintParsed, progressPercent float64)
 	return "Yes"
 }
 
-func displaySprintIssues(issues []jira.Issue) {
+func displaySprintIssues(issues []jira.Issue, remoteLinks map[string][]jira.RemoteLink) {
 	statusGroups := groupIssuesByStatus(issues)
 
 	fmt.Println("\n---")
@@ -246,19 +247,41 @@ func displaySprintIssues(issues []jira.Issue) {
 		if groupIssues, ok := statusGroups[statusName]; ok {
 			fmt.Printf("[%s]\n", statusName)
 			for i := range groupIssues {
-				issue := &groupIssues[i]
-				points := issue.Fields.StoryPoints
-				if points > 0 {
-					fmt.Printf("  %s: %s (%.0f points)\n", issue.Key, issue.Fields.Summary, points)
-				} else {
-					fmt.Printf("  %s: %s\n", issue.Key, issue.Fields.Summary)
-				}
+				printStatusIssue(&groupIssues[i], remoteLinks[groupIssues[i].Key])
 			}
 			fmt.Println()
 		}
 	}
 }
 
+// printStatusIssue prints a ticket in a status list, with its remote links below it
+func printStatusIssue(issue *jira.Issue, links []jira.RemoteLink) {
+	points := issue.Fields.StoryPoints
+	if points > 0 {
+		fmt.Printf("  %s: %s (%.0f points)\n", issue.Key, issue.Fields.Summary, points)
+	} else {
+		fmt.Printf("  %s: %s\n", issue.Key, issue.Fields.Summary)
+	}
+	for i := range links {
+		fmt.Printf("      %s\n", formatRemoteLink(&links[i]))
+	}
+}
+
+// fetchRemoteLinks returns the remote links of each ticket by key when --links is set, or nil
+// Tickets whose links can't be fetched are listed without them
+func fetchRemoteLinks(client jira.JiraClient, issues []jira.Issue) map[string][]jira.RemoteLink {
+	if !linksFlag {
+		return nil
+	}
+	links := make(map[string][]jira.RemoteLink, len(issues))
+	for i := range issues {
+		if remote, err := client.GetRemoteLinks(issues[i].Key); err == nil {
+			links[issues[i].Key] = remote
+		}
+	}
+	return links
+}
+
 func groupIssuesByStatus(issues []jira.Issue) map[string][]jira.Issue {
 	statusGroups := make(map[string][]jira.Issue)
 	for i := range issues {
@@ -271,7 +294,7 @@ func groupIssuesByStatus(issues []jira.Issue) map[string][]jira.Issue {
 
 func runReleaseStatus(_ *cobra.Command, _ []string) error {
 	configDir := GetConfigDir()
-	client, err := jira.NewClient(configDir, GetNoCache())
+	client, err := newJiraClient(configDir)
 	if err != nil {
 		return err
 	}
@@ -299,7 +322,7 @@ func runReleaseStatus(_ *cobra.Command, _ []string) error {
 
 	stats := calculateSprintStats(issues)
 	displayReleaseStatus(&selectedRelease, stats)
-	displaySprintIssues(issues)
+	displaySprintIssues(issues, fetchRemoteLinks(client, issues))
 
 	return nil
 }
@@ -392,7 +415,7 @@ func displayReleaseStatus(release *jira.ReleaseParsed, stats sprintStats) {
 
 func runSpikesStatus(_ *cobra.Command, _ []string) error {
 	configDir := GetConfigDir()
-	client, err := jira.NewClient(configDir, GetNoCache())
+	client, err := newJiraClient(configDir)
 	if err != nil {
 		return err
 	}
@@ -496,6 +519,7 @@ func runSpikesStatus(_ *cobra.Command, _ []string) error {
 	}
 
 	// Print detailed list
+	remoteLinks := fetchRemoteLinks(client, issues)
 	fmt.Println("\n---")
 	fmt.Println("Spike Tickets:")
 	fmt.Println()
@@ -505,13 +529,7 @@ func runSpikesStatus(_ *cobra.Command, _ []string) error {
 		if issues, ok := statusGroups[statusName]; ok {
 			fmt.Printf("[%s]\n", statusName)
 			for i := range issues {
-				issue := &issues[i]
-				points := issue.Fields.StoryPoints
-				if points > 0 {
-					fmt.Printf("  %s: %s (%.0f points)\n", issue.Key, issue.Fields.Summary, points)
-				} else {
-					fmt.Printf("  %s: %s\n", issue.Key, issue.Fields.Summary)
-				}
+				printStatusIssue(&issues[i], remoteLinks[issues[i].Key])
 			}
 			fmt.Println()
 		}
@@ -521,6 +539,8 @@ func runSpikesStatus(_ *cobra.Command, _ []string) error {
 }
 
 func init() {
+	statusCmd.PersistentFlags().BoolVar(&linksFlag, "links", false,
+		"Also list each ticket's pull requests and other remote links")
 	statusCmd.AddCommand(sprintCmd)
 	statusCmd.AddCommand(releaseCmd)
 	statusCmd.AddCommand(spikesCmd)
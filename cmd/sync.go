@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/plan"
+	"github.com/beekhof/jira-tool/pkg/prompt"
+	"github.com/beekhof/jira-tool/pkg/triage"
+
+	"github.com/spf13/cobra"
+)
+
+var syncForceFlag bool
+
+var syncCmd = &cobra.Command{
+	Use:   "sync FILE",
+	Short: "Push the edits of an exported plan file back to Jira",
+	Long: `Push the edits made to a file written by 'jira export' back to Jira: renamed tickets,
+new points, descriptions and assignees, new children and children in a new order.
+
+Fields changed only in Jira since the export are left as they are. A field changed both
+in the file and in Jira is a conflict, and nothing is changed until it's resolved: export
+again to start from Jira's version, or use --force to overwrite it with the file's.
+
+The changes are shown first and made once confirmed, like 'jira apply'. Afterwards the
+file records the tickets as synced, so the next sync only pushes the edits since.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSync,
+}
+
+func runSync(_ *cobra.Command, args []string) error {
+	configDir := GetConfigDir()
+	cfg, err := config.LoadConfig(config.GetConfigPath(configDir))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	p, err := plan.Load(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := newJiraClient(configDir)
+	if err != nil {
+		return err
+	}
+
+	runner := plan.NewRunner(client, triage.NewApplier(client, cfg))
+	runner.Project = cfg.DefaultProject
+	runner.EpicLinkField = cfg.EpicLinkFieldID
+
+	result, err := runner.Sync(p, syncForceFlag)
+	if err != nil {
+		return err
+	}
+	if len(result.InJira) > 0 {
+		fmt.Printf("Changed only in Jira, left as they are: %s\n\n", strings.Join(result.InJira, ", "))
+	}
+	if len(result.Conflicts) > 0 {
+		fmt.Println("Changed both in the file and in Jira:")
+		for _, conflict := range result.Conflicts {
+			fmt.Printf("  %s\n", conflict)
+		}
+		return fmt.Errorf("%d conflict(s): export again to start from Jira's version, or use --force to overwrite it",
+			len(result.Conflicts))
+	}
+
+	plan.Print(os.Stdout, p, result.Changes)
+	if create, update, _ := plan.Summary(result.Changes); create+update == 0 {
+		return nil
+	}
+
+	// Keys made up by a dry run must never be written to the file
+	saved := func() error { return p.Save(args[0]) }
+	if dryRunFlag {
+		saved = func() error { return nil }
+	} else {
+		ok, err := prompt.Confirm(bufio.NewReader(os.Stdin), "sync_plan", "\nPush these changes to Jira? [y/N] ", false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Nothing was changed.")
+			return nil
+		}
+	}
+
+	if err := runner.Apply(p, result.Changes, saved); err != nil {
+		if dryRunFlag {
+			return err
+		}
+		return fmt.Errorf("%w (the keys of the tickets created so far are saved; sync again to continue)", err)
+	}
+
+	p.Synced = plan.NewSyncState(p, time.Now())
+	return saved()
+}
+
+func init() {
+	syncCmd.Flags().BoolVar(&syncForceFlag, "force", false, "Overwrite fields changed in Jira since the export with the file's")
+	rootCmd.AddCommand(syncCmd)
+}
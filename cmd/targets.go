@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/triage"
+)
+
+// ticketTargets are where newly created tickets go, given with flags instead of prompts
+type ticketTargets struct {
+	Assignee   string
+	Sprint     string
+	Release    string
+	Components []string
+}
+
+func (t ticketTargets) empty() bool {
+	return t.Assignee == "" && t.Sprint == "" && t.Release == "" && len(t.Components) == 0
+}
+
+// resolvedTargets are ticketTargets with every name resolved, ready to apply to tickets
+type resolvedTargets struct {
+	ticketTargets
+	user         jira.User
+	sprintID     int
+	releaseID    string
+	componentIDs []string
+}
+
+// resolveTargets resolves every name in targets, so that commands creating tickets can report
+// a typo before any ticket is created
+func resolveTargets(
+	client jira.JiraClient, cfg *config.Config, project string, targets ticketTargets,
+) (*resolvedTargets, error) {
+	resolved := &resolvedTargets{ticketTargets: targets}
+	if targets.empty() {
+		return resolved, nil
+	}
+
+	resolver := triage.NewApplier(client, cfg)
+
+	if targets.Assignee != "" {
+		var err error
+		if resolved.user, err = resolver.User(targets.Assignee); err != nil {
+			return nil, fmt.Errorf("invalid --assignee: %w", err)
+		}
+	}
+
+	if targets.Sprint != "" {
+		var err error
+		if resolved.sprintID, err = resolver.SprintID(project, targets.Sprint); err != nil {
+			return nil, fmt.Errorf("invalid --sprint: %w", err)
+		}
+	}
+
+	if targets.Release != "" {
+		var err error
+		if resolved.releaseID, err = resolver.ReleaseID(project, targets.Release); err != nil {
+			return nil, fmt.Errorf("invalid --release: %w", err)
+		}
+	}
+
+	for _, name := range targets.Components {
+		id, err := resolver.ComponentID(project, name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --component: %w", err)
+		}
+		resolved.componentIDs = append(resolved.componentIDs, id)
+	}
+
+	return resolved, nil
+}
+
+// apply assigns tickets and adds them to the sprint, release and components
+func (r *resolvedTargets) apply(client jira.JiraClient, keys []string) error {
+	if r.empty() || len(keys) == 0 {
+		return nil
+	}
+
+	for _, key := range keys {
+		if r.Assignee != "" {
+			if err := client.AssignTicket(key, r.user.AccountID, r.user.Name); err != nil {
+				return fmt.Errorf("failed to assign %s: %w", key, err)
+			}
+		}
+		if len(r.componentIDs) > 0 {
+			if err := client.UpdateTicketComponents(key, r.componentIDs); err != nil {
+				return fmt.Errorf("failed to set components of %s: %w", key, err)
+			}
+		}
+	}
+	if r.sprintID != 0 {
+		if err := client.AddIssuesToSprint(r.sprintID, keys); err != nil {
+			return fmt.Errorf("failed to add issues to sprint: %w", err)
+		}
+		fmt.Printf("Added %d ticket(s) to sprint %s.\n", len(keys), r.Sprint)
+	}
+	if r.releaseID != "" {
+		if err := client.AddIssuesToRelease(r.releaseID, keys); err != nil {
+			return fmt.Errorf("failed to add issues to release: %w", err)
+		}
+		fmt.Printf("Added %d ticket(s) to release %s.\n", len(keys), r.Release)
+	}
+
+	return nil
+}
+
+// applyTargets assigns existing tickets and adds them to the sprint, release and components in targets
+// Every name is resolved before any ticket is changed, so a typo doesn't leave a partial update
+func applyTargets(
+	client jira.JiraClient, cfg *config.Config, project string, keys []string, targets ticketTargets,
+) error {
+	if targets.empty() || len(keys) == 0 {
+		return nil
+	}
+	resolved, err := resolveTargets(client, cfg, project, targets)
+	if err != nil {
+		return err
+	}
+	return resolved.apply(client, keys)
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+// targetsClient records where tickets were put; lookups return fixed data
+type targetsClient struct {
+	jira.JiraClient
+	assigned   map[string]string
+	components map[string][]string
+	sprint     int
+	release    string
+	added      []string
+}
+
+func (c *targetsClient) SearchUsers(_ string) ([]jira.User, error) {
+	return []jira.User{{AccountID: "acc-1", Name: "jane", EmailAddress: "jane@example.com"}}, nil
+}
+
+func (c *targetsClient) AssignTicket(ticketID, accountID, _ string) error {
+	c.assigned[ticketID] = accountID
+	return nil
+}
+
+func (c *targetsClient) GetComponents(_ string) ([]jira.Component, error) {
+	return []jira.Component{{ID: "10", Name: "Backend"}, {ID: "11", Name: "UI"}}, nil
+}
+
+func (c *targetsClient) UpdateTicketComponents(ticketID string, ids []string) error {
+	c.components[ticketID] = ids
+	return nil
+}
+
+func (c *targetsClient) GetActiveSprints(_ int) ([]jira.SprintParsed, error) {
+	return []jira.SprintParsed{{ID: 7, Name: "Sprint 7"}}, nil
+}
+
+func (c *targetsClient) GetPlannedSprints(_ int) ([]jira.SprintParsed, error) {
+	return []jira.SprintParsed{{ID: 8, Name: "Sprint 8"}}, nil
+}
+
+func (c *targetsClient) AddIssuesToSprint(sprintID int, keys []string) error {
+	c.sprint = sprintID
+	c.added = keys
+	return nil
+}
+
+func (c *targetsClient) GetReleases(_ string) ([]jira.ReleaseParsed, error) {
+	return []jira.ReleaseParsed{{ID: "100", Name: "1.0", Released: true}, {ID: "101", Name: "1.1"}}, nil
+}
+
+func (c *targetsClient) AddIssuesToRelease(releaseID string, _ []string) error {
+	c.release = releaseID
+	return nil
+}
+
+func newTargetsClient() *targetsClient {
+	return &targetsClient{assigned: map[string]string{}, components: map[string][]string{}}
+}
+
+func TestApplyTargets(t *testing.T) {
+	cfg := &config.Config{DefaultBoardID: 1}
+	keys := []string{"ENG-1", "ENG-2"}
+
+	t.Run("all targets", func(t *testing.T) {
+		client := newTargetsClient()
+		targets := ticketTargets{Assignee: "jane", Sprint: "8", Release: "1.1", Components: []string{"backend", "UI"}}
+		if err := applyTargets(client, cfg, "ENG", keys, targets); err != nil {
+			t.Fatalf("applyTargets failed: %v", err)
+		}
+		if client.assigned["ENG-1"] != "acc-1" || client.assigned["ENG-2"] != "acc-1" {
+			t.Errorf("Expected both tickets assigned, got %v", client.assigned)
+		}
+		if got := client.components["ENG-2"]; len(got) != 2 || got[0] != "10" || got[1] != "11" {
+			t.Errorf("Expected components [10 11], got %v", got)
+		}
+		if client.sprint != 8 || len(client.added) != 2 {
+			t.Errorf("Expected both tickets in sprint 8, got %d %v", client.sprint, client.added)
+		}
+		if client.release != "101" {
+			t.Errorf("Expected release 101, got %q", client.release)
+		}
+	})
+
+	t.Run("unknown name changes nothing", func(t *testing.T) {
+		client := newTargetsClient()
+		targets := ticketTargets{Assignee: "jane", Release: "1.0"}
+		if err := applyTargets(client, cfg, "ENG", keys, targets); err == nil {
+			t.Fatal("Expected an error for a released version")
+		}
+		if len(client.assigned) != 0 {
+			t.Errorf("Expected no ticket to be assigned, got %v", client.assigned)
+		}
+	})
+
+	t.Run("resolved before the tickets exist", func(t *testing.T) {
+		client := newTargetsClient()
+		if _, err := resolveTargets(client, cfg, "ENG", ticketTargets{Components: []string{"Mobile"}}); err == nil {
+			t.Fatal("Expected an error for an unknown component")
+		}
+
+		resolved, err := resolveTargets(client, cfg, "ENG", ticketTargets{Assignee: "jane", Components: []string{"UI"}})
+		if err != nil {
+			t.Fatalf("resolveTargets failed: %v", err)
+		}
+		if len(client.assigned) != 0 || len(client.components) != 0 {
+			t.Error("Expected resolving to change nothing")
+		}
+		if err := resolved.apply(client, []string{"ENG-3"}); err != nil {
+			t.Fatalf("apply failed: %v", err)
+		}
+		if client.assigned["ENG-3"] != "acc-1" || len(client.components["ENG-3"]) != 1 {
+			t.Errorf("Expected ENG-3 assigned with one component, got %v %v", client.assigned, client.components)
+		}
+	})
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/gemini"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	transcriptsTicketFlag string
+	transcriptsLimitFlag  int
+)
+
+var transcriptsCmd = &cobra.Command{
+	Use:   "transcripts",
+	Short: "Inspect the Gemini transcript log",
+	Long: `Inspect the local log of prompts sent to Gemini and the responses received.
+Every request is recorded in transcripts.jsonl in the config directory.`,
+}
+
+var transcriptsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recorded Gemini transcripts",
+	Long: `List recorded Gemini transcripts, most recent last.
+Use --ticket to show only transcripts for a specific ticket.`,
+	Args: cobra.NoArgs,
+	RunE: runTranscriptsList,
+}
+
+var transcriptsShowCmd = &cobra.Command{
+	Use:   "show ID|#N",
+	Short: "Show the full prompt and response of a transcript",
+	Long:  `Show the full prompt and response of a transcript, referenced by ID (or ID prefix) or by list number as #N.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTranscriptsShow,
+}
+
+var transcriptsReplayCmd = &cobra.Command{
+	Use:   "replay ID|#N",
+	Short: "Re-send a recorded prompt to Gemini",
+	Long: `Re-send a recorded prompt to the model it was originally sent to and print the new response.
+The response cache is bypassed. The replayed exchange is recorded as a new transcript.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTranscriptsReplay,
+}
+
+func runTranscriptsList(_ *cobra.Command, _ []string) error {
+	configDir := GetConfigDir()
+	store := gemini.NewTranscriptStore(gemini.GetTranscriptPath(configDir))
+	transcripts, err := store.List()
+	if err != nil {
+		return err
+	}
+
+	ticketFilter := ""
+	if transcriptsTicketFlag != "" {
+		defaultProject := ""
+		if cfg, err := config.LoadConfig(config.GetConfigPath(configDir)); err == nil {
+			defaultProject = cfg.DefaultProject
+		}
+		ticketFilter = normalizeTicketID(transcriptsTicketFlag, defaultProject)
+	}
+
+	type numbered struct {
+		index int
+		entry gemini.Transcript
+	}
+	var matches []numbered
+	for i := range transcripts {
+		if ticketFilter != "" && !strings.EqualFold(transcripts[i].TicketKey, ticketFilter) {
+			continue
+		}
+		matches = append(matches, numbered{index: i + 1, entry: transcripts[i]})
+	}
+
+	if len(matches) == 0 {
+		fmt.Println("No transcripts recorded.")
+		return nil
+	}
+
+	if transcriptsLimitFlag > 0 && len(matches) > transcriptsLimitFlag {
+		matches = matches[len(matches)-transcriptsLimitFlag:]
+	}
+
+	for _, m := range matches {
+		t := m.entry
+		ticket := t.TicketKey
+		if ticket == "" {
+			ticket = "-"
+		}
+		status := fmt.Sprintf("%d tokens", t.Usage.TotalTokenCount)
+		if t.Cached {
+			status = "cached"
+		}
+		if t.Error != "" {
+			status = "error"
+		}
+		fmt.Printf("%5s  %s  %s  %-12s  %-20s  %s\n",
+			fmt.Sprintf("#%d", m.index), t.ID, t.Timestamp.Local().Format("2006-01-02 15:04"), ticket, t.Model, status)
+	}
+
+	return nil
+}
+
+func runTranscriptsShow(_ *cobra.Command, args []string) error {
+	store := gemini.NewTranscriptStore(gemini.GetTranscriptPath(GetConfigDir()))
+	t, err := store.Find(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("ID:        %s\n", t.ID)
+	fmt.Printf("Timestamp: %s\n", t.Timestamp.Local().Format("2006-01-02 15:04:05"))
+	fmt.Printf("Model:     %s\n", t.Model)
+	if t.TicketKey != "" {
+		fmt.Printf("Ticket:    %s\n", t.TicketKey)
+	}
+	if t.Cached {
+		fmt.Println("Cached:    yes")
+	}
+	fmt.Printf("Tokens:    %d prompt, %d response, %d total\n",
+		t.Usage.PromptTokenCount, t.Usage.CandidatesTokenCount, t.Usage.TotalTokenCount)
+
+	fmt.Println("\n--- Prompt ---")
+	fmt.Println(t.Prompt)
+	if t.Error != "" {
+		fmt.Println("\n--- Error ---")
+		fmt.Println(t.Error)
+	} else {
+		fmt.Println("\n--- Response ---")
+		fmt.Println(t.Response)
+	}
+
+	return nil
+}
+
+func runTranscriptsReplay(_ *cobra.Command, args []string) error {
+	configDir := GetConfigDir()
+	store := gemini.NewTranscriptStore(gemini.GetTranscriptPath(configDir))
+	t, err := store.Find(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Replaying transcript %s (%s)...\n", t.ID, t.Model)
+	response, err := gemini.Replay(configDir, t)
+	if err != nil {
+		return fmt.Errorf("failed to replay transcript: %w", err)
+	}
+
+	fmt.Println("\n--- Response ---")
+	fmt.Println(response)
+
+	return nil
+}
+
+func init() {
+	transcriptsListCmd.Flags().StringVar(&transcriptsTicketFlag, "ticket", "", "Only show transcripts for this ticket")
+	transcriptsListCmd.Flags().IntVar(&transcriptsLimitFlag, "limit", 20,
+		"Maximum number of transcripts to show (0 for all)")
+
+	transcriptsCmd.AddCommand(transcriptsListCmd, transcriptsShowCmd, transcriptsReplayCmd)
+	utilsCmd.AddCommand(transcriptsCmd)
+}
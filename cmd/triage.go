@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/prompt"
+	"github.com/beekhof/jira-tool/pkg/review"
+	"github.com/beekhof/jira-tool/pkg/triage"
+
+	"github.com/spf13/cobra"
+)
+
+var triageRulesFlag string
+
+var triageCmd = &cobra.Command{
+	Use:   "triage [JQL]",
+	Short: "Apply triage rules to tickets in batch",
+	Long: `Apply YAML triage rules to tickets selected by JQL. Rules match on summary and
+description (regular expressions), issue type, reporter, labels and components, and can
+set priority, severity, component or assignee, add labels, add tickets to a sprint and
+transition them.
+
+The planned changes are previewed per ticket before anything is applied.
+Without a query, unresolved tickets in the default project that are missing a priority,
+component or assignee are triaged.
+
+Rules are read from --rules, or ~/.jira-tool/triage.yaml. If that file exists, 'review'
+also offers the priority, severity and component from the rules as defaults.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTriage,
+}
+
+func runTriage(_ *cobra.Command, args []string) error {
+	configDir := GetConfigDir()
+
+	rulesPath := triageRulesFlag
+	if rulesPath == "" {
+		rulesPath = triage.GetRulesPath(configDir)
+	}
+	ruleSet, err := triage.LoadRules(rulesPath)
+	if err != nil {
+		return err
+	}
+	if len(ruleSet.Rules) == 0 {
+		return fmt.Errorf("no triage rules in %s", rulesPath)
+	}
+
+	cfg, err := config.LoadConfig(config.GetConfigPath(configDir))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	client, err := newJiraClient(configDir)
+	if err != nil {
+		return err
+	}
+
+	var jql string
+	if len(args) > 0 {
+		jql = args[0]
+	} else {
+		if cfg.DefaultProject == "" {
+			return fmt.Errorf("default_project not configured. Please run 'jira init' or pass a JQL query")
+		}
+		jql = fmt.Sprintf("project = %s AND resolution = Unresolved AND "+
+			"(priority is EMPTY OR component is EMPTY OR assignee is EMPTY) ORDER BY key", cfg.DefaultProject)
+	}
+	jql = jira.ApplyTicketFilter(jql, GetTicketFilter(cfg))
+
+	issues, err := client.SearchTickets(jql)
+	if err != nil {
+		return fmt.Errorf("failed to search tickets: %w", err)
+	}
+	if len(issues) == 0 {
+		fmt.Println("No tickets found.")
+		return nil
+	}
+
+	fmt.Printf("Evaluating %d rule(s) against %d ticket(s)...\n", len(ruleSet.Rules), len(issues))
+	plans := []*triage.Plan{}
+	changeCount := 0
+	for i := range issues {
+		plan := triage.BuildPlan(ruleSet, triage.Gather(client, cfg, ruleSet, &issues[i]))
+		if len(plan.Changes) > 0 {
+			plans = append(plans, plan)
+			changeCount += len(plan.Changes)
+		}
+	}
+
+	if len(plans) == 0 {
+		fmt.Println("No changes planned.")
+		return nil
+	}
+
+	printTriagePlans(plans)
+	fmt.Printf("\n%d change(s) planned for %d of %d ticket(s).\n", changeCount, len(plans), len(issues))
+
+	apply, err := prompt.Confirm(bufio.NewReader(os.Stdin), "apply_triage", "Apply these changes? [y/N] ", false)
+	if err != nil {
+		return err
+	}
+	if !apply {
+		fmt.Println("No changes applied.")
+		return nil
+	}
+
+	applier := triage.NewApplier(client, cfg)
+	failed := 0
+	for _, plan := range plans {
+		errs := applier.Apply(plan)
+		if len(errs) == 0 {
+			fmt.Printf("✓ %s\n", plan.Key)
+			continue
+		}
+		failed += len(errs)
+		for _, err := range errs {
+			fmt.Printf("✗ %v\n", err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d change(s) failed", failed, changeCount)
+	}
+	fmt.Printf("Applied %d change(s).\n", changeCount)
+	return nil
+}
+
+func printTriagePlans(plans []*triage.Plan) {
+	for _, plan := range plans {
+		fmt.Printf("\n%s - %s\n", plan.Key, truncateSummary(plan.Summary, 60))
+		for _, change := range plan.Changes {
+			from := change.From
+			if from == "" {
+				from = "(none)"
+			}
+			fmt.Printf("  %-11s %s -> %s  [%s]\n", change.Field, from, change.To, change.Rule)
+		}
+	}
+}
+
+// reviewStepDefaults returns the triage rule suggestions for a ticket in review,
+// or nil if there is no triage rules file
+func reviewStepDefaults(
+	client jira.JiraClient, cfg *config.Config, configDir string, issue *jira.Issue,
+) *review.StepDefaults {
+	rulesPath := triage.GetRulesPath(configDir)
+	if _, err := os.Stat(rulesPath); err != nil {
+		return nil
+	}
+	ruleSet, err := triage.LoadRules(rulesPath)
+	if err != nil {
+		fmt.Printf("Warning: Could not load triage rules: %v\n", err)
+		return nil
+	}
+	return triage.Suggest(client, cfg, ruleSet, issue)
+}
+
+func init() {
+	triageCmd.Flags().StringVar(&triageRulesFlag, "rules", "", "Rules file (default: triage.yaml in the config directory)")
+	rootCmd.AddCommand(triageCmd)
+}
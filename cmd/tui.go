@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/gemini"
+	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/review"
+	"github.com/beekhof/jira-tool/pkg/tui"
+)
+
+// maxDetailComments is how many of a ticket's latest comments the detail pane shows
+const maxDetailComments = 5
+
+// reviewStepActions are the single workflow steps that can be run from the full-screen review
+var reviewStepActions = []struct {
+	key    rune
+	label  string
+	stepID string
+}{
+	{'d', "description", "description"},
+	{'c', "component", "component"},
+	{'p', "priority", "priority"},
+	{'v', "severity", "severity"},
+	{'e', "estimate", "story_points"},
+	{'b', "backlog", "backlog"},
+	{'a', "assign", "assignment"},
+}
+
+// ticketRow is a ticket as a row in a full-screen queue
+func ticketRow(issue *jira.Issue) tui.Row {
+	return tui.Row{
+		Key:     issue.Key,
+		Columns: []string{issue.Fields.IssueType.Name, getPriorityName(issue), issue.Fields.Status.Name},
+		Summary: issue.Fields.Summary,
+	}
+}
+
+func ticketRows(issues []jira.Issue) []tui.Row {
+	rows := make([]tui.Row, len(issues))
+	for i := range issues {
+		rows[i] = ticketRow(&issues[i])
+	}
+	return rows
+}
+
+// refreshTicketRow reloads a ticket's row after an action
+func refreshTicketRow(client jira.JiraClient) func(key string) (tui.Row, error) {
+	return func(key string) (tui.Row, error) {
+		issue, err := client.GetIssue(key)
+		if err != nil {
+			return tui.Row{}, err
+		}
+		return ticketRow(issue), nil
+	}
+}
+
+// ticketDetails returns the detail pane text for a ticket: its fields, description,
+// latest comments and children
+func ticketDetails(client jira.JiraClient, cfg *config.Config) func(key string) (string, error) {
+	return func(key string) (string, error) {
+		issue, err := client.GetIssue(key)
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch ticket: %w", err)
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "%s: %s\n\n", issue.Key, issue.Fields.Summary)
+		fmt.Fprintf(&b, "Type:     %s\n", issue.Fields.IssueType.Name)
+		fmt.Fprintf(&b, "Status:   %s\n", issue.Fields.Status.Name)
+		fmt.Fprintf(&b, "Priority: %s\n", getPriorityName(issue))
+		fmt.Fprintf(&b, "Assignee: %s\n", getAssigneeName(issue))
+		if issue.Fields.StoryPoints > 0 {
+			fmt.Fprintf(&b, "Points:   %g\n", issue.Fields.StoryPoints)
+		}
+
+		description, err := client.GetTicketDescription(key)
+		if err != nil {
+			description = "" // Shown as no description
+		}
+		description = strings.TrimSpace(description)
+		if description == "" {
+			description = "(no description)"
+		}
+		fmt.Fprintf(&b, "\nDescription\n%s\n", description)
+
+		// Comments and children are extras; the pane is still useful without them
+		if comments, err := client.GetTicketComments(key); err == nil && len(comments) > 0 {
+			fmt.Fprintf(&b, "\nComments (%d)\n", len(comments))
+			if len(comments) > maxDetailComments {
+				comments = comments[len(comments)-maxDetailComments:]
+			}
+			for _, comment := range comments {
+				fmt.Fprintf(&b, "\n%s, %s\n%s\n", comment.Author.DisplayName,
+					formatJiraTime(comment.Created), strings.TrimSpace(comment.Body))
+			}
+		}
+
+		if children, err := jira.GetChildTicketsDetailed(client, key, cfg.EpicLinkFieldID); err == nil &&
+			len(children) > 0 {
+			fmt.Fprintf(&b, "\nChildren (%d)\n", len(children))
+			for _, child := range children {
+				fmt.Fprintf(&b, "%s [%s] %s\n", child.Key, child.Type, child.Summary)
+			}
+		}
+
+		return b.String(), nil
+	}
+}
+
+// runReviewTUI shows the review queue full screen; actions run the review workflow,
+// or one of its steps, on the selected tickets
+func runReviewTUI(
+	client jira.JiraClient, geminiClient gemini.GeminiClient, reader *bufio.Reader, cfg *config.Config,
+	issues []jira.Issue, configDir string, rs *reviewSession,
+) error {
+	selected, actedOn := rs.restoreSelection()
+	defer rs.close()
+
+	byKey := map[string]*jira.Issue{}
+	for i := range issues {
+		byKey[issues[i].Key] = &issues[i]
+	}
+
+	runSteps := func(stepCfg *config.Config) func(key string) error {
+		return func(key string) error {
+			ticket, err := client.GetIssue(key)
+			if err != nil {
+				ticket = byKey[key]
+			}
+			defaults := reviewStepDefaults(client, stepCfg, configDir, ticket)
+			progress := rs.progress(key, selected, actedOn)
+			return review.ProcessTicketWorkflow(
+				client, geminiClient, reader, stepCfg, ticket, configDir, defaults, progress)
+		}
+	}
+
+	actions := []tui.Action{{Key: 'r', Label: "review", Run: runSteps(cfg)}}
+	for _, step := range reviewStepActions {
+		actions = append(actions, tui.Action{
+			Key: step.key, Label: step.label, Run: runSteps(review.OnlyStep(cfg, step.stepID)),
+		})
+	}
+
+	q := tui.NewQueue("Review", ticketRows(issues), actions)
+	q.Selected = selected
+	q.Done = actedOn
+	q.Details = ticketDetails(client, cfg)
+	q.Refresh = refreshTicketRow(client)
+	q.OnChange = func() { rs.save(selected, actedOn, rs.session.Page) }
+
+	return tui.Run(q)
+}
+
+// runEstimateTUI shows the tickets without story points full screen
+func runEstimateTUI(
+	client jira.JiraClient, cfg *config.Config, issues []jira.Issue, storyPoints []int, configDir string,
+) error {
+	actions := []tui.Action{{Key: 'e', Label: "estimate", Run: func(key string) error {
+		return estimateSingleTicket(client, cfg, key, storyPoints, configDir)
+	}}}
+
+	q := tui.NewQueue("Estimate", ticketRows(issues), actions)
+	q.Details = ticketDetails(client, cfg)
+	q.Refresh = refreshTicketRow(client)
+	return tui.Run(q)
+}
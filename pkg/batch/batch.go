@@ -0,0 +1,269 @@
+package batch
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+// Batch is a set of tickets created together, e.g. the child tickets of a decompose or the
+// Epic and tasks of an accept. It is saved after every step, so a batch that fails halfway
+// can be resumed from the failed item or rolled back instead of leaving orphaned tickets.
+type Batch struct {
+	// Name identifies the batch, e.g. "accept-ENG-123"
+	Name    string `json:"name"`
+	Command string `json:"command"`
+	// Source is the ticket the batch was created from
+	Source  string `json:"source"`
+	Project string `json:"project"`
+	Items   []Item `json:"items"`
+	// SourcePoints, if set, are written to the source ticket once all items exist
+	SourcePoints int `json:"source_points,omitempty"`
+	// CloseSource transitions the source ticket to Done once all items exist
+	CloseSource bool `json:"close_source,omitempty"`
+	// Finished is set once the follow-up changes to the source ticket are made
+	Finished bool `json:"finished,omitempty"`
+	// Error is the error that stopped the last run
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Item is one ticket to create in a batch
+type Item struct {
+	Type        string `json:"type"`
+	Summary     string `json:"summary"`
+	Description string `json:"description,omitempty"`
+	StoryPoints int    `json:"story_points,omitempty"`
+	// Parent is the key of an existing parent ticket
+	Parent string `json:"parent,omitempty"`
+	// ParentItem is the 1-based position of an earlier item that is the parent, used instead of Parent
+	ParentItem int `json:"parent_item,omitempty"`
+	// EpicLinkField, if set, links the item to its parent with this Epic Link field
+	// instead of the parent field
+	EpicLinkField string `json:"epic_link_field,omitempty"`
+	// Key is set as soon as the ticket is created
+	Key string `json:"key,omitempty"`
+	// Complete is set once the description and story points are set as well
+	Complete bool `json:"complete,omitempty"`
+}
+
+// NameFor returns the name of the batch a command creates from a source ticket
+func NameFor(command, source string) string {
+	return command + "-" + source
+}
+
+// New starts a batch of items created from a source ticket
+func New(command, source, project string, items []Item) *Batch {
+	now := time.Now()
+	return &Batch{
+		Name:      NameFor(command, source),
+		Command:   command,
+		Source:    source,
+		Project:   project,
+		Items:     items,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// CreatedKeys returns the keys of the tickets created so far, in item order
+func (b *Batch) CreatedKeys() []string {
+	keys := []string{}
+	for i := range b.Items {
+		if b.Items[i].Key != "" {
+			keys = append(keys, b.Items[i].Key)
+		}
+	}
+	return keys
+}
+
+// Runner creates the tickets of a batch, saving its progress after every step
+type Runner struct {
+	client jira.JiraClient
+	// store may be nil, e.g. in a dry run, in which case progress isn't saved
+	store *Store
+}
+
+// NewRunner creates a runner that saves progress to store (which may be nil)
+func NewRunner(client jira.JiraClient, store *Store) *Runner {
+	return &Runner{client: client, store: store}
+}
+
+func (r *Runner) save(b *Batch) error {
+	if r.store == nil {
+		return nil
+	}
+	return r.store.Save(b)
+}
+
+// Run creates the remaining items of a batch in order, then makes the follow-up changes to the
+// source ticket. It stops at the first failure, leaving the batch saved so it can be resumed
+// (by calling Run again) or rolled back.
+func (r *Runner) Run(b *Batch) error {
+	b.Error = ""
+	if err := r.save(b); err != nil {
+		return err
+	}
+
+	for i := range b.Items {
+		if b.Items[i].Complete {
+			continue
+		}
+		fmt.Printf("Creating ticket %d of %d...\n", i+1, len(b.Items))
+		if err := r.createItem(b, i); err != nil {
+			return r.fail(b, fmt.Errorf("item %d (%q): %w", i+1, b.Items[i].Summary, err))
+		}
+	}
+
+	if !b.Finished {
+		if err := r.finish(b); err != nil {
+			return r.fail(b, err)
+		}
+	}
+
+	if r.store != nil {
+		if err := r.store.Delete(b.Name); err != nil {
+			_ = err // Ignore - a finished batch only shows up in 'utils batches'
+		}
+	}
+	return nil
+}
+
+// fail records the error that stopped the batch
+func (r *Runner) fail(b *Batch, err error) error {
+	b.Error = err.Error()
+	if saveErr := r.save(b); saveErr != nil {
+		return fmt.Errorf("%w (and the batch could not be saved: %v)", err, saveErr)
+	}
+	return err
+}
+
+// createItem creates one item, or finishes setting it up if it was created in an earlier run
+func (r *Runner) createItem(b *Batch, index int) error {
+	item := &b.Items[index]
+
+	if item.Key == "" {
+		key, err := r.create(b, item)
+		if err != nil {
+			return err
+		}
+		item.Key = key
+		if err := r.save(b); err != nil {
+			return err
+		}
+		fmt.Printf("Created %s: %s\n", item.Type, key)
+	}
+
+	if item.Description != "" {
+		if err := r.client.UpdateTicketDescription(item.Key, item.Description); err != nil {
+			return fmt.Errorf("failed to set description of %s: %w", item.Key, err)
+		}
+	}
+	if item.StoryPoints > 0 {
+		if err := r.client.UpdateTicketPoints(item.Key, item.StoryPoints); err != nil {
+			return fmt.Errorf("failed to set story points of %s: %w", item.Key, err)
+		}
+	}
+
+	item.Complete = true
+	return r.save(b)
+}
+
+func (r *Runner) create(b *Batch, item *Item) (string, error) {
+	parent := item.Parent
+	if item.ParentItem > 0 {
+		if item.ParentItem > len(b.Items) || b.Items[item.ParentItem-1].Key == "" {
+			return "", fmt.Errorf("parent item %d has not been created", item.ParentItem)
+		}
+		parent = b.Items[item.ParentItem-1].Key
+	}
+
+	var key string
+	var err error
+	switch {
+	case parent == "":
+		key, err = r.client.CreateTicket(b.Project, item.Type, item.Summary)
+	case item.EpicLinkField != "":
+		key, err = r.client.CreateTicketWithEpicLink(b.Project, item.Type, item.Summary, parent, item.EpicLinkField)
+	default:
+		key, err = r.client.CreateTicketWithParent(b.Project, item.Type, item.Summary, parent)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to create ticket: %w", err)
+	}
+	return key, nil
+}
+
+// finish makes the changes to the source ticket that wait until every item exists
+func (r *Runner) finish(b *Batch) error {
+	if b.SourcePoints > 0 {
+		if err := r.client.UpdateTicketPoints(b.Source, b.SourcePoints); err != nil {
+			return fmt.Errorf("failed to update story points of %s: %w", b.Source, err)
+		}
+	}
+	if b.CloseSource {
+		if err := CloseTicket(r.client, b.Source); err != nil {
+			return fmt.Errorf("failed to transition %s to Done: %w", b.Source, err)
+		}
+	}
+
+	b.Finished = true
+	return r.save(b)
+}
+
+// Rollback removes the tickets a batch created, newest first. Tickets that can't be deleted
+// (e.g. for lack of permission) are transitioned to Done instead. Once every ticket is
+// handled the saved batch is removed; otherwise it is kept with the tickets still left.
+func (r *Runner) Rollback(b *Batch) error {
+	var errs []string
+	for i := len(b.Items) - 1; i >= 0; i-- {
+		item := &b.Items[i]
+		if item.Key == "" {
+			continue
+		}
+
+		if err := r.client.DeleteTicket(item.Key); err == nil {
+			fmt.Printf("Deleted %s\n", item.Key)
+		} else if closeErr := CloseTicket(r.client, item.Key); closeErr == nil {
+			fmt.Printf("Closed %s (could not delete it: %v)\n", item.Key, err)
+		} else {
+			errs = append(errs, fmt.Sprintf("%s: %v; %v", item.Key, err, closeErr))
+			continue
+		}
+
+		item.Key = ""
+		item.Complete = false
+	}
+
+	if len(errs) > 0 {
+		err := fmt.Errorf("could not roll back %d ticket(s): %s", len(errs), strings.Join(errs, "; "))
+		return r.fail(b, err)
+	}
+
+	if r.store != nil {
+		return r.store.Delete(b.Name)
+	}
+	return nil
+}
+
+// ErrNoDoneTransition is returned by CloseTicket when the workflow has no way to Done from the current status
+var ErrNoDoneTransition = errors.New("no transition to Done or Closed")
+
+// CloseTicket transitions a ticket to Done (or Closed)
+func CloseTicket(client jira.JiraClient, ticketID string) error {
+	transitions, err := client.GetTransitions(ticketID)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range transitions {
+		if strings.EqualFold(t.To.Name, "Done") || strings.EqualFold(t.To.Name, "Closed") {
+			return client.TransitionTicket(ticketID, t.ID)
+		}
+	}
+	return fmt.Errorf("could not close %s: %w", ticketID, ErrNoDoneTransition)
+}
@@ -0,0 +1,177 @@
+package batch
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+// fakeClient creates numbered tickets and can be told to fail
+type fakeClient struct {
+	jira.JiraClient
+	created     int
+	failCreate  int // fail the Nth create (1-based), once
+	parents     map[string]string
+	points      map[string]int
+	transitions map[string]string
+	deleted     []string
+	noDelete    map[string]bool
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		parents:     map[string]string{},
+		points:      map[string]int{},
+		transitions: map[string]string{},
+		noDelete:    map[string]bool{},
+	}
+}
+
+func (c *fakeClient) next() (string, error) {
+	if c.failCreate == c.created+1 {
+		c.failCreate = 0
+		return "", errors.New("server error")
+	}
+	c.created++
+	return fmt.Sprintf("ENG-%d", 100+c.created), nil
+}
+
+func (c *fakeClient) CreateTicket(_, _, _ string) (string, error) {
+	return c.next()
+}
+
+func (c *fakeClient) CreateTicketWithParent(_, _, _, parentKey string) (string, error) {
+	key, err := c.next()
+	if err == nil {
+		c.parents[key] = parentKey
+	}
+	return key, err
+}
+
+func (c *fakeClient) UpdateTicketDescription(_, _ string) error {
+	return nil
+}
+
+func (c *fakeClient) UpdateTicketPoints(ticketID string, points int) error {
+	c.points[ticketID] = points
+	return nil
+}
+
+func (c *fakeClient) GetTransitions(_ string) ([]jira.Transition, error) {
+	var done jira.Transition
+	done.ID, done.To.Name = "31", "Done"
+	return []jira.Transition{done}, nil
+}
+
+func (c *fakeClient) TransitionTicket(ticketID, transitionID string) error {
+	c.transitions[ticketID] = transitionID
+	return nil
+}
+
+func (c *fakeClient) DeleteTicket(ticketID string) error {
+	if c.noDelete[ticketID] {
+		return errors.New("forbidden")
+	}
+	c.deleted = append(c.deleted, ticketID)
+	return nil
+}
+
+func epicBatch() *Batch {
+	b := New("accept", "ENG-1", "ENG", []Item{
+		{Type: "Epic", Summary: "Epic", Description: "Plan"},
+		{Type: "Task", Summary: "Task 1", ParentItem: 1},
+		{Type: "Task", Summary: "Task 2", ParentItem: 1},
+	})
+	b.CloseSource = true
+	return b
+}
+
+func TestRunResumesFromFailedItem(t *testing.T) {
+	store := NewStore(t.TempDir())
+	client := newFakeClient()
+	client.failCreate = 3
+	runner := NewRunner(client, store)
+	b := epicBatch()
+
+	if err := runner.Run(b); err == nil {
+		t.Fatal("Expected the third create to fail")
+	}
+	if _, ok := client.transitions["ENG-1"]; ok {
+		t.Error("Expected the source ticket to stay open while tasks are missing")
+	}
+
+	saved, err := store.Load(b.Name)
+	if err != nil || saved == nil {
+		t.Fatalf("Expected the failed batch to be saved, got %v (err %v)", saved, err)
+	}
+	if keys := saved.CreatedKeys(); len(keys) != 2 || saved.Error == "" {
+		t.Fatalf("Expected 2 created tickets and the error to be saved, got %v (%q)", keys, saved.Error)
+	}
+
+	// Resume from the saved copy, as 'utils batches resume' does
+	if err := runner.Run(saved); err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	if keys := saved.CreatedKeys(); len(keys) != 3 || keys[2] != "ENG-103" {
+		t.Errorf("Expected the remaining task to be created as ENG-103, got %v", keys)
+	}
+	if client.parents["ENG-103"] != "ENG-101" {
+		t.Errorf("Expected the task to be created under the Epic, got parent %q", client.parents["ENG-103"])
+	}
+	if client.transitions["ENG-1"] != "31" {
+		t.Error("Expected the source ticket to be transitioned to Done once all tickets exist")
+	}
+	if remaining, _ := store.Load(b.Name); remaining != nil {
+		t.Error("Expected the finished batch to be removed")
+	}
+}
+
+func TestRollback(t *testing.T) {
+	store := NewStore(t.TempDir())
+	client := newFakeClient()
+	client.failCreate = 3
+	client.noDelete["ENG-101"] = true
+	runner := NewRunner(client, store)
+	b := epicBatch()
+
+	if err := runner.Run(b); err == nil {
+		t.Fatal("Expected the third create to fail")
+	}
+	if err := runner.Rollback(b); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+
+	if len(client.deleted) != 1 || client.deleted[0] != "ENG-102" {
+		t.Errorf("Expected the task to be deleted, got %v", client.deleted)
+	}
+	if client.transitions["ENG-101"] != "31" {
+		t.Error("Expected the Epic that couldn't be deleted to be closed")
+	}
+	if len(b.CreatedKeys()) != 0 {
+		t.Errorf("Expected no tickets left in the batch, got %v", b.CreatedKeys())
+	}
+	if remaining, _ := store.Load(b.Name); remaining != nil {
+		t.Error("Expected the rolled back batch to be removed")
+	}
+}
+
+func TestRunSetsPointsWithoutStore(t *testing.T) {
+	client := newFakeClient()
+	b := New("decompose", "ENG-5", "ENG", []Item{
+		{Type: "Sub-task", Summary: "A", StoryPoints: 3, Parent: "ENG-5"},
+		{Type: "Sub-task", Summary: "B", StoryPoints: 2, Parent: "ENG-5"},
+	})
+	b.SourcePoints = 5
+
+	if err := NewRunner(client, nil).Run(b); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if client.points["ENG-101"] != 3 || client.points["ENG-102"] != 2 || client.points["ENG-5"] != 5 {
+		t.Errorf("Unexpected story points: %v", client.points)
+	}
+	if client.parents["ENG-101"] != "ENG-5" {
+		t.Errorf("Expected children of ENG-5, got %v", client.parents)
+	}
+}
@@ -0,0 +1,112 @@
+package batch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Store saves unfinished batches as one JSON file per batch
+type Store struct {
+	dir string
+}
+
+// GetBatchesDir returns the directory used for unfinished batches
+// If configDir is empty, uses the default ~/.jira-tool
+func GetBatchesDir(configDir string) string {
+	if configDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "./.jira-tool/batches"
+		}
+		configDir = filepath.Join(homeDir, ".jira-tool")
+	}
+	return filepath.Join(configDir, "batches")
+}
+
+// NewStore creates a batch store in dir
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+func (s *Store) batchPath(name string) string {
+	return filepath.Join(s.dir, strings.ReplaceAll(name, string(filepath.Separator), "_")+".json")
+}
+
+// Save writes a batch, replacing any previous copy
+func (s *Store) Save(b *Batch) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create batches directory: %w", err)
+	}
+
+	b.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch: %w", err)
+	}
+
+	if err := os.WriteFile(s.batchPath(b.Name), data, 0600); err != nil {
+		return fmt.Errorf("failed to write batch: %w", err)
+	}
+	return nil
+}
+
+// Load reads a batch by name
+// Returns nil (not an error) if no such batch exists
+func (s *Store) Load(name string) (*Batch, error) {
+	data, err := os.ReadFile(s.batchPath(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read batch: %w", err)
+	}
+
+	var b Batch
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse batch %s: %w", name, err)
+	}
+	return &b, nil
+}
+
+// Delete removes a batch; deleting a missing batch is not an error
+func (s *Store) Delete(name string) error {
+	if err := os.Remove(s.batchPath(name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete batch: %w", err)
+	}
+	return nil
+}
+
+// List returns all unfinished batches, most recently updated first
+func (s *Store) List() ([]*Batch, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Batch{}, nil
+		}
+		return nil, fmt.Errorf("failed to read batches directory: %w", err)
+	}
+
+	batches := []*Batch{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		b, err := s.Load(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil || b == nil {
+			// Skip unreadable batches rather than failing the whole listing
+			continue
+		}
+		batches = append(batches, b)
+	}
+
+	sort.Slice(batches, func(i, j int) bool {
+		return batches[i].UpdatedAt.After(batches[j].UpdatedAt)
+	})
+
+	return batches, nil
+}
@@ -26,7 +26,7 @@ type Config struct {
 	StoryPointsFieldID                string `yaml:"story_points_field_id,omitempty"`
 	// Minimum description length (default: 128)
 	DescriptionMinLength int `yaml:"description_min_length,omitempty"`
-	// Enable Gemini AI analysis for description quality (default: false)
+	// Enable Gemini rubric scoring of description quality in review (default: false)
 	DescriptionQualityAI bool `yaml:"description_quality_ai,omitempty"`
 	// Custom field ID for severity (optional)
 	SeverityFieldID string `yaml:"severity_field_id,omitempty"`
@@ -44,6 +44,31 @@ type Config struct {
 	DefaultMaxDecomposePoints int `yaml:"default_max_decompose_points,omitempty"`
 	// Prompt template for decomposition planning with Gemini AI
 	DecomposePromptTemplate string `yaml:"decompose_prompt_template,omitempty"`
+	// Cache Gemini responses keyed by a hash of the model and prompt (default: false)
+	GeminiResponseCache bool `yaml:"gemini_response_cache,omitempty"`
+	// How long cached Gemini responses stay valid, in hours (default: 168)
+	GeminiResponseCacheTTLHours int `yaml:"gemini_response_cache_ttl_hours,omitempty"`
+	// Warn once this many AI tokens have been used in a single command run (default: 0, no limit)
+	AIRunSoftLimitTokens int `yaml:"ai_run_soft_limit_tokens,omitempty"`
+	// Refuse further AI calls once this many tokens have been used in a single command run (default: 0, no limit)
+	AIRunHardLimitTokens int `yaml:"ai_run_hard_limit_tokens,omitempty"`
+	// Warn once this many AI tokens have been used today (default: 0, no limit)
+	AIDailySoftLimitTokens int `yaml:"ai_daily_soft_limit_tokens,omitempty"`
+	// Refuse further AI calls once this many tokens have been used today (default: 0, no limit)
+	AIDailyHardLimitTokens int `yaml:"ai_daily_hard_limit_tokens,omitempty"`
+	// Price in USD per million input tokens, overriding the built-in price for the model (optional)
+	AIInputPricePerMillion float64 `yaml:"ai_input_price_per_million,omitempty"`
+	// Price in USD per million output tokens, overriding the built-in price for the model (optional)
+	AIOutputPricePerMillion float64 `yaml:"ai_output_price_per_million,omitempty"`
+	// Number of similar completed tickets used to calibrate AI estimates (default: 5, negative disables)
+	EstimateReferenceCount int `yaml:"estimate_reference_count,omitempty"`
+	// Steps of the guided review workflow, by project and issue type (default: the built-in steps)
+	ReviewWorkflow ReviewWorkflow `yaml:"review_workflow,omitempty"`
+	// Branch name for 'jira branch', with {type}, {key}, {project} and {slug} placeholders
+	// (default: "{type}/{key}-{slug}")
+	BranchPattern string `yaml:"branch_pattern,omitempty"`
+	// {type} in branch names by issue type, e.g. Bug: fix (default: the issue type in lower case)
+	BranchTypes map[string]string `yaml:"branch_types,omitempty"`
 }
 
 // GetConfigPath returns the path for the config file
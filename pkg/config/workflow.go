@@ -0,0 +1,109 @@
+package config
+
+import "strings"
+
+// ReviewWorkflow configures the steps of the guided review workflow
+//
+// Steps are named by ID: description, component, priority, severity, story_points,
+// backlog, assignment, labels, fix_version, due_date, links, or the name of a field step.
+// The most specific step list wins: project and issue type, project, issue type, then steps.
+// Disabled steps are then removed from whichever list was chosen.
+type ReviewWorkflow struct {
+	// Step order (default: description, component, priority, severity, story_points, backlog, assignment)
+	Steps []string `yaml:"steps,omitempty"`
+	// Steps to leave out
+	Disabled []string `yaml:"disabled,omitempty"`
+	// Step lists by issue type name
+	IssueTypes map[string][]string `yaml:"issue_types,omitempty"`
+	// Overrides by project key
+	Projects map[string]ProjectWorkflow `yaml:"projects,omitempty"`
+	// User-defined steps that set custom fields
+	FieldSteps []FieldStep `yaml:"field_steps,omitempty"`
+}
+
+// ProjectWorkflow overrides the review steps for one project
+type ProjectWorkflow struct {
+	Steps      []string            `yaml:"steps,omitempty"`
+	Disabled   []string            `yaml:"disabled,omitempty"`
+	IssueTypes map[string][]string `yaml:"issue_types,omitempty"`
+}
+
+// FieldStep is a user-defined review step that prompts for one or more fields
+// The step is done once all its fields have a value
+type FieldStep struct {
+	// ID used in step lists
+	Name string `yaml:"name"`
+	// Shown in the progress list (default: Name)
+	Label  string        `yaml:"label,omitempty"`
+	Fields []FieldPrompt `yaml:"fields"`
+}
+
+// FieldPrompt is a field a field step asks for
+type FieldPrompt struct {
+	// Jira field ID, e.g. customfield_12345 or duedate
+	ID string `yaml:"id"`
+	// Shown when prompting (default: ID)
+	Label string `yaml:"label,omitempty"`
+	// text (default), number, date (YYYY-MM-DD) or option
+	Type string `yaml:"type,omitempty"`
+	// Allowed values for option fields
+	Options []string `yaml:"options,omitempty"`
+}
+
+// StepsFor returns the step list for a project and issue type, with disabled steps removed
+// defaults is used when no step list applies
+func (w *ReviewWorkflow) StepsFor(projectKey, issueType string, defaults []string) []string {
+	project, hasProject := w.Projects[projectKey]
+
+	var steps []string
+	switch {
+	case hasProject && lookupFold(project.IssueTypes, issueType) != nil:
+		steps = lookupFold(project.IssueTypes, issueType)
+	case hasProject && len(project.Steps) > 0:
+		steps = project.Steps
+	case lookupFold(w.IssueTypes, issueType) != nil:
+		steps = lookupFold(w.IssueTypes, issueType)
+	case len(w.Steps) > 0:
+		steps = w.Steps
+	default:
+		steps = defaults
+	}
+
+	disabled := append(append([]string{}, w.Disabled...), project.Disabled...)
+	result := []string{}
+	for _, step := range steps {
+		if !containsFold(disabled, step) {
+			result = append(result, step)
+		}
+	}
+	return result
+}
+
+// FieldStep returns the field step with the given name, or nil
+func (w *ReviewWorkflow) FieldStep(name string) *FieldStep {
+	for i := range w.FieldSteps {
+		if strings.EqualFold(w.FieldSteps[i].Name, name) {
+			return &w.FieldSteps[i]
+		}
+	}
+	return nil
+}
+
+// lookupFold finds a map entry by case-insensitive key
+func lookupFold(m map[string][]string, key string) []string {
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package estimate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+// Record is the outcome of estimating a single ticket
+type Record struct {
+	TicketKey    string    `json:"ticket_key"`
+	IssueType    string    `json:"issue_type,omitempty"`
+	Components   []string  `json:"components,omitempty"`
+	AIPoints     int       `json:"ai_points,omitempty"` // 0 if no AI estimate was available
+	ChosenPoints int       `json:"chosen_points"`
+	EstimatedAt  time.Time `json:"estimated_at"`
+	// Filled in later, once the ticket is done
+	CycleTimeHours float64   `json:"cycle_time_hours,omitempty"`
+	CompletedAt    time.Time `json:"completed_at,omitempty"`
+}
+
+// HasAI reports whether an AI estimate was recorded
+func (r *Record) HasAI() bool {
+	return r.AIPoints > 0
+}
+
+// Completed reports whether the cycle time has been recorded
+func (r *Record) Completed() bool {
+	return !r.CompletedAt.IsZero()
+}
+
+// History holds estimate records keyed by ticket
+type History struct {
+	Records map[string]*Record `json:"records"`
+}
+
+// GetHistoryPath returns the path for the estimate history file
+// If configDir is empty, uses the default ~/.jira-tool
+func GetHistoryPath(configDir string) string {
+	if configDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "./.jira-tool/estimates.json"
+		}
+		configDir = filepath.Join(homeDir, ".jira-tool")
+	}
+	return filepath.Join(configDir, "estimates.json")
+}
+
+// LoadHistory loads the estimate history from path
+// Returns an empty history if the file doesn't exist (not an error)
+func LoadHistory(path string) (*History, error) {
+	history := &History{Records: map[string]*Record{}}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return history, nil
+		}
+		return nil, fmt.Errorf("failed to read estimate history: %w", err)
+	}
+
+	if err := json.Unmarshal(data, history); err != nil {
+		return nil, fmt.Errorf("failed to parse estimate history: %w", err)
+	}
+	if history.Records == nil {
+		history.Records = map[string]*Record{}
+	}
+
+	return history, nil
+}
+
+// SaveHistory saves the estimate history to path
+func SaveHistory(history *History, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create estimate history directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal estimate history: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write estimate history: %w", err)
+	}
+
+	return nil
+}
+
+// Add records the chosen points (and AI suggestion, if any) for a ticket,
+// replacing any earlier record for the same ticket
+func (h *History) Add(ticket *jira.Issue, aiPoints, chosenPoints int) {
+	components := make([]string, 0, len(ticket.Fields.Components))
+	for _, c := range ticket.Fields.Components {
+		components = append(components, c.Name)
+	}
+
+	h.Records[ticket.Key] = &Record{
+		TicketKey:    ticket.Key,
+		IssueType:    ticket.Fields.IssueType.Name,
+		Components:   components,
+		AIPoints:     aiPoints,
+		ChosenPoints: chosenPoints,
+		EstimatedAt:  time.Now(),
+	}
+}
+
+// RecordEstimate adds an estimate to the history in configDir
+// Errors are ignored - accuracy tracking is optional and must not interrupt estimating
+func RecordEstimate(configDir string, ticket *jira.Issue, aiPoints, chosenPoints int) {
+	path := GetHistoryPath(configDir)
+	history, err := LoadHistory(path)
+	if err != nil {
+		return
+	}
+
+	history.Add(ticket, aiPoints, chosenPoints)
+	if err := SaveHistory(history, path); err != nil {
+		_ = err // Ignore - accuracy tracking is optional
+	}
+}
+
+// UpdateCycleTimes fills in the cycle time of records whose tickets are now done
+// Returns the number of records updated
+func (h *History) UpdateCycleTimes(client jira.JiraClient) int {
+	updated := 0
+	var categories jira.StatusCategories
+	for _, record := range h.Records {
+		if record.Completed() {
+			continue
+		}
+		if categories == nil {
+			var err error
+			if categories, err = client.GetStatusCategories(); err != nil {
+				return updated // Without categories there is no telling when work started or finished
+			}
+		}
+
+		changes, err := client.GetStatusHistory(record.TicketKey)
+		if err != nil {
+			continue // Ticket may have been deleted or be inaccessible
+		}
+
+		cycle, ok := jira.CycleTime(changes, categories)
+		if !ok {
+			continue
+		}
+
+		record.CycleTimeHours = cycle.Hours()
+		record.CompletedAt = changes[len(changes)-1].At
+		updated++
+	}
+	return updated
+}
@@ -0,0 +1,180 @@
+package estimate
+
+import (
+	"math"
+	"sort"
+)
+
+// GroupBias is how far AI estimates were from the chosen points for a group of tickets
+type GroupBias struct {
+	Name  string
+	Count int
+	// MeanDiff is the mean of (AI points - chosen points); positive means the AI overestimates
+	MeanDiff float64
+	Agreed   int
+}
+
+// PointsCycleTime summarizes the actual cycle time of tickets given the same points
+type PointsCycleTime struct {
+	Points     int
+	Count      int
+	MedianDays float64
+	MeanDays   float64
+}
+
+// Report summarizes how AI estimates compare with the team's choices and actual cycle time
+type Report struct {
+	Total         int // Records in the history
+	WithAI        int // Records with an AI suggestion
+	Agreed        int // AI suggestion equal to chosen points
+	WithinOneStep int // AI suggestion at most one step away on the point scale
+	MeanAbsError  float64
+	MeanDiff      float64 // Mean of (AI - chosen) across all records with AI
+	ByIssueType   []GroupBias
+	ByComponent   []GroupBias
+	CycleTimes    []PointsCycleTime
+}
+
+// AgreementRate returns the fraction of AI suggestions that matched the chosen points
+func (r *Report) AgreementRate() float64 {
+	if r.WithAI == 0 {
+		return 0
+	}
+	return float64(r.Agreed) / float64(r.WithAI)
+}
+
+// WithinOneStepRate returns the fraction of AI suggestions at most one step from the chosen points
+func (r *Report) WithinOneStepRate() float64 {
+	if r.WithAI == 0 {
+		return 0
+	}
+	return float64(r.WithinOneStep) / float64(r.WithAI)
+}
+
+// scaleStep returns the position of points on the scale, placing values that
+// aren't on the scale between their neighbours
+func scaleStep(scale []int, points int) float64 {
+	for i, p := range scale {
+		if points == p {
+			return float64(i)
+		}
+		if points < p {
+			return float64(i) - 0.5
+		}
+	}
+	return float64(len(scale)) - 0.5
+}
+
+// BuildReport computes the accuracy report for a history using the given point scale
+func BuildReport(history *History, scale []int) Report {
+	sortedScale := append([]int(nil), scale...)
+	sort.Ints(sortedScale)
+
+	report := Report{Total: len(history.Records)}
+	byType := map[string]*GroupBias{}
+	byComponent := map[string]*GroupBias{}
+	cycleDays := map[int][]float64{}
+
+	addBias := func(groups map[string]*GroupBias, name string, diff int) {
+		g, ok := groups[name]
+		if !ok {
+			g = &GroupBias{Name: name}
+			groups[name] = g
+		}
+		g.Count++
+		g.MeanDiff += float64(diff) // Summed here, averaged below
+		if diff == 0 {
+			g.Agreed++
+		}
+	}
+
+	sumAbs, sumDiff := 0.0, 0.0
+	for _, record := range history.Records {
+		if record.Completed() {
+			cycleDays[record.ChosenPoints] = append(cycleDays[record.ChosenPoints], record.CycleTimeHours/24)
+		}
+
+		if !record.HasAI() {
+			continue
+		}
+
+		report.WithAI++
+		diff := record.AIPoints - record.ChosenPoints
+		if diff == 0 {
+			report.Agreed++
+		}
+		if math.Abs(scaleStep(sortedScale, record.AIPoints)-scaleStep(sortedScale, record.ChosenPoints)) <= 1 {
+			report.WithinOneStep++
+		}
+		sumAbs += math.Abs(float64(diff))
+		sumDiff += float64(diff)
+
+		issueType := record.IssueType
+		if issueType == "" {
+			issueType = "(none)"
+		}
+		addBias(byType, issueType, diff)
+
+		if len(record.Components) == 0 {
+			addBias(byComponent, "(none)", diff)
+		}
+		for _, component := range record.Components {
+			addBias(byComponent, component, diff)
+		}
+	}
+
+	if report.WithAI > 0 {
+		report.MeanAbsError = sumAbs / float64(report.WithAI)
+		report.MeanDiff = sumDiff / float64(report.WithAI)
+	}
+
+	report.ByIssueType = sortedGroups(byType)
+	report.ByComponent = sortedGroups(byComponent)
+
+	for points, days := range cycleDays {
+		report.CycleTimes = append(report.CycleTimes, summarizeCycleTimes(points, days))
+	}
+	sort.Slice(report.CycleTimes, func(i, j int) bool {
+		return report.CycleTimes[i].Points < report.CycleTimes[j].Points
+	})
+
+	return report
+}
+
+// sortedGroups averages group totals and orders groups by size, largest first
+func sortedGroups(groups map[string]*GroupBias) []GroupBias {
+	result := make([]GroupBias, 0, len(groups))
+	for _, g := range groups {
+		g.MeanDiff /= float64(g.Count)
+		result = append(result, *g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func summarizeCycleTimes(points int, days []float64) PointsCycleTime {
+	sorted := append([]float64(nil), days...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, d := range sorted {
+		sum += d
+	}
+
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+
+	return PointsCycleTime{
+		Points:     points,
+		Count:      len(sorted),
+		MedianDays: median,
+		MeanDays:   sum / float64(len(sorted)),
+	}
+}
@@ -0,0 +1,130 @@
+package estimate
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+func TestHistorySaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "estimates.json")
+
+	history, err := LoadHistory(path)
+	if err != nil {
+		t.Fatalf("Failed to load missing history: %v", err)
+	}
+	if len(history.Records) != 0 {
+		t.Errorf("Expected empty history, got %d records", len(history.Records))
+	}
+
+	ticket := newIssue("ENG-1", "Add login", 0)
+	ticket.Fields.IssueType.Name = "Story"
+	history.Add(&ticket, 3, 5)
+	if err := SaveHistory(history, path); err != nil {
+		t.Fatalf("Failed to save history: %v", err)
+	}
+
+	loaded, err := LoadHistory(path)
+	if err != nil {
+		t.Fatalf("Failed to load history: %v", err)
+	}
+	record := loaded.Records["ENG-1"]
+	if record == nil || record.AIPoints != 3 || record.ChosenPoints != 5 || record.IssueType != "Story" {
+		t.Errorf("Unexpected record: %+v", record)
+	}
+}
+
+func TestBuildReport(t *testing.T) {
+	done := time.Now()
+	history := &History{Records: map[string]*Record{
+		"ENG-1": {TicketKey: "ENG-1", IssueType: "Story", Components: []string{"API"}, AIPoints: 3, ChosenPoints: 3,
+			CycleTimeHours: 48, CompletedAt: done},
+		"ENG-2": {TicketKey: "ENG-2", IssueType: "Story", Components: []string{"API"}, AIPoints: 8, ChosenPoints: 5,
+			CycleTimeHours: 96, CompletedAt: done},
+		"ENG-3": {TicketKey: "ENG-3", IssueType: "Bug", AIPoints: 1, ChosenPoints: 5},
+		"ENG-4": {TicketKey: "ENG-4", IssueType: "Bug", ChosenPoints: 5, CycleTimeHours: 144, CompletedAt: done},
+	}}
+
+	report := BuildReport(history, []int{1, 2, 3, 5, 8, 13})
+
+	if report.Total != 4 || report.WithAI != 3 {
+		t.Errorf("Expected 4 records, 3 with AI, got %d and %d", report.Total, report.WithAI)
+	}
+	if report.Agreed != 1 {
+		t.Errorf("Expected 1 agreement, got %d", report.Agreed)
+	}
+	// 3 vs 3 and 8 vs 5 are within one step; 1 vs 5 is not
+	if report.WithinOneStep != 2 {
+		t.Errorf("Expected 2 within one step, got %d", report.WithinOneStep)
+	}
+	if math.Abs(report.MeanDiff-(-1.0/3)) > 1e-9 {
+		t.Errorf("Expected mean bias -1/3, got %v", report.MeanDiff)
+	}
+
+	if len(report.ByIssueType) != 2 || report.ByIssueType[0].Name != "Bug" && report.ByIssueType[0].Name != "Story" {
+		t.Fatalf("Unexpected issue type breakdown: %+v", report.ByIssueType)
+	}
+	for _, g := range report.ByIssueType {
+		if g.Name == "Story" && g.MeanDiff != 1.5 {
+			t.Errorf("Expected Story bias +1.5, got %v", g.MeanDiff)
+		}
+	}
+
+	if len(report.CycleTimes) != 2 {
+		t.Fatalf("Expected cycle times for 2 point values, got %+v", report.CycleTimes)
+	}
+	five := report.CycleTimes[1]
+	if five.Points != 5 || five.Count != 2 || five.MedianDays != 5 {
+		t.Errorf("Expected 5 points: 2 tickets, median 5 days, got %+v", five)
+	}
+}
+
+// statusHistoryClient answers GetStatusHistory from a fixed map; other methods are not used
+type statusHistoryClient struct {
+	jira.JiraClient
+	changes map[string][]jira.StatusChange
+}
+
+func (c *statusHistoryClient) GetStatusHistory(ticketID string) ([]jira.StatusChange, error) {
+	return c.changes[ticketID], nil
+}
+
+func (c *statusHistoryClient) GetStatusCategories() (jira.StatusCategories, error) {
+	return jira.StatusCategories{
+		"Open": jira.StatusCategoryToDo, "Backlog": jira.StatusCategoryToDo, "To Do": jira.StatusCategoryToDo,
+		"In Progress": jira.StatusCategoryInProgress, "Shipped": jira.StatusCategoryDone,
+	}, nil
+}
+
+func TestUpdateCycleTimes(t *testing.T) {
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	client := &statusHistoryClient{changes: map[string][]jira.StatusChange{
+		// Time in the backlog before work starts doesn't count
+		"ENG-1": {
+			{From: "Open", To: "Backlog", At: start.Add(-240 * time.Hour)},
+			{From: "Backlog", To: "In Progress", At: start},
+			{From: "In Progress", To: "Shipped", At: start.Add(72 * time.Hour)},
+		},
+		"ENG-2": {
+			{From: "To Do", To: "In Progress", At: start},
+		},
+	}}
+
+	history := &History{Records: map[string]*Record{
+		"ENG-1": {TicketKey: "ENG-1", ChosenPoints: 3},
+		"ENG-2": {TicketKey: "ENG-2", ChosenPoints: 5},
+	}}
+
+	if updated := history.UpdateCycleTimes(client); updated != 1 {
+		t.Errorf("Expected 1 record updated, got %d", updated)
+	}
+	if history.Records["ENG-1"].CycleTimeHours != 72 {
+		t.Errorf("Expected 72h cycle time, got %v", history.Records["ENG-1"].CycleTimeHours)
+	}
+	if history.Records["ENG-2"].Completed() {
+		t.Error("ENG-2 is still in progress and should not be completed")
+	}
+}
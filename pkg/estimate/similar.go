@@ -0,0 +1,236 @@
+package estimate
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/beekhof/jira-tool/pkg/gemini"
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+// DefaultReferenceCount is how many reference tickets are included when not configured
+const DefaultReferenceCount = 5
+
+// stopWords are common words ignored when comparing ticket text
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"by": true, "for": true, "from": true, "in": true, "is": true, "it": true, "of": true,
+	"on": true, "or": true, "so": true, "that": true, "the": true, "this": true, "to": true,
+	"we": true, "when": true, "with": true, "should": true, "can": true, "will": true,
+}
+
+// tokenize splits text into lowercase words, dropping stop words and single characters
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(words))
+	for _, w := range words {
+		if len(w) < 2 || stopWords[w] {
+			continue
+		}
+		tokens = append(tokens, w)
+	}
+	return tokens
+}
+
+// termFrequencies counts each token in a document
+func termFrequencies(tokens []string) map[string]float64 {
+	tf := make(map[string]float64, len(tokens))
+	for _, t := range tokens {
+		tf[t]++
+	}
+	return tf
+}
+
+// RankBySimilarity orders documents by TF-IDF cosine similarity to query
+// Returns the indexes of documents with a non-zero similarity, most similar first
+func RankBySimilarity(query string, documents []string) []int {
+	docTerms := make([]map[string]float64, len(documents))
+	docFreq := map[string]float64{}
+	for i, doc := range documents {
+		docTerms[i] = termFrequencies(tokenize(doc))
+		for term := range docTerms[i] {
+			docFreq[term]++
+		}
+	}
+
+	// Smoothed inverse document frequency, so terms in every document still count a little
+	n := float64(len(documents))
+	idf := func(term string) float64 {
+		return math.Log((n+1)/(docFreq[term]+1)) + 1
+	}
+
+	weigh := func(tf map[string]float64) (map[string]float64, float64) {
+		vec := make(map[string]float64, len(tf))
+		norm := 0.0
+		for term, count := range tf {
+			w := count * idf(term)
+			vec[term] = w
+			norm += w * w
+		}
+		return vec, math.Sqrt(norm)
+	}
+
+	queryVec, queryNorm := weigh(termFrequencies(tokenize(query)))
+	if queryNorm == 0 {
+		return nil
+	}
+
+	type scored struct {
+		index int
+		score float64
+	}
+	var results []scored
+	for i := range documents {
+		docVec, docNorm := weigh(docTerms[i])
+		if docNorm == 0 {
+			continue
+		}
+		dot := 0.0
+		for term, w := range queryVec {
+			dot += w * docVec[term]
+		}
+		if dot > 0 {
+			results = append(results, scored{index: i, score: dot / (queryNorm * docNorm)})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].score > results[j].score
+	})
+
+	indexes := make([]int, len(results))
+	for i, r := range results {
+		indexes[i] = r.index
+	}
+	return indexes
+}
+
+// FindReferenceTickets finds completed, estimated tickets in the same project (and
+// components, if any) and returns the ones most similar to the ticket being estimated.
+// If fewer than limit tickets share a component, the whole project is searched.
+func FindReferenceTickets(
+	client jira.JiraClient, ticket *jira.Issue, description string, limit int,
+) ([]gemini.EstimateExample, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	baseJQL := fmt.Sprintf("project = %q AND statusCategory = Done AND key != %s",
+		jira.ProjectKey(ticket.Key), ticket.Key)
+
+	var candidates []jira.Issue
+	if len(ticket.Fields.Components) > 0 {
+		names := make([]string, len(ticket.Fields.Components))
+		for i, c := range ticket.Fields.Components {
+			names[i] = fmt.Sprintf("%q", c.Name)
+		}
+		jql := fmt.Sprintf("%s AND component in (%s) ORDER BY updated DESC", baseJQL, strings.Join(names, ", "))
+		issues, err := client.SearchTickets(jql)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search reference tickets: %w", err)
+		}
+		candidates = estimated(issues)
+	}
+
+	if len(candidates) < limit {
+		issues, err := client.SearchTickets(baseJQL + " ORDER BY updated DESC")
+		if err != nil {
+			return nil, fmt.Errorf("failed to search reference tickets: %w", err)
+		}
+		candidates = mergeIssues(candidates, estimated(issues))
+	}
+
+	summaries := make([]string, len(candidates))
+	for i := range candidates {
+		summaries[i] = candidates[i].Fields.Summary
+	}
+
+	query := ticket.Fields.Summary + "\n" + description
+	examples := []gemini.EstimateExample{}
+	for _, i := range RankBySimilarity(query, summaries) {
+		examples = append(examples, gemini.EstimateExample{
+			Key:     candidates[i].Key,
+			Summary: candidates[i].Fields.Summary,
+			Points:  int(candidates[i].Fields.StoryPoints),
+		})
+		if len(examples) >= limit {
+			break
+		}
+	}
+
+	return examples, nil
+}
+
+// estimated returns only the issues that have story points set
+func estimated(issues []jira.Issue) []jira.Issue {
+	result := []jira.Issue{}
+	for i := range issues {
+		if issues[i].Fields.StoryPoints > 0 {
+			result = append(result, issues[i])
+		}
+	}
+	return result
+}
+
+// mergeIssues appends issues from extra that aren't already in base
+func mergeIssues(base, extra []jira.Issue) []jira.Issue {
+	seen := make(map[string]bool, len(base))
+	for i := range base {
+		seen[base[i].Key] = true
+	}
+	for i := range extra {
+		if !seen[extra[i].Key] {
+			base = append(base, extra[i])
+			seen[extra[i].Key] = true
+		}
+	}
+	return base
+}
+
+// EstimateWithReferences gets an AI estimate calibrated with similar completed tickets
+// If the reference search fails, the estimate is made without references
+func EstimateWithReferences(
+	geminiClient gemini.GeminiClient, client jira.JiraClient, ticket *jira.Issue,
+	description string, availablePoints []int, referenceCount int,
+) (points int, reasoning string, references []gemini.EstimateExample, err error) {
+	references, err = FindReferenceTickets(client, ticket, description, referenceCount)
+	if err != nil {
+		references = nil // References are optional - estimate without them
+	}
+
+	points, reasoning, err = geminiClient.EstimateStoryPointsWithExamples(
+		ticket.Fields.Summary, description, availablePoints, references)
+	if err != nil {
+		return 0, "", nil, err
+	}
+
+	return points, reasoning, references, nil
+}
+
+// ReferenceCount returns the configured number of reference tickets
+// Zero means the default; a negative value disables references
+func ReferenceCount(configured int) int {
+	if configured == 0 {
+		return DefaultReferenceCount
+	}
+	if configured < 0 {
+		return 0
+	}
+	return configured
+}
+
+// FormatReferences returns a short description of the reference tickets used,
+// e.g. "ENG-12 (3), ENG-40 (5)", or an empty string if there were none
+func FormatReferences(references []gemini.EstimateExample) string {
+	parts := make([]string, len(references))
+	for i, ref := range references {
+		parts[i] = fmt.Sprintf("%s (%d)", ref.Key, ref.Points)
+	}
+	return strings.Join(parts, ", ")
+}
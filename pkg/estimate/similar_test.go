@@ -0,0 +1,102 @@
+package estimate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+// searchOnlyClient answers SearchTickets from a fixed list; other methods are not used
+type searchOnlyClient struct {
+	jira.JiraClient
+	issues  []jira.Issue
+	queries []string
+}
+
+func (c *searchOnlyClient) SearchTickets(jql string) ([]jira.Issue, error) {
+	c.queries = append(c.queries, jql)
+	return c.issues, nil
+}
+
+func newIssue(key, summary string, points float64) jira.Issue {
+	var issue jira.Issue
+	issue.Key = key
+	issue.Fields.Summary = summary
+	issue.Fields.StoryPoints = points
+	return issue
+}
+
+func TestRankBySimilarity(t *testing.T) {
+	documents := []string{
+		"Update README badges",
+		"Add OAuth login for the web dashboard",
+		"Fix flaky login test in CI",
+		"Refactor database connection pooling",
+	}
+
+	ranked := RankBySimilarity("Support OAuth login on mobile", documents)
+	if len(ranked) < 2 {
+		t.Fatalf("Expected at least 2 matches, got %v", ranked)
+	}
+	if ranked[0] != 1 {
+		t.Errorf("Expected OAuth login ticket to rank first, got %d", ranked[0])
+	}
+	for _, i := range ranked {
+		if i == 0 || i == 3 {
+			t.Errorf("Unrelated document %d should not match", i)
+		}
+	}
+
+	if ranked := RankBySimilarity("the and of", documents); ranked != nil {
+		t.Errorf("Expected no matches for a stop-word query, got %v", ranked)
+	}
+}
+
+func TestFindReferenceTickets(t *testing.T) {
+	client := &searchOnlyClient{issues: []jira.Issue{
+		newIssue("ENG-1", "Add OAuth login for the web dashboard", 5),
+		newIssue("ENG-2", "Fix login redirect loop", 2),
+		newIssue("ENG-3", "Add OAuth scopes to the API", 0), // not estimated
+		newIssue("ENG-4", "Upgrade build tooling", 3),
+	}}
+
+	ticket := newIssue("ENG-10", "Add OAuth login to the mobile app", 0)
+	examples, err := FindReferenceTickets(client, &ticket, "", 2)
+	if err != nil {
+		t.Fatalf("FindReferenceTickets failed: %v", err)
+	}
+
+	if len(examples) != 2 {
+		t.Fatalf("Expected 2 references, got %d: %+v", len(examples), examples)
+	}
+	if examples[0].Key != "ENG-1" || examples[0].Points != 5 {
+		t.Errorf("Expected ENG-1 (5) first, got %+v", examples[0])
+	}
+	for _, ex := range examples {
+		if ex.Key == "ENG-3" {
+			t.Error("Tickets without story points should not be used as references")
+		}
+	}
+
+	// Without components, only the project-wide search is made
+	if len(client.queries) != 1 || !strings.Contains(client.queries[0], `project = "ENG"`) {
+		t.Errorf("Unexpected queries: %v", client.queries)
+	}
+
+	if got := FormatReferences(examples); !strings.HasPrefix(got, "ENG-1 (5), ") {
+		t.Errorf("Unexpected formatted references: %s", got)
+	}
+}
+
+func TestReferenceCount(t *testing.T) {
+	if ReferenceCount(0) != DefaultReferenceCount {
+		t.Errorf("Expected default reference count for 0")
+	}
+	if ReferenceCount(-1) != 0 {
+		t.Errorf("Expected negative reference count to disable references")
+	}
+	if ReferenceCount(3) != 3 {
+		t.Errorf("Expected configured reference count to be used")
+	}
+}
@@ -0,0 +1,120 @@
+package gemini
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ComponentOption is a component the classifier can choose from
+type ComponentOption struct {
+	Name        string
+	Description string
+}
+
+// ClassificationInput is the ticket and the values to choose from when classifying it
+// Empty option lists are left out of the prompt and get no recommendation
+type ClassificationInput struct {
+	Summary        string
+	Description    string
+	IssueType      string
+	Priorities     []string // In the order of the project's priority scheme, most urgent first
+	SeverityValues []string
+	Components     []ComponentOption
+}
+
+// Recommendation is a recommended value with the reason for it
+// Value is empty if there is no recommendation
+type Recommendation struct {
+	Value     string `json:"value"`
+	Rationale string `json:"rationale"`
+}
+
+// Classification holds the recommended triage values for a ticket
+type Classification struct {
+	Priority  Recommendation `json:"priority"`
+	Severity  Recommendation `json:"severity"`
+	Component Recommendation `json:"component"`
+}
+
+// ClassifyTicket recommends a priority, severity and component for a ticket in a single request
+func (c *geminiClient) ClassifyTicket(input *ClassificationInput) (*Classification, error) {
+	response, err := c.generateContent(buildClassificationPrompt(input))
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseClassification(response, input)
+}
+
+func buildClassificationPrompt(input *ClassificationInput) string {
+	var b strings.Builder
+	b.WriteString("You are triaging a Jira ticket. Recommend values for it, choosing only from the options given.\n\n")
+	fmt.Fprintf(&b, "Issue Type: %s\nSummary: %s\n\nDescription:\n%s\n\n",
+		input.IssueType, input.Summary, input.Description)
+
+	if len(input.Priorities) > 0 {
+		fmt.Fprintf(&b, "Priorities (most urgent first): %s\n\n", strings.Join(input.Priorities, ", "))
+	}
+	if len(input.SeverityValues) > 0 {
+		fmt.Fprintf(&b, "Severities: %s\n\n", strings.Join(input.SeverityValues, ", "))
+	}
+	if len(input.Components) > 0 {
+		b.WriteString("Components:\n")
+		for _, comp := range input.Components {
+			if comp.Description != "" {
+				fmt.Fprintf(&b, "- %s: %s\n", comp.Name, comp.Description)
+			} else {
+				fmt.Fprintf(&b, "- %s\n", comp.Name)
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(`For each of priority, severity and component, give the recommended value exactly as listed
+and a one-sentence rationale. Use an empty value if no options were given or none fits.
+
+Respond with ONLY a JSON object in this format, with no other text:
+{"priority": {"value": "", "rationale": ""}, "severity": {"value": "", "rationale": ""}, ` +
+		`"component": {"value": "", "rationale": ""}}`)
+
+	return b.String()
+}
+
+// ParseClassification parses the recommendations from a Gemini response
+// Values that aren't among the options are dropped, and matching values take the option's spelling
+func ParseClassification(response string, input *ClassificationInput) (*Classification, error) {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("could not find classification in response")
+	}
+
+	var parsed Classification
+	if err := json.Unmarshal([]byte(response[start:end+1]), &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse classification: %w", err)
+	}
+
+	componentNames := make([]string, len(input.Components))
+	for i, comp := range input.Components {
+		componentNames[i] = comp.Name
+	}
+
+	parsed.Priority = matchOption(parsed.Priority, input.Priorities)
+	parsed.Severity = matchOption(parsed.Severity, input.SeverityValues)
+	parsed.Component = matchOption(parsed.Component, componentNames)
+
+	return &parsed, nil
+}
+
+// matchOption returns the recommendation with its value replaced by the matching
+// option, or an empty recommendation if the value isn't one of the options
+func matchOption(rec Recommendation, options []string) Recommendation {
+	value := strings.TrimSpace(rec.Value)
+	for _, option := range options {
+		if strings.EqualFold(option, value) {
+			return Recommendation{Value: option, Rationale: strings.TrimSpace(rec.Rationale)}
+		}
+	}
+	return Recommendation{}
+}
@@ -0,0 +1,55 @@
+package gemini
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseClassification(t *testing.T) {
+	input := &ClassificationInput{
+		Priorities:     []string{"Blocker", "Major", "Minor"},
+		SeverityValues: []string{"Critical", "Moderate", "Low"},
+		Components:     []ComponentOption{{Name: "Networking", Description: "Routing and DNS"}, {Name: "Storage"}},
+	}
+	response := "Here you go:\n" + `{
+		"priority": {"value": "major", "rationale": " Breaks a common workflow "},
+		"severity": {"value": "Catastrophic", "rationale": "Not an option"},
+		"component": {"value": "Networking", "rationale": "DNS lookups fail"}
+	}`
+
+	classification, err := ParseClassification(response, input)
+	if err != nil {
+		t.Fatalf("ParseClassification failed: %v", err)
+	}
+
+	if classification.Priority.Value != "Major" || classification.Priority.Rationale != "Breaks a common workflow" {
+		t.Errorf("Expected priority Major with trimmed rationale, got %+v", classification.Priority)
+	}
+	if classification.Severity.Value != "" || classification.Severity.Rationale != "" {
+		t.Errorf("Expected unknown severity to be dropped, got %+v", classification.Severity)
+	}
+	if classification.Component.Value != "Networking" {
+		t.Errorf("Expected component Networking, got %+v", classification.Component)
+	}
+
+	if _, err := ParseClassification("no json here", input); err == nil {
+		t.Error("Expected an error for a response without JSON")
+	}
+}
+
+func TestBuildClassificationPrompt(t *testing.T) {
+	prompt := buildClassificationPrompt(&ClassificationInput{
+		Summary:    "DNS fails",
+		Priorities: []string{"Blocker", "Minor"},
+		Components: []ComponentOption{{Name: "Networking", Description: "Routing and DNS"}, {Name: "Storage"}},
+	})
+
+	for _, want := range []string{"DNS fails", "Blocker, Minor", "- Networking: Routing and DNS\n", "- Storage\n"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Expected prompt to contain %q", want)
+		}
+	}
+	if strings.Contains(prompt, "Severities:") {
+		t.Error("Expected no severities in the prompt when none are given")
+	}
+}
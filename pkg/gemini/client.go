@@ -22,13 +22,32 @@ type GeminiClient interface {
 	GenerateQuestion(history []string, context string, summaryOrKey string, issueTypeName string) (string, error)
 	GenerateDescription(history []string, context string, summaryOrKey string, issueTypeName string) (string, error)
 	EstimateStoryPoints(summary, description string, availablePoints []int) (int, string, error)
+	// EstimateStoryPointsWithExamples estimates story points calibrated against
+	// previously estimated tickets from the same team
+	EstimateStoryPointsWithExamples(
+		summary, description string, availablePoints []int, examples []EstimateExample,
+	) (int, string, error)
+	// AssessDescription scores a description against the description quality rubric
+	AssessDescription(summary, description, issueTypeName string) (*DescriptionAssessment, error)
+	// ClassifyTicket recommends a priority, severity and component for a ticket
+	ClassifyTicket(input *ClassificationInput) (*Classification, error)
+	// SummarizeReleaseGroup summarizes a group of tickets in a release for users
+	SummarizeReleaseGroup(group string, tickets []string) (string, error)
+	// SetTicketKey sets the ticket that subsequent requests relate to (recorded in transcripts)
+	SetTicketKey(ticketKey string)
 }
 
 // geminiClient is the concrete implementation of GeminiClient
 type geminiClient struct {
 	apiKey                            string
+	model                             string
 	baseURL                           string
 	client                            *http.Client
+	ticketKey                         string
+	configDir                         string
+	budget                            budget
+	transcripts                       *TranscriptStore
+	responseCache                     *ResponseCache
 	questionPromptTemplate            string
 	descriptionPromptTemplate         string
 	spikeQuestionPromptTemplate       string
@@ -88,7 +107,13 @@ func ListModels(configDir string) ([]ModelInfo, error) {
 
 // NewClient creates a new Gemini client
 // configDir can be empty to use the default ~/.jira-tool
-func NewClient(configDir string) (GeminiClient, error) {
+// noCache if true, bypasses the response cache (if enabled in config)
+func NewClient(configDir string, noCache bool) (GeminiClient, error) {
+	return newClient(configDir, noCache)
+}
+
+// newClient creates the concrete Gemini client used by NewClient and Replay
+func newClient(configDir string, noCache bool) (*geminiClient, error) {
 	// Get API key from credentials
 	// We use a dummy user since we store by service, not user
 	apiKey, err := credentials.GetSecret(credentials.GeminiServiceKey, "default", configDir)
@@ -110,12 +135,6 @@ func NewClient(configDir string) (GeminiClient, error) {
 		model = "gemini-2.5-flash"
 	}
 
-	// Strip "models/" prefix if present (ListModels returns names with prefix)
-	modelName := model
-	if strings.HasPrefix(model, "models/") {
-		modelName = strings.TrimPrefix(model, "models/")
-	}
-
 	// Get prompt templates or use defaults
 	questionTemplate := cfg.QuestionPromptTemplate
 	if questionTemplate == "" {
@@ -147,19 +166,43 @@ func NewClient(configDir string) (GeminiClient, error) {
 		epicFeatureTemplate = getDefaultEpicFeaturePrompt()
 	}
 
-	return &geminiClient{
-		apiKey: apiKey,
-		baseURL: fmt.Sprintf(
-			"https://generativelanguage.googleapis.com/v1/models/%s:generateContent",
-			modelName),
+	c := &geminiClient{
+		apiKey:                            apiKey,
 		client:                            &http.Client{},
+		configDir:                         configDir,
+		budget:                            newBudget(cfg),
+		transcripts:                       NewTranscriptStore(GetTranscriptPath(configDir)),
 		questionPromptTemplate:            questionTemplate,
 		descriptionPromptTemplate:         descriptionTemplate,
 		spikeQuestionPromptTemplate:       spikeQuestionTemplate,
 		spikePromptTemplate:               spikeTemplate,
 		epicFeatureQuestionPromptTemplate: epicFeatureQuestionTemplate,
 		epicFeaturePromptTemplate:         epicFeatureTemplate,
-	}, nil
+	}
+	c.setModel(model)
+
+	// Response cache is opt-in and bypassed by --no-cache
+	if cfg.GeminiResponseCache && !noCache {
+		ttl := time.Duration(cfg.GeminiResponseCacheTTLHours) * time.Hour
+		c.responseCache = NewResponseCache(GetResponseCacheDir(configDir), ttl)
+	}
+
+	return c, nil
+}
+
+// setModel sets the model name and the generateContent endpoint for it
+func (c *geminiClient) setModel(model string) {
+	// Strip "models/" prefix if present (ListModels returns names with prefix)
+	modelName := strings.TrimPrefix(model, "models/")
+	c.model = modelName
+	c.baseURL = fmt.Sprintf(
+		"https://generativelanguage.googleapis.com/v1/models/%s:generateContent",
+		modelName)
+}
+
+// SetTicketKey sets the ticket that subsequent requests relate to
+func (c *geminiClient) SetTicketKey(ticketKey string) {
+	c.ticketKey = ticketKey
 }
 
 // getDefaultQuestionPrompt returns the default question generation prompt template
@@ -328,7 +371,15 @@ type Part struct {
 //
 //nolint:revive // Type name is intentional for clarity
 type GeminiResponse struct {
-	Candidates []Candidate `json:"candidates"`
+	Candidates    []Candidate   `json:"candidates"`
+	UsageMetadata UsageMetadata `json:"usageMetadata"`
+}
+
+// UsageMetadata reports the token usage of a generateContent call
+type UsageMetadata struct {
+	PromptTokenCount     int `json:"promptTokenCount"`
+	CandidatesTokenCount int `json:"candidatesTokenCount"`
+	TotalTokenCount      int `json:"totalTokenCount"`
 }
 
 // Candidate represents a candidate response
@@ -367,6 +418,36 @@ func (c *geminiClient) GenerateDescription(
 // Returns the estimated points, reasoning text, and any error
 func (c *geminiClient) EstimateStoryPoints(
 	summary, description string, availablePoints []int,
+) (points int, reasoning string, err error) {
+	return c.EstimateStoryPointsWithExamples(summary, description, availablePoints, nil)
+}
+
+// EstimateExample is a completed ticket used as a few-shot reference when estimating
+type EstimateExample struct {
+	Key     string
+	Summary string
+	Points  int
+}
+
+// formatEstimateExamples renders reference tickets for the estimate prompt
+func formatEstimateExamples(examples []EstimateExample) string {
+	if len(examples) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\nFor calibration, here are similar completed tickets and the story points this team gave them:\n")
+	for _, ex := range examples {
+		sb.WriteString(fmt.Sprintf("- %s: %s -> %d points\n", ex.Key, ex.Summary, ex.Points))
+	}
+	sb.WriteString("Use these to match the team's scale when choosing your estimate.\n")
+	return sb.String()
+}
+
+// EstimateStoryPointsWithExamples uses Gemini to estimate story points for a ticket,
+// including similar previously estimated tickets as few-shot examples
+func (c *geminiClient) EstimateStoryPointsWithExamples(
+	summary, description string, availablePoints []int, examples []EstimateExample,
 ) (points int, reasoning string, err error) {
 	// Build the prompt
 	var pointsList strings.Builder
@@ -390,7 +471,7 @@ Ticket Description:
 %s
 
 Available story point options: %s
-
+%s
 Please provide a story point estimate for this ticket. Consider:
 - Complexity and technical difficulty
 - Amount of work required
@@ -405,7 +486,7 @@ Respond with ONLY a single number (the story point estimate), `+
 Example format:
 5
 This task involves moderate complexity with clear requirements and minimal risk.`,
-		summary, description, pointsList.String())
+		summary, description, pointsList.String(), formatEstimateExamples(examples))
 
 	response, err := c.generateContent(prompt)
 	if err != nil {
@@ -530,8 +611,65 @@ func (c *geminiClient) buildDescriptionPrompt(history []string, context, summary
 	return prompt
 }
 
-// generateContent makes the actual API call to Gemini with automatic retry for transient errors
+// generateContent makes the actual API call to Gemini, serving from the response cache
+// when enabled and recording every exchange in the transcript log
 func (c *geminiClient) generateContent(prompt string) (string, error) {
+	if c.responseCache != nil {
+		if cached, ok := c.responseCache.Get(c.model, prompt); ok {
+			c.recordTranscript(prompt, cached.Response, cached.Usage, true, nil)
+			c.recordUsage(cached.Usage, true)
+			return cached.Response, nil
+		}
+	}
+
+	if err := c.checkBudget(); err != nil {
+		return "", err
+	}
+
+	result, usage, err := c.generateContentWithRetry(prompt)
+	c.recordTranscript(prompt, result, usage, false, err)
+	if err != nil {
+		return "", err
+	}
+	c.recordUsage(usage, false)
+
+	if c.responseCache != nil {
+		if err := c.responseCache.Put(c.model, prompt, result, usage); err != nil {
+			// Log but don't fail - caching is optional
+			_ = err
+		}
+	}
+
+	return result, nil
+}
+
+// recordTranscript appends an exchange to the transcript log
+// Failures to write the log are ignored - the transcript is for auditing only
+func (c *geminiClient) recordTranscript(prompt, response string, usage UsageMetadata, cached bool, genErr error) {
+	if c.transcripts == nil {
+		return
+	}
+
+	entry := &Transcript{
+		Timestamp: time.Now(),
+		Model:     c.model,
+		TicketKey: c.ticketKey,
+		Prompt:    prompt,
+		Response:  response,
+		Cached:    cached,
+		Usage:     usage,
+	}
+	if genErr != nil {
+		entry.Error = genErr.Error()
+	}
+
+	if err := c.transcripts.Append(entry); err != nil {
+		_ = err
+	}
+}
+
+// generateContentWithRetry calls Gemini with automatic retry for transient errors
+func (c *geminiClient) generateContentWithRetry(prompt string) (string, UsageMetadata, error) {
 	const maxRetries = 3
 	const initialBackoff = 5 * time.Second
 
@@ -559,12 +697,12 @@ func (c *geminiClient) generateContent(prompt string) (string, error) {
 			time.Sleep(backoff)
 		}
 
-		result, err := c.generateContentOnce(prompt)
+		result, usage, err := c.generateContentOnce(prompt)
 		if err == nil {
 			if attempt > 0 {
 				fmt.Fprintf(os.Stderr, "Request succeeded after %d retry(ies).\n", attempt)
 			}
-			return result, nil
+			return result, usage, nil
 		}
 
 		lastErr = err
@@ -582,20 +720,20 @@ func (c *geminiClient) generateContent(prompt string) (string, error) {
 			strings.Contains(errStr, "rate limit")
 
 		if !isRetryable {
-			return "", err
+			return "", UsageMetadata{}, err
 		}
 
 		// On last attempt, return the error
 		if attempt == maxRetries {
-			return "", fmt.Errorf("%w (after %d retries)", err, maxRetries)
+			return "", UsageMetadata{}, fmt.Errorf("%w (after %d retries)", err, maxRetries)
 		}
 	}
 
-	return "", lastErr
+	return "", UsageMetadata{}, lastErr
 }
 
 // generateContentOnce makes a single API call to Gemini
-func (c *geminiClient) generateContentOnce(prompt string) (string, error) {
+func (c *geminiClient) generateContentOnce(prompt string) (string, UsageMetadata, error) {
 	// Build the request payload
 	reqPayload := GeminiRequest{
 		Contents: []Content{
@@ -609,7 +747,7 @@ func (c *geminiClient) generateContentOnce(prompt string) (string, error) {
 
 	jsonData, err := json.Marshal(reqPayload)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return "", UsageMetadata{}, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	// Build the URL with API key
@@ -618,7 +756,7 @@ func (c *geminiClient) generateContentOnce(prompt string) (string, error) {
 	// Create the POST request
 	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return "", UsageMetadata{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -630,7 +768,7 @@ func (c *geminiClient) generateContentOnce(prompt string) (string, error) {
 	// Execute the request
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to execute request: %w", err)
+		return "", UsageMetadata{}, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -638,7 +776,7 @@ func (c *geminiClient) generateContentOnce(prompt string) (string, error) {
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		body, readErr := io.ReadAll(resp.Body)
 		if readErr != nil {
-			return "", fmt.Errorf(
+			return "", UsageMetadata{}, fmt.Errorf(
 				"Gemini API returned error: %d %s (failed to read body: %w)",
 				resp.StatusCode, resp.Status, readErr)
 		}
@@ -660,42 +798,43 @@ func (c *geminiClient) generateContentOnce(prompt string) (string, error) {
 		// Provide user-friendly error messages
 		switch resp.StatusCode {
 		case 401, 403:
-			return "", fmt.Errorf("authentication failed. Your Gemini API key may be invalid. Please run 'jira init'")
+			return "", UsageMetadata{}, fmt.Errorf(
+				"authentication failed. Your Gemini API key may be invalid. Please run 'jira init'")
 		case 429:
-			return "", fmt.Errorf("Gemini API rate limit exceeded. Please wait a moment and try again")
+			return "", UsageMetadata{}, fmt.Errorf("Gemini API rate limit exceeded. Please wait a moment and try again")
 		case 503:
 			errorMsg := "Gemini API is temporarily unavailable (service overloaded)"
 			if apiError.Error.Message != "" {
 				errorMsg = fmt.Sprintf("%s: %s", errorMsg, apiError.Error.Message)
 			}
-			return "", fmt.Errorf("%s. Please try again in a few moments", errorMsg)
+			return "", UsageMetadata{}, fmt.Errorf("%s. Please try again in a few moments", errorMsg)
 		case 500, 502, 504:
-			return "", fmt.Errorf("Gemini API server error. Please try again in a few moments")
+			return "", UsageMetadata{}, fmt.Errorf("Gemini API server error. Please try again in a few moments")
 		default:
 			// For other errors, include the API's error message if available
 			if apiError.Error.Message != "" {
-				return "", fmt.Errorf("Gemini API error: %s", apiError.Error.Message)
+				return "", UsageMetadata{}, fmt.Errorf("Gemini API error: %s", apiError.Error.Message)
 			}
-			return "", fmt.Errorf("Gemini API returned error: %d %s", resp.StatusCode, resp.Status)
+			return "", UsageMetadata{}, fmt.Errorf("Gemini API returned error: %d %s", resp.StatusCode, resp.Status)
 		}
 	}
 
 	// Parse response
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return "", UsageMetadata{}, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	var geminiResp GeminiResponse
 	if err := json.Unmarshal(body, &geminiResp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+		return "", UsageMetadata{}, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	if len(geminiResp.Candidates) == 0 || len(geminiResp.Candidates[0].Content.Parts) == 0 {
-		return "", fmt.Errorf("no response from Gemini API")
+		return "", UsageMetadata{}, fmt.Errorf("no response from Gemini API")
 	}
 
-	return geminiResp.Candidates[0].Content.Parts[0].Text, nil
+	return geminiResp.Candidates[0].Content.Parts[0].Text, geminiResp.UsageMetadata, nil
 }
 
 // showThinkingIndicator displays "Thinking..." and appends a dot every second
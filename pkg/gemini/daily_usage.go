@@ -0,0 +1,125 @@
+package gemini
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DailyUsage holds the AI token usage and estimated cost accumulated on one day
+type DailyUsage struct {
+	Requests       int
+	PromptTokens   int
+	ResponseTokens int
+	TotalTokens    int
+	CostUSD        float64
+}
+
+// usageEntry is one request in a daily usage log
+type usageEntry struct {
+	Timestamp      time.Time `json:"timestamp"`
+	Model          string    `json:"model"`
+	PromptTokens   int       `json:"prompt_tokens"`
+	ResponseTokens int       `json:"response_tokens"`
+	TotalTokens    int       `json:"total_tokens"`
+	CostUSD        float64   `json:"cost_usd"`
+}
+
+// GetUsageDir returns the directory holding one usage log per day
+// If configDir is empty, uses the default ~/.jira-tool
+func GetUsageDir(configDir string) string {
+	if configDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "./.jira-tool/ai-usage"
+		}
+		configDir = filepath.Join(homeDir, ".jira-tool")
+	}
+	return filepath.Join(configDir, "ai-usage")
+}
+
+// usageLogPath returns the usage log for the day of t
+func usageLogPath(configDir string, t time.Time) string {
+	return filepath.Join(GetUsageDir(configDir), t.Format(usageDateFormat)+".jsonl")
+}
+
+// appendUsage adds a request to the day's usage log. Each request is a single appended
+// line, so concurrent runs can't overwrite each other's usage.
+func appendUsage(configDir string, entry usageEntry) error {
+	if err := os.MkdirAll(GetUsageDir(configDir), 0755); err != nil {
+		return fmt.Errorf("failed to create usage directory: %w", err)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal usage: %w", err)
+	}
+
+	f, err := os.OpenFile(usageLogPath(configDir, entry.Timestamp), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open usage log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write usage: %w", err)
+	}
+	return nil
+}
+
+// readDailyUsage totals the usage log for the day of t
+// Returns zero usage if nothing was recorded that day (not an error)
+func readDailyUsage(configDir string, t time.Time) (DailyUsage, error) {
+	var usage DailyUsage
+	f, err := os.Open(usageLogPath(configDir, t))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return usage, nil
+		}
+		return usage, fmt.Errorf("failed to open usage log: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry usageEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// Skip corrupt (e.g. partially written) lines rather than losing the day's total
+			continue
+		}
+		usage.Requests++
+		usage.PromptTokens += entry.PromptTokens
+		usage.ResponseTokens += entry.ResponseTokens
+		usage.TotalTokens += entry.TotalTokens
+		usage.CostUSD += entry.CostUSD
+	}
+	if err := scanner.Err(); err != nil {
+		return usage, fmt.Errorf("failed to read usage log: %w", err)
+	}
+	return usage, nil
+}
+
+// pruneUsage removes the usage logs of days before the given date (YYYY-MM-DD)
+func pruneUsage(configDir, before string) error {
+	entries, err := os.ReadDir(GetUsageDir(configDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read usage directory: %w", err)
+	}
+	for _, entry := range entries {
+		date, ok := strings.CutSuffix(entry.Name(), ".jsonl")
+		if !ok || date >= before {
+			continue
+		}
+		if err := os.Remove(filepath.Join(GetUsageDir(configDir), entry.Name())); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove old usage log: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package gemini
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SummarizeReleaseGroup summarizes the tickets of one group of release notes (e.g. the bugs fixed)
+// in a short paragraph for the people using the product rather than the team building it
+func (c *geminiClient) SummarizeReleaseGroup(group string, tickets []string) (string, error) {
+	response, err := c.generateContent(buildReleaseGroupPrompt(group, tickets))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(response), nil
+}
+
+func buildReleaseGroupPrompt(group string, tickets []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `You are writing release notes. Summarize these %s tickets in one short paragraph
+for the people using the product, not the team that built it. Describe what changed for them,
+leave out ticket keys and internal details, and don't invent changes that aren't listed.
+
+Respond with ONLY the paragraph, as plain text.
+
+Tickets:
+`, group)
+	for _, ticket := range tickets {
+		fmt.Fprintf(&b, "- %s\n", ticket)
+	}
+	return b.String()
+}
@@ -0,0 +1,106 @@
+package gemini
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// defaultResponseCacheTTL is how long cached responses stay valid if not configured
+const defaultResponseCacheTTL = 7 * 24 * time.Hour
+
+// cachedResponse is a single response stored in the response cache
+type cachedResponse struct {
+	Model     string        `json:"model"`
+	Response  string        `json:"response"`
+	Usage     UsageMetadata `json:"usage"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// ResponseCache stores Gemini responses keyed by a hash of the model and prompt,
+// so identical prompts (e.g. re-running decompose on an unchanged ticket) are not
+// generated twice
+type ResponseCache struct {
+	dir string
+	ttl time.Duration
+}
+
+// GetResponseCacheDir returns the directory used for cached Gemini responses
+// If configDir is empty, uses the default ~/.jira-tool
+func GetResponseCacheDir(configDir string) string {
+	if configDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "./.jira-tool/gemini-cache"
+		}
+		configDir = filepath.Join(homeDir, ".jira-tool")
+	}
+	return filepath.Join(configDir, "gemini-cache")
+}
+
+// NewResponseCache creates a response cache in dir
+// A ttl of zero or less uses the default of 7 days
+func NewResponseCache(dir string, ttl time.Duration) *ResponseCache {
+	if ttl <= 0 {
+		ttl = defaultResponseCacheTTL
+	}
+	return &ResponseCache{dir: dir, ttl: ttl}
+}
+
+// responseCacheKey returns the content hash used to key a prompt in the cache
+func responseCacheKey(model, prompt string) string {
+	sum := sha256.Sum256([]byte(model + "\x00" + prompt))
+	return hex.EncodeToString(sum[:])
+}
+
+func (rc *ResponseCache) entryPath(model, prompt string) string {
+	return filepath.Join(rc.dir, responseCacheKey(model, prompt)+".json")
+}
+
+// Get returns the cached response for a prompt, if present and not expired
+func (rc *ResponseCache) Get(model, prompt string) (*cachedResponse, bool) {
+	data, err := os.ReadFile(rc.entryPath(model, prompt))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cachedResponse
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+
+	if time.Since(entry.CreatedAt) > rc.ttl {
+		return nil, false
+	}
+
+	return &entry, true
+}
+
+// Put stores a response for a prompt
+func (rc *ResponseCache) Put(model, prompt, response string, usage UsageMetadata) error {
+	if err := os.MkdirAll(rc.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create response cache directory: %w", err)
+	}
+
+	entry := cachedResponse{
+		Model:     model,
+		Response:  response,
+		Usage:     usage,
+		CreatedAt: time.Now(),
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cached response: %w", err)
+	}
+
+	if err := os.WriteFile(rc.entryPath(model, prompt), data, 0600); err != nil {
+		return fmt.Errorf("failed to write cached response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package gemini
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Rubric criteria a good ticket description should cover
+const (
+	CriterionProblem            = "problem_statement"
+	CriterionMotivation         = "motivation"
+	CriterionAcceptanceCriteria = "acceptance_criteria"
+	CriterionScope              = "scope"
+	CriterionTestNotes          = "test_notes"
+)
+
+// MaxRubricScore is the highest score a criterion can receive
+const MaxRubricScore = 2
+
+// RubricCriteria lists the criteria in the order they are reported
+var RubricCriteria = []string{
+	CriterionProblem,
+	CriterionMotivation,
+	CriterionAcceptanceCriteria,
+	CriterionScope,
+	CriterionTestNotes,
+}
+
+// criterionLabels are the human-readable names of the criteria
+var criterionLabels = map[string]string{
+	CriterionProblem:            "Problem statement",
+	CriterionMotivation:         "Motivation",
+	CriterionAcceptanceCriteria: "Acceptance criteria",
+	CriterionScope:              "Scope / out of scope",
+	CriterionTestNotes:          "Test notes",
+}
+
+// CriterionLabel returns the human-readable name of a rubric criterion
+func CriterionLabel(criterion string) string {
+	if label, ok := criterionLabels[criterion]; ok {
+		return label
+	}
+	return criterion
+}
+
+// RubricScore is the score of a description against one rubric criterion
+// Score is 0 (missing), 1 (partial) or 2 (complete)
+type RubricScore struct {
+	Criterion string `json:"criterion"`
+	Score     int    `json:"score"`
+	Hint      string `json:"hint,omitempty"` // How to improve the description for this criterion
+}
+
+// DescriptionAssessment is the result of scoring a description against the rubric
+type DescriptionAssessment struct {
+	Scores []RubricScore `json:"scores"`
+}
+
+// Missing returns the criteria that scored 0
+func (a *DescriptionAssessment) Missing() []string {
+	var missing []string
+	for _, s := range a.Scores {
+		if s.Score == 0 {
+			missing = append(missing, s.Criterion)
+		}
+	}
+	return missing
+}
+
+// Hints returns the improvement hints for criteria that didn't get the maximum score
+func (a *DescriptionAssessment) Hints() []string {
+	var hints []string
+	for _, s := range a.Scores {
+		if s.Score < MaxRubricScore && s.Hint != "" {
+			hints = append(hints, fmt.Sprintf("%s: %s", CriterionLabel(s.Criterion), s.Hint))
+		}
+	}
+	return hints
+}
+
+// AssessDescription scores a ticket description against the description rubric
+func (c *geminiClient) AssessDescription(summary, description, issueTypeName string) (*DescriptionAssessment, error) {
+	prompt := fmt.Sprintf(`You are reviewing the description of a Jira %s for completeness.
+
+Ticket Summary: %s
+
+Ticket Description:
+%s
+
+Score the description against each of these criteria:
+- problem_statement: is the problem or requested change clearly stated?
+- motivation: does it explain why this matters and who benefits?
+- acceptance_criteria: are there concrete, verifiable conditions for done?
+- scope: is it clear what is in scope and what is explicitly out of scope?
+- test_notes: does it say how the change should be tested or verified?
+
+Use 0 if the criterion is not addressed, 1 if partially addressed and 2 if fully addressed.
+For every criterion scored below 2, give one concrete, specific hint on what to add.
+
+Respond with ONLY a JSON object in this format, with no other text:
+{"scores": [{"criterion": "problem_statement", "score": 2, "hint": ""}, ...]}`,
+		issueTypeOrTicket(issueTypeName), summary, description)
+
+	response, err := c.generateContent(prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseDescriptionAssessment(response)
+}
+
+func issueTypeOrTicket(issueTypeName string) string {
+	if issueTypeName == "" {
+		return "ticket"
+	}
+	return strings.ToLower(issueTypeName)
+}
+
+// ParseDescriptionAssessment parses the JSON rubric scores from a Gemini response
+// Markdown code fences around the JSON are tolerated. Scores are clamped to the
+// rubric range, unknown criteria are dropped and missing criteria score 0.
+func ParseDescriptionAssessment(response string) (*DescriptionAssessment, error) {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("could not find rubric scores in response")
+	}
+
+	var parsed DescriptionAssessment
+	if err := json.Unmarshal([]byte(response[start:end+1]), &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse rubric scores: %w", err)
+	}
+
+	byCriterion := make(map[string]RubricScore, len(parsed.Scores))
+	for _, s := range parsed.Scores {
+		s.Criterion = strings.ToLower(strings.TrimSpace(s.Criterion))
+		if s.Score < 0 {
+			s.Score = 0
+		}
+		if s.Score > MaxRubricScore {
+			s.Score = MaxRubricScore
+		}
+		s.Hint = strings.TrimSpace(s.Hint)
+		byCriterion[s.Criterion] = s
+	}
+
+	assessment := &DescriptionAssessment{}
+	for _, criterion := range RubricCriteria {
+		score, ok := byCriterion[criterion]
+		if !ok {
+			score = RubricScore{Criterion: criterion}
+		}
+		assessment.Scores = append(assessment.Scores, score)
+	}
+
+	return assessment, nil
+}
@@ -0,0 +1,57 @@
+package gemini
+
+import (
+	"testing"
+)
+
+func TestParseDescriptionAssessment(t *testing.T) {
+	response := "```json\n" + `{"scores": [
+		{"criterion": "problem_statement", "score": 2, "hint": ""},
+		{"criterion": "Motivation", "score": 1, "hint": "Say who is affected"},
+		{"criterion": "acceptance_criteria", "score": 0, "hint": "List the conditions for done"},
+		{"criterion": "scope", "score": 5},
+		{"criterion": "unrelated", "score": 2}
+	]}` + "\n```"
+
+	assessment, err := ParseDescriptionAssessment(response)
+	if err != nil {
+		t.Fatalf("ParseDescriptionAssessment failed: %v", err)
+	}
+
+	if len(assessment.Scores) != len(RubricCriteria) {
+		t.Fatalf("Expected %d scores, got %d", len(RubricCriteria), len(assessment.Scores))
+	}
+
+	scores := map[string]int{}
+	for _, s := range assessment.Scores {
+		scores[s.Criterion] = s.Score
+	}
+	expected := map[string]int{
+		CriterionProblem:            2,
+		CriterionMotivation:         1,
+		CriterionAcceptanceCriteria: 0,
+		CriterionScope:              MaxRubricScore, // Clamped
+		CriterionTestNotes:          0,              // Not in the response
+	}
+	for criterion, want := range expected {
+		if scores[criterion] != want {
+			t.Errorf("Expected %s score %d, got %d", criterion, want, scores[criterion])
+		}
+	}
+
+	missing := assessment.Missing()
+	if len(missing) != 2 || missing[0] != CriterionAcceptanceCriteria || missing[1] != CriterionTestNotes {
+		t.Errorf("Expected acceptance criteria and test notes missing, got %v", missing)
+	}
+
+	hints := assessment.Hints()
+	if len(hints) != 2 || hints[0] != "Motivation: Say who is affected" {
+		t.Errorf("Unexpected hints: %v", hints)
+	}
+}
+
+func TestParseDescriptionAssessmentInvalid(t *testing.T) {
+	if _, err := ParseDescriptionAssessment("The description looks fine."); err == nil {
+		t.Error("Expected an error for a response without JSON")
+	}
+}
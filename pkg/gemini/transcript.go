@@ -0,0 +1,177 @@
+package gemini
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Transcript records a single prompt/response exchange with the Gemini API
+type Transcript struct {
+	ID        string        `json:"id"`
+	Timestamp time.Time     `json:"timestamp"`
+	Model     string        `json:"model"`
+	TicketKey string        `json:"ticket_key,omitempty"`
+	Prompt    string        `json:"prompt"`
+	Response  string        `json:"response,omitempty"`
+	Error     string        `json:"error,omitempty"`
+	Cached    bool          `json:"cached,omitempty"`
+	Usage     UsageMetadata `json:"usage"`
+}
+
+// TranscriptStore appends transcripts to a local JSONL file
+type TranscriptStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// GetTranscriptPath returns the path for the transcript log
+// If configDir is empty, uses the default ~/.jira-tool
+func GetTranscriptPath(configDir string) string {
+	if configDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "./.jira-tool/transcripts.jsonl"
+		}
+		configDir = filepath.Join(homeDir, ".jira-tool")
+	}
+	return filepath.Join(configDir, "transcripts.jsonl")
+}
+
+// NewTranscriptStore creates a transcript store backed by the given file
+func NewTranscriptStore(path string) *TranscriptStore {
+	return &TranscriptStore{path: path}
+}
+
+// newTranscriptID derives a short, stable identifier for a transcript entry
+func newTranscriptID(timestamp time.Time, prompt string) string {
+	sum := sha256.Sum256([]byte(timestamp.Format(time.RFC3339Nano) + prompt))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// Append writes a transcript entry as a single JSON line
+func (s *TranscriptStore) Append(entry *Transcript) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry.ID == "" {
+		entry.ID = newTranscriptID(entry.Timestamp, entry.Prompt)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create transcript directory: %w", err)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal transcript: %w", err)
+	}
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open transcript log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write transcript: %w", err)
+	}
+
+	return nil
+}
+
+// List returns all transcripts in the order they were recorded
+// Returns an empty list if the log doesn't exist yet (not an error)
+func (s *TranscriptStore) List() ([]Transcript, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Transcript{}, nil
+		}
+		return nil, fmt.Errorf("failed to open transcript log: %w", err)
+	}
+	defer f.Close()
+
+	transcripts := []Transcript{}
+	scanner := bufio.NewScanner(f)
+	// Prompts can be large - allow lines up to 16MB
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry Transcript
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			// Skip corrupt lines rather than failing the whole listing
+			continue
+		}
+		transcripts = append(transcripts, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read transcript log: %w", err)
+	}
+
+	return transcripts, nil
+}
+
+// Find looks up a transcript by ID, unique ID prefix, or "#N" for its 1-based position in the log
+func (s *TranscriptStore) Find(ref string) (*Transcript, error) {
+	transcripts, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	// Positions are marked with '#' because IDs are hex and may be all digits
+	if number, ok := strings.CutPrefix(ref, "#"); ok {
+		index, err := strconv.Atoi(number)
+		if err != nil || index < 1 || index > len(transcripts) {
+			return nil, fmt.Errorf("transcript %s not found (%d recorded)", ref, len(transcripts))
+		}
+		return &transcripts[index-1], nil
+	}
+
+	var match *Transcript
+	for i := range transcripts {
+		if transcripts[i].ID == ref {
+			return &transcripts[i], nil
+		}
+		if strings.HasPrefix(transcripts[i].ID, ref) {
+			if match != nil && match.ID != transcripts[i].ID {
+				return nil, fmt.Errorf("transcript ID %s is ambiguous", ref)
+			}
+			match = &transcripts[i]
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("transcript %s not found", ref)
+	}
+	return match, nil
+}
+
+// Replay re-sends a recorded prompt to the model it was originally sent to.
+// The response cache is bypassed so a fresh generation is always produced,
+// and the new exchange is recorded in the transcript log.
+func Replay(configDir string, entry *Transcript) (string, error) {
+	c, err := newClient(configDir, true)
+	if err != nil {
+		return "", err
+	}
+	if entry.Model != "" {
+		c.setModel(entry.Model)
+	}
+	c.ticketKey = entry.TicketKey
+	return c.generateContent(entry.Prompt)
+}
@@ -0,0 +1,151 @@
+package gemini
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTranscriptStore(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := NewTranscriptStore(filepath.Join(tmpDir, "transcripts.jsonl"))
+
+	// Listing before anything is recorded should not fail
+	transcripts, err := store.List()
+	if err != nil {
+		t.Fatalf("Failed to list empty transcript log: %v", err)
+	}
+	if len(transcripts) != 0 {
+		t.Errorf("Expected 0 transcripts, got %d", len(transcripts))
+	}
+
+	first := &Transcript{
+		Timestamp: time.Now(),
+		Model:     "gemini-2.5-flash",
+		TicketKey: "ENG-123",
+		Prompt:    "first prompt",
+		Response:  "first response",
+		Usage:     UsageMetadata{PromptTokenCount: 10, CandidatesTokenCount: 5, TotalTokenCount: 15},
+	}
+	second := &Transcript{
+		Timestamp: time.Now(),
+		Model:     "gemini-2.5-flash",
+		Prompt:    "second prompt",
+		Error:     "Gemini API returned error: 500",
+	}
+	if err := store.Append(first); err != nil {
+		t.Fatalf("Failed to append transcript: %v", err)
+	}
+	if err := store.Append(second); err != nil {
+		t.Fatalf("Failed to append transcript: %v", err)
+	}
+
+	if first.ID == "" || first.ID == second.ID {
+		t.Errorf("Expected distinct transcript IDs, got %q and %q", first.ID, second.ID)
+	}
+
+	transcripts, err = store.List()
+	if err != nil {
+		t.Fatalf("Failed to list transcripts: %v", err)
+	}
+	if len(transcripts) != 2 {
+		t.Fatalf("Expected 2 transcripts, got %d", len(transcripts))
+	}
+	if transcripts[0].TicketKey != "ENG-123" || transcripts[0].Usage.TotalTokenCount != 15 {
+		t.Errorf("Unexpected first transcript: %+v", transcripts[0])
+	}
+
+	t.Run("Find by number", func(t *testing.T) {
+		found, err := store.Find("#2")
+		if err != nil {
+			t.Fatalf("Failed to find transcript: %v", err)
+		}
+		if found.Prompt != "second prompt" {
+			t.Errorf("Expected second prompt, got %q", found.Prompt)
+		}
+	})
+
+	t.Run("Find by ID prefix", func(t *testing.T) {
+		found, err := store.Find(first.ID[:6])
+		if err != nil {
+			t.Fatalf("Failed to find transcript: %v", err)
+		}
+		if found.ID != first.ID {
+			t.Errorf("Expected ID %s, got %s", first.ID, found.ID)
+		}
+	})
+
+	t.Run("Digits are an ID prefix, not a position", func(t *testing.T) {
+		numericStore := NewTranscriptStore(filepath.Join(tmpDir, "numeric.jsonl"))
+		other := &Transcript{ID: "abcd1234", Timestamp: time.Now(), Prompt: "other prompt"}
+		numeric := &Transcript{ID: "1234abcd", Timestamp: time.Now(), Prompt: "numeric prompt"}
+		if err := numericStore.Append(other); err != nil {
+			t.Fatal(err)
+		}
+		if err := numericStore.Append(numeric); err != nil {
+			t.Fatal(err)
+		}
+		// Position 1 is the other transcript, but "1" is a prefix of the numeric one's ID
+		found, err := numericStore.Find("1")
+		if err != nil {
+			t.Fatalf("Failed to find transcript: %v", err)
+		}
+		if found.ID != numeric.ID {
+			t.Errorf("Expected ID %s, got %s", numeric.ID, found.ID)
+		}
+	})
+
+	t.Run("Find missing", func(t *testing.T) {
+		if _, err := store.Find("#3"); err == nil {
+			t.Error("Expected error for out of range transcript number")
+		}
+		if _, err := store.Find("zzzz"); err == nil {
+			t.Error("Expected error for unknown transcript ID")
+		}
+	})
+}
+
+func TestResponseCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	cache := NewResponseCache(filepath.Join(tmpDir, "gemini-cache"), time.Hour)
+
+	if _, ok := cache.Get("gemini-2.5-flash", "prompt"); ok {
+		t.Fatal("Expected cache miss before Put")
+	}
+
+	usage := UsageMetadata{TotalTokenCount: 42}
+	if err := cache.Put("gemini-2.5-flash", "prompt", "response", usage); err != nil {
+		t.Fatalf("Failed to put cached response: %v", err)
+	}
+
+	cached, ok := cache.Get("gemini-2.5-flash", "prompt")
+	if !ok {
+		t.Fatal("Expected cache hit after Put")
+	}
+	if cached.Response != "response" || cached.Usage.TotalTokenCount != 42 {
+		t.Errorf("Unexpected cached response: %+v", cached)
+	}
+
+	// Same prompt on a different model is a different entry
+	if _, ok := cache.Get("gemini-2.5-pro", "prompt"); ok {
+		t.Error("Expected cache miss for different model")
+	}
+
+	// Expired entries are ignored
+	data, err := json.Marshal(cachedResponse{
+		Model:     "gemini-2.5-flash",
+		Response:  "stale",
+		CreatedAt: time.Now().Add(-2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal cached response: %v", err)
+	}
+	if err := os.WriteFile(cache.entryPath("gemini-2.5-flash", "old prompt"), data, 0600); err != nil {
+		t.Fatalf("Failed to write cached response: %v", err)
+	}
+	if _, ok := cache.Get("gemini-2.5-flash", "old prompt"); ok {
+		t.Error("Expected cache miss for expired entry")
+	}
+}
@@ -0,0 +1,250 @@
+package gemini
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+)
+
+// ErrBudgetExceeded is returned instead of calling Gemini once a hard token budget is reached
+var ErrBudgetExceeded = errors.New("AI token budget exceeded")
+
+// modelPrice is the price in USD per million tokens for a model
+type modelPrice struct {
+	Input  float64
+	Output float64
+}
+
+// defaultModelPrices holds list prices (USD per million tokens) for common models
+// Costs are estimates only; override with ai_input_price_per_million / ai_output_price_per_million
+var defaultModelPrices = map[string]modelPrice{
+	"gemini-2.5-pro":        {Input: 1.25, Output: 10.00},
+	"gemini-2.5-flash":      {Input: 0.30, Output: 2.50},
+	"gemini-2.5-flash-lite": {Input: 0.10, Output: 0.40},
+	"gemini-2.0-flash":      {Input: 0.10, Output: 0.40},
+	"gemini-2.0-flash-lite": {Input: 0.075, Output: 0.30},
+}
+
+// RunUsage is the Gemini usage accumulated during the current command run
+type RunUsage struct {
+	Requests       int
+	CachedRequests int
+	PromptTokens   int
+	ResponseTokens int
+	TotalTokens    int
+	CostUSD        float64
+}
+
+// runUsage accumulates usage across every Gemini client created in this process
+var (
+	runUsageMu      sync.Mutex
+	runUsage        RunUsage
+	runSoftWarned   bool
+	dailySoftWarned bool
+	hardLimitWarned bool
+	// usagePruned is set once old daily usage logs have been removed in this run
+	usagePruned bool
+)
+
+const (
+	// usageDateFormat is the name format of daily usage logs
+	usageDateFormat = "2006-01-02"
+	// usageHistoryDays is how many days of daily usage logs are kept
+	usageHistoryDays = 30
+)
+
+// GetRunUsage returns the Gemini usage accumulated so far in this run
+func GetRunUsage() RunUsage {
+	runUsageMu.Lock()
+	defer runUsageMu.Unlock()
+	return runUsage
+}
+
+// GetDailyUsage returns the Gemini usage recorded today (from the daily usage log)
+func GetDailyUsage(configDir string) DailyUsage {
+	usage, err := readDailyUsage(configDir, time.Now())
+	if err != nil {
+		return DailyUsage{}
+	}
+	return usage
+}
+
+// budget holds the token limits and price overrides that apply to a client
+// A limit or price of zero means no limit / use the default price
+type budget struct {
+	runSoft     int
+	runHard     int
+	dailySoft   int
+	dailyHard   int
+	inputPrice  float64
+	outputPrice float64
+}
+
+// newBudget builds the budget from config
+func newBudget(cfg *config.Config) budget {
+	return budget{
+		runSoft:     cfg.AIRunSoftLimitTokens,
+		runHard:     cfg.AIRunHardLimitTokens,
+		dailySoft:   cfg.AIDailySoftLimitTokens,
+		dailyHard:   cfg.AIDailyHardLimitTokens,
+		inputPrice:  cfg.AIInputPricePerMillion,
+		outputPrice: cfg.AIOutputPricePerMillion,
+	}
+}
+
+// lookupModelPrice returns the default price for a model, matching versioned
+// names (e.g. "gemini-2.5-flash-001") by their longest known prefix
+func lookupModelPrice(model string) modelPrice {
+	model = strings.TrimPrefix(model, "models/")
+	if price, ok := defaultModelPrices[model]; ok {
+		return price
+	}
+
+	best := ""
+	for name := range defaultModelPrices {
+		if strings.HasPrefix(model, name) && len(name) > len(best) {
+			best = name
+		}
+	}
+	return defaultModelPrices[best]
+}
+
+// cost returns the estimated cost in USD of a request to model
+func (b budget) cost(model string, usage UsageMetadata) float64 {
+	price := lookupModelPrice(model)
+	if b.inputPrice > 0 {
+		price.Input = b.inputPrice
+	}
+	if b.outputPrice > 0 {
+		price.Output = b.outputPrice
+	}
+	return float64(usage.PromptTokenCount)*price.Input/1e6 +
+		float64(usage.CandidatesTokenCount)*price.Output/1e6
+}
+
+// checkBudget returns ErrBudgetExceeded if a hard limit has been reached
+func (c *geminiClient) checkBudget() error {
+	if c.budget.runHard == 0 && c.budget.dailyHard == 0 {
+		return nil
+	}
+
+	runUsageMu.Lock()
+	runTotal := runUsage.TotalTokens
+	runUsageMu.Unlock()
+
+	var err error
+	if c.budget.runHard > 0 && runTotal >= c.budget.runHard {
+		err = fmt.Errorf("%w: %d tokens used this run (limit %d)", ErrBudgetExceeded, runTotal, c.budget.runHard)
+	} else if c.budget.dailyHard > 0 {
+		dailyTotal := GetDailyUsage(c.configDir).TotalTokens
+		if dailyTotal >= c.budget.dailyHard {
+			err = fmt.Errorf("%w: %d tokens used today (limit %d)", ErrBudgetExceeded, dailyTotal, c.budget.dailyHard)
+		}
+	}
+
+	if err != nil {
+		runUsageMu.Lock()
+		if !hardLimitWarned {
+			fmt.Fprintf(os.Stderr, "Warning: %v. Further AI calls will be skipped.\n", err)
+			hardLimitWarned = true
+		}
+		runUsageMu.Unlock()
+	}
+
+	return err
+}
+
+// recordUsage adds a request's usage to the run and daily totals and warns
+// (once per run) when a soft limit is crossed
+func (c *geminiClient) recordUsage(usage UsageMetadata, cached bool) {
+	cost := 0.0
+	if !cached {
+		cost = c.budget.cost(c.model, usage)
+	}
+
+	runUsageMu.Lock()
+	runUsage.Requests++
+	if cached {
+		runUsage.CachedRequests++
+	} else {
+		runUsage.PromptTokens += usage.PromptTokenCount
+		runUsage.ResponseTokens += usage.CandidatesTokenCount
+		runUsage.TotalTokens += usage.TotalTokenCount
+		runUsage.CostUSD += cost
+	}
+	runTotal := runUsage.TotalTokens
+	warnRun := c.budget.runSoft > 0 && runTotal >= c.budget.runSoft && !runSoftWarned
+	if warnRun {
+		runSoftWarned = true
+	}
+	runUsageMu.Unlock()
+
+	if warnRun {
+		fmt.Fprintf(os.Stderr, "Warning: %d AI tokens used this run (soft limit %d)\n", runTotal, c.budget.runSoft)
+	}
+
+	// Cached responses cost nothing, so there is nothing to add to the daily totals
+	if cached {
+		return
+	}
+
+	now := time.Now()
+	entry := usageEntry{
+		Timestamp:      now,
+		Model:          c.model,
+		PromptTokens:   usage.PromptTokenCount,
+		ResponseTokens: usage.CandidatesTokenCount,
+		TotalTokens:    usage.TotalTokenCount,
+		CostUSD:        cost,
+	}
+	if err := appendUsage(c.configDir, entry); err != nil {
+		// Usage tracking is optional - don't interrupt the command
+		return
+	}
+
+	runUsageMu.Lock()
+	prune := !usagePruned
+	usagePruned = true
+	runUsageMu.Unlock()
+	if prune {
+		_ = pruneUsage(c.configDir, now.AddDate(0, 0, -usageHistoryDays).Format(usageDateFormat))
+	}
+
+	dailyTotal := GetDailyUsage(c.configDir).TotalTokens
+	runUsageMu.Lock()
+	warnDaily := c.budget.dailySoft > 0 && dailyTotal >= c.budget.dailySoft && !dailySoftWarned
+	if warnDaily {
+		dailySoftWarned = true
+	}
+	runUsageMu.Unlock()
+
+	if warnDaily {
+		fmt.Fprintf(os.Stderr, "Warning: %d AI tokens used today (soft limit %d)\n", dailyTotal, c.budget.dailySoft)
+	}
+}
+
+// FormatUsageSummary returns a one-line summary of this run's Gemini usage
+// alongside today's totals, or an empty string if Gemini wasn't used
+func FormatUsageSummary(configDir string) string {
+	run := GetRunUsage()
+	if run.Requests == 0 {
+		return ""
+	}
+
+	summary := fmt.Sprintf("AI usage: %d request(s)", run.Requests)
+	if run.CachedRequests > 0 {
+		summary += fmt.Sprintf(" (%d cached)", run.CachedRequests)
+	}
+	summary += fmt.Sprintf(", %d tokens (%d in / %d out), ~$%.4f",
+		run.TotalTokens, run.PromptTokens, run.ResponseTokens, run.CostUSD)
+
+	daily := GetDailyUsage(configDir)
+	summary += fmt.Sprintf("; today: %d tokens, ~$%.4f", daily.TotalTokens, daily.CostUSD)
+
+	return summary
+}
@@ -0,0 +1,131 @@
+package gemini
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+)
+
+func TestLookupModelPrice(t *testing.T) {
+	if price := lookupModelPrice("models/gemini-2.5-pro"); price.Input != 1.25 {
+		t.Errorf("Expected gemini-2.5-pro input price 1.25, got %v", price.Input)
+	}
+	// Versioned names match the longest known prefix
+	if price := lookupModelPrice("gemini-2.5-flash-lite-001"); price.Output != 0.40 {
+		t.Errorf("Expected gemini-2.5-flash-lite output price 0.40, got %v", price.Output)
+	}
+	if price := lookupModelPrice("unknown-model"); price.Input != 0 || price.Output != 0 {
+		t.Errorf("Expected zero price for unknown model, got %+v", price)
+	}
+}
+
+func TestBudgetCost(t *testing.T) {
+	usage := UsageMetadata{PromptTokenCount: 1000000, CandidatesTokenCount: 1000000}
+
+	b := newBudget(&config.Config{})
+	if cost := b.cost("gemini-2.5-flash", usage); math.Abs(cost-2.80) > 1e-9 {
+		t.Errorf("Expected cost 2.80, got %v", cost)
+	}
+
+	b = newBudget(&config.Config{AIInputPricePerMillion: 1, AIOutputPricePerMillion: 2})
+	if cost := b.cost("gemini-2.5-flash", usage); math.Abs(cost-3) > 1e-9 {
+		t.Errorf("Expected overridden cost 3, got %v", cost)
+	}
+}
+
+func TestBudgetHardLimits(t *testing.T) {
+	runUsageMu.Lock()
+	saved := runUsage
+	runUsage = RunUsage{}
+	runUsageMu.Unlock()
+	defer func() {
+		runUsageMu.Lock()
+		runUsage = saved
+		runUsageMu.Unlock()
+	}()
+
+	tmpDir := t.TempDir()
+	c := &geminiClient{
+		model:     "gemini-2.5-flash",
+		configDir: tmpDir,
+		budget:    newBudget(&config.Config{AIRunHardLimitTokens: 100, AIDailyHardLimitTokens: 150}),
+	}
+
+	if err := c.checkBudget(); err != nil {
+		t.Fatalf("Expected no error before any usage, got %v", err)
+	}
+
+	c.recordUsage(UsageMetadata{PromptTokenCount: 80, CandidatesTokenCount: 40, TotalTokenCount: 120}, false)
+	if err := c.checkBudget(); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("Expected ErrBudgetExceeded after run limit, got %v", err)
+	}
+
+	// Daily usage is persisted in the usage log and applies to later runs
+	if daily := GetDailyUsage(tmpDir); daily.TotalTokens != 120 {
+		t.Errorf("Expected 120 tokens recorded today, got %d", daily.TotalTokens)
+	}
+	runUsageMu.Lock()
+	runUsage = RunUsage{}
+	runUsageMu.Unlock()
+	if err := c.checkBudget(); err != nil {
+		t.Fatalf("Expected no error below daily limit, got %v", err)
+	}
+	c.recordUsage(UsageMetadata{TotalTokenCount: 40}, false)
+	runUsageMu.Lock()
+	runUsage = RunUsage{}
+	runUsageMu.Unlock()
+	if err := c.checkBudget(); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("Expected ErrBudgetExceeded after daily limit, got %v", err)
+	}
+
+	// Cached responses don't count towards budgets
+	c.recordUsage(UsageMetadata{TotalTokenCount: 1000}, true)
+	if run := GetRunUsage(); run.TotalTokens != 0 || run.CachedRequests != 1 {
+		t.Errorf("Expected cached request to add no tokens, got %+v", run)
+	}
+}
+
+func TestDailyUsageLog(t *testing.T) {
+	tmpDir := t.TempDir()
+	today := time.Now()
+
+	// Concurrent requests (e.g. from parallel runs) must all be counted
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			entry := usageEntry{Timestamp: today, PromptTokens: 2, ResponseTokens: 1, TotalTokens: 3, CostUSD: 0.01}
+			if err := appendUsage(tmpDir, entry); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	usage, err := readDailyUsage(tmpDir, today)
+	if err != nil {
+		t.Fatalf("Failed to read daily usage: %v", err)
+	}
+	if usage.Requests != 20 || usage.PromptTokens != 40 || usage.TotalTokens != 60 || math.Abs(usage.CostUSD-0.2) > 1e-9 {
+		t.Errorf("Unexpected daily usage: %+v", usage)
+	}
+
+	old := today.AddDate(0, 0, -40)
+	if err := appendUsage(tmpDir, usageEntry{Timestamp: old, TotalTokens: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if err := pruneUsage(tmpDir, today.AddDate(0, 0, -usageHistoryDays).Format(usageDateFormat)); err != nil {
+		t.Fatalf("Failed to prune usage: %v", err)
+	}
+	if usage, _ := readDailyUsage(tmpDir, old); usage.Requests != 0 {
+		t.Errorf("Expected the old usage log to be pruned, got %+v", usage)
+	}
+	if usage, _ := readDailyUsage(tmpDir, today); usage.Requests != 20 {
+		t.Errorf("Expected today's usage log to be kept, got %+v", usage)
+	}
+}
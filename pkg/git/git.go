@@ -0,0 +1,176 @@
+// Package git runs git on PATH in a working tree
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"unicode"
+)
+
+// ErrNotRepository is returned when the directory isn't in a git working tree
+var ErrNotRepository = errors.New("not a git repository")
+
+// Repo is a git working tree
+type Repo struct {
+	// Dir is a directory in the working tree; empty for the current directory
+	Dir string
+}
+
+// run runs git with args and returns its output without the trailing newline
+func (r *Repo) run(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.Dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if strings.Contains(message, "not a git repository") {
+			return "", ErrNotRepository
+		}
+		if message == "" {
+			message = err.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], message)
+	}
+	return strings.TrimRight(stdout.String(), "\n"), nil
+}
+
+// CurrentBranch returns the name of the checked out branch
+func (r *Repo) CurrentBranch() (string, error) {
+	branch, err := r.run("symbolic-ref", "--short", "-q", "HEAD")
+	if err != nil {
+		if errors.Is(err, ErrNotRepository) {
+			return "", err
+		}
+		return "", errors.New("no branch is checked out (detached HEAD)")
+	}
+	return branch, nil
+}
+
+// BranchExists checks if a local branch exists
+func (r *Repo) BranchExists(name string) bool {
+	_, err := r.run("rev-parse", "--verify", "-q", "refs/heads/"+name)
+	return err == nil
+}
+
+// CreateBranch creates a branch from base (HEAD if empty) and checks it out
+func (r *Repo) CreateBranch(name, base string) error {
+	args := []string{"checkout", "-b", name}
+	if base != "" {
+		args = append(args, base)
+	}
+	_, err := r.run(args...)
+	return err
+}
+
+// Checkout checks out an existing branch
+func (r *Repo) Checkout(name string) error {
+	_, err := r.run("checkout", name)
+	return err
+}
+
+// HooksDir returns the directory git runs hooks from, honouring core.hooksPath
+func (r *Repo) HooksDir() (string, error) {
+	dir, err := r.run("rev-parse", "--path-format=absolute", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// Commit is a commit in the log
+type Commit struct {
+	Hash    string
+	Subject string
+	Body    string
+}
+
+// ShortHash returns the abbreviated commit hash
+func (c *Commit) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
+}
+
+// Message returns the subject and body of the commit
+func (c *Commit) Message() string {
+	return strings.TrimSpace(c.Subject + "\n\n" + c.Body)
+}
+
+// Log returns the commits reachable from to but not from from, newest first
+// An empty to means HEAD, and an empty from lists the whole history
+func (r *Repo) Log(from, to string) ([]Commit, error) {
+	if to == "" {
+		to = "HEAD"
+	}
+	revisions := to
+	if from != "" {
+		revisions = from + ".." + to
+	}
+
+	// Fields are separated by the unit separator and commits by the record separator,
+	// neither of which appear in commit messages
+	output, err := r.run("log", "--no-merges", "--format=%H%x1f%s%x1f%b%x1e", revisions, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []Commit
+	for _, record := range strings.Split(output, "\x1e") {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x1f", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		commits = append(commits, Commit{Hash: fields[0], Subject: fields[1], Body: strings.TrimSpace(fields[2])})
+	}
+	return commits, nil
+}
+
+// defaultSlugLength is the most characters of the summary used in a branch name
+const defaultSlugLength = 40
+
+// BranchName fills in a branch name pattern such as "{type}/{key}-{slug}"
+// The slug is the summary in lower case with words joined by dashes
+func BranchName(pattern, branchType, project, key, summary string) string {
+	name := strings.NewReplacer(
+		"{type}", Slug(branchType, defaultSlugLength),
+		"{key}", key,
+		"{project}", project,
+		"{slug}", Slug(summary, defaultSlugLength),
+	).Replace(pattern)
+
+	// A placeholder left empty (e.g. no summary) shouldn't leave stray separators
+	for strings.Contains(name, "--") || strings.Contains(name, "//") {
+		name = strings.ReplaceAll(strings.ReplaceAll(name, "--", "-"), "//", "/")
+	}
+	return strings.Trim(strings.ReplaceAll(strings.ReplaceAll(name, "/-", "/"), "-/", "/"), "-/")
+}
+
+// Slug turns text into lower case words joined by dashes, cut at a word to at most maxLength
+func Slug(text string, maxLength int) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)))
+	})
+
+	slug := ""
+	for _, word := range words {
+		next := word
+		if slug != "" {
+			next = slug + "-" + word
+		}
+		if len(next) > maxLength {
+			if slug == "" {
+				slug = word[:maxLength]
+			}
+			break
+		}
+		slug = next
+	}
+	return slug
+}
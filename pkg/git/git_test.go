@@ -0,0 +1,143 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// newTestRepo creates a repository with one commit on main
+func newTestRepo(t *testing.T) *Repo {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := &Repo{Dir: t.TempDir()}
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test"},
+		{"commit", "-q", "--allow-empty", "-m", "Initial commit"},
+	} {
+		if _, err := repo.run(args...); err != nil {
+			t.Fatalf("git %v failed: %v", args, err)
+		}
+	}
+	return repo
+}
+
+func TestBranches(t *testing.T) {
+	repo := newTestRepo(t)
+
+	branch, err := repo.CurrentBranch()
+	if err != nil || branch != "main" {
+		t.Fatalf("Expected main, got %q (err %v)", branch, err)
+	}
+
+	if err := repo.CreateBranch("bug/ENG-1-crash", ""); err != nil {
+		t.Fatalf("CreateBranch failed: %v", err)
+	}
+	if branch, _ := repo.CurrentBranch(); branch != "bug/ENG-1-crash" {
+		t.Errorf("Expected the new branch checked out, got %q", branch)
+	}
+	if !repo.BranchExists("bug/ENG-1-crash") || repo.BranchExists("bug/ENG-2") {
+		t.Error("Expected only the created branch to exist")
+	}
+	if err := repo.CreateBranch("bug/ENG-1-crash", "main"); err == nil {
+		t.Error("Expected an error creating an existing branch")
+	}
+
+	if err := repo.Checkout("main"); err != nil {
+		t.Fatalf("Checkout failed: %v", err)
+	}
+	if branch, _ := repo.CurrentBranch(); branch != "main" {
+		t.Errorf("Expected main checked out, got %q", branch)
+	}
+
+	if _, err := repo.run("checkout", "-q", "--detach"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.CurrentBranch(); err == nil {
+		t.Error("Expected an error on a detached HEAD")
+	}
+}
+
+func TestLog(t *testing.T) {
+	repo := newTestRepo(t)
+	for _, message := range []string{"ENG-1: Fix crash\n\nAlso see ENG-2", "Tidy up"} {
+		if _, err := repo.run("commit", "-q", "--allow-empty", "-m", message); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	commits, err := repo.Log("HEAD~2", "")
+	if err != nil {
+		t.Fatalf("Log failed: %v", err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("Expected 2 commits, got %d", len(commits))
+	}
+	if commits[0].Subject != "Tidy up" || commits[0].Body != "" {
+		t.Errorf("Expected the newest commit first, got %+v", commits[0])
+	}
+	if commits[1].Message() != "ENG-1: Fix crash\n\nAlso see ENG-2" || len(commits[1].ShortHash()) != 7 {
+		t.Errorf("Unexpected commit %+v", commits[1])
+	}
+
+	if all, _ := repo.Log("", ""); len(all) != 3 {
+		t.Errorf("Expected the whole history, got %d commits", len(all))
+	}
+	if _, err := repo.Log("no-such-ref", ""); err == nil {
+		t.Error("Expected an error for an unknown ref")
+	}
+}
+
+func TestHooksDir(t *testing.T) {
+	repo := newTestRepo(t)
+
+	dir, err := repo.HooksDir()
+	if err != nil || dir != filepath.Join(repo.Dir, ".git", "hooks") {
+		t.Errorf("Expected .git/hooks, got %q (err %v)", dir, err)
+	}
+
+	if _, err := repo.run("config", "core.hooksPath", "githooks"); err != nil {
+		t.Fatal(err)
+	}
+	if dir, _ := repo.HooksDir(); dir != filepath.Join(repo.Dir, "githooks") {
+		t.Errorf("Expected core.hooksPath, got %q", dir)
+	}
+}
+
+func TestNotRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("GIT_CEILING_DIRECTORIES", t.TempDir())
+
+	repo := &Repo{Dir: t.TempDir()}
+	if _, err := repo.CurrentBranch(); !errors.Is(err, ErrNotRepository) {
+		t.Errorf("Expected ErrNotRepository, got %v", err)
+	}
+}
+
+func TestBranchName(t *testing.T) {
+	tests := []struct {
+		pattern, branchType, project, key, summary string
+		want                                       string
+	}{
+		{"{type}/{key}-{slug}", "Bug", "ENG", "ENG-1", "Crash on start!", "bug/ENG-1-crash-on-start"},
+		{"{type}/{key}-{slug}", "Sub-task", "ENG", "ENG-2", "", "sub-task/ENG-2"},
+		{"{project}/{key}_{slug}", "", "OPS", "OPS-7", "Rotate certs", "OPS/OPS-7_rotate-certs"},
+		{"{type}/{key}-{slug}", "", "ENG", "ENG-3", "Fix it", "ENG-3-fix-it"},
+		{"{key}-{slug}", "Task", "ENG", "ENG-4",
+			"Make the login page accessible to screen readers and keyboards", "ENG-4-make-the-login-page-accessible-to-screen"},
+	}
+	for _, tt := range tests {
+		if got := BranchName(tt.pattern, tt.branchType, tt.project, tt.key, tt.summary); got != tt.want {
+			t.Errorf("BranchName(%q, %q, %q, %q, %q) = %q, want %q",
+				tt.pattern, tt.branchType, tt.project, tt.key, tt.summary, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+// Package hooks installs git hooks that keep commits traceable to Jira tickets, and runs their checks
+package hooks
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+// DefaultPrefix is how the ticket key is put in front of the subject when it isn't in the message
+const DefaultPrefix = "{key}: "
+
+// hookMarker identifies hooks installed by this tool, so they can be replaced without --force
+const hookMarker = "# Installed by 'jira hooks install'"
+
+// ErrNoTicket is returned for a commit message that names no ticket, on a branch that doesn't either
+var ErrNoTicket = errors.New("the commit message doesn't name a Jira ticket")
+
+// Ticket is what the commit check needs to know about a ticket
+type Ticket struct {
+	Key     string
+	Summary string
+	Status  string
+	// StatusCategory is the key of the status's category; commits shouldn't refer to done tickets
+	StatusCategory string
+}
+
+// CommitCheck checks that a commit message names an open ticket
+type CommitCheck struct {
+	// Projects whose keys are accepted; empty accepts any key
+	Projects []string
+	// Branch is the current branch, whose ticket is used when the message names none
+	Branch string
+	// Lookup fetches a ticket, or is nil to only check the key (offline)
+	Lookup func(key string) (*Ticket, error)
+	// Prefix is put in front of the subject when the ticket was taken from the branch,
+	// with {key} and {summary} filled in; empty leaves the message as it is
+	Prefix string
+}
+
+// CommitResult is a commit message that passed the check
+type CommitResult struct {
+	Key string
+	// FromBranch is set when the message didn't name the ticket and the branch's was used
+	FromBranch bool
+	// Ticket is nil when checked offline
+	Ticket *Ticket
+	// Message is the commit message, with the prefix added if there is one
+	Message string
+	// Skipped is set for messages git generates (merges, fixups), which aren't checked
+	Skipped bool
+}
+
+// Check checks a commit message, returning it with the ticket it names
+func (c *CommitCheck) Check(message string) (*CommitResult, error) {
+	text := withoutComments(message)
+	if isGenerated(text) {
+		return &CommitResult{Message: message, Skipped: true}, nil
+	}
+
+	result := &CommitResult{Message: message}
+	for _, key := range jira.FindTicketKeys(text) {
+		if c.known(key) {
+			result.Key = key
+			break
+		}
+	}
+	if result.Key == "" {
+		if key := jira.FindTicketKey(c.Branch); key != "" && c.known(key) {
+			result.Key = key
+			result.FromBranch = true
+		}
+	}
+	if result.Key == "" {
+		if len(c.Projects) > 0 {
+			return nil, fmt.Errorf("%w (e.g. %s-123)", ErrNoTicket, c.Projects[0])
+		}
+		return nil, ErrNoTicket
+	}
+
+	if c.Lookup != nil {
+		ticket, err := c.Lookup(result.Key)
+		if err != nil {
+			return nil, err
+		}
+		if jira.IsDoneCategory(ticket.StatusCategory) {
+			return nil, fmt.Errorf("%s is %s: commit to an open ticket instead", ticket.Key, ticket.Status)
+		}
+		result.Ticket = ticket
+	}
+
+	if result.FromBranch && c.Prefix != "" {
+		result.Message = c.prefix(result) + message
+	}
+	return result, nil
+}
+
+// known checks if a key is in one of the accepted projects
+func (c *CommitCheck) known(key string) bool {
+	if len(c.Projects) == 0 {
+		return true
+	}
+	project := jira.ProjectKey(key)
+	for _, known := range c.Projects {
+		if strings.EqualFold(project, known) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CommitCheck) prefix(result *CommitResult) string {
+	summary := ""
+	if result.Ticket != nil {
+		summary = result.Ticket.Summary
+	}
+	// Without a summary (offline), drop the space before it too
+	return strings.NewReplacer(
+		"{key}", result.Key,
+		" {summary}", prefixWith(" ", summary),
+		"{summary}", summary,
+	).Replace(c.Prefix)
+}
+
+// prefixWith returns text after separator, or "" without text
+func prefixWith(separator, text string) string {
+	if text == "" {
+		return ""
+	}
+	return separator + text
+}
+
+// withoutComments drops the lines git strips from the message
+func withoutComments(message string) string {
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		if strings.HasPrefix(line, "# ------------------------ >8") {
+			break
+		}
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// isGenerated checks for messages git writes itself, which don't need a ticket
+func isGenerated(text string) bool {
+	for _, prefix := range []string{"Merge ", "fixup! ", "squash! ", "amend! "} {
+		if strings.HasPrefix(text, prefix) {
+			return true
+		}
+	}
+	return text == ""
+}
+
+// CommitMsgHook returns the commit-msg hook script that runs command with the message file
+func CommitMsgHook(command string) string {
+	return fmt.Sprintf("#!/bin/sh\n%s: checks the commit message names a Jira ticket\nexec %s \"$1\"\n",
+		hookMarker, command)
+}
+
+// InstallCommitMsg writes the commit-msg hook into hooksDir and returns its path
+// A commit-msg hook that wasn't installed by this tool is only replaced with force
+func InstallCommitMsg(hooksDir, command string, force bool) (string, error) {
+	path := filepath.Join(hooksDir, "commit-msg")
+	if existing, err := os.ReadFile(path); err == nil && !force && !strings.Contains(string(existing), hookMarker) {
+		return "", fmt.Errorf("%s already exists; use --force to replace it", path)
+	}
+
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(CommitMsgHook(command)), 0755); err != nil {
+		return "", fmt.Errorf("failed to write hook: %w", err)
+	}
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(path, 0755); err != nil {
+		return "", fmt.Errorf("failed to make hook executable: %w", err)
+	}
+	return path, nil
+}
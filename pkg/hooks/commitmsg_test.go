@@ -0,0 +1,112 @@
+package hooks
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+func lookup(tickets ...Ticket) func(string) (*Ticket, error) {
+	return func(key string) (*Ticket, error) {
+		for i := range tickets {
+			if tickets[i].Key == key {
+				return &tickets[i], nil
+			}
+		}
+		return nil, errors.New("not found")
+	}
+}
+
+func TestCommitCheck(t *testing.T) {
+	check := &CommitCheck{
+		Projects: []string{"ENG"},
+		Branch:   "bug/ENG-7-crash",
+		Lookup: lookup(
+			Ticket{Key: "ENG-1", Summary: "Login", Status: "In Progress", StatusCategory: jira.StatusCategoryInProgress},
+			Ticket{Key: "ENG-2", Summary: "Old", Status: "Shipped", StatusCategory: jira.StatusCategoryDone},
+			Ticket{Key: "ENG-7", Summary: "Crash on start", Status: "Open", StatusCategory: jira.StatusCategoryToDo},
+		),
+	}
+
+	result, err := check.Check("Fix login for UTF-8 names (ENG-1)\n")
+	if err != nil || result.Key != "ENG-1" || result.FromBranch || result.Ticket.Summary != "Login" {
+		t.Errorf("Expected ENG-1 from the message, got %+v (err %v)", result, err)
+	}
+
+	if _, err := check.Check("ENG-2: Tidy up"); err == nil || !strings.Contains(err.Error(), "ENG-2 is Shipped") {
+		t.Errorf("Expected an error for a closed ticket, got %v", err)
+	}
+	if _, err := check.Check("ENG-9: Tidy up"); err == nil {
+		t.Error("Expected an error for a missing ticket")
+	}
+
+	result, err = check.Check("Fix the crash\n\n# ENG-1 in a comment doesn't count\n")
+	if err != nil || result.Key != "ENG-7" || !result.FromBranch || result.Message != "Fix the crash\n\n# ENG-1 in a comment doesn't count\n" {
+		t.Errorf("Expected ENG-7 from the branch, unchanged message, got %+v (err %v)", result, err)
+	}
+
+	check.Prefix = "[{key}] {summary}: "
+	if result, _ := check.Check("Fix the crash\n"); result.Message != "[ENG-7] Crash on start: Fix the crash\n" {
+		t.Errorf("Expected the key and summary prepended, got %q", result.Message)
+	}
+
+	check.Branch = "main"
+	if _, err := check.Check("OPS-3: Rotate certs"); !errors.Is(err, ErrNoTicket) || !strings.Contains(err.Error(), "ENG-123") {
+		t.Errorf("Expected ErrNoTicket for an unknown project, got %v", err)
+	}
+	if result, err := check.Check("Merge branch 'main' into feature\n"); err != nil || !result.Skipped {
+		t.Errorf("Expected merges to be skipped, got %+v (err %v)", result, err)
+	}
+}
+
+func TestCommitCheckOffline(t *testing.T) {
+	check := &CommitCheck{Branch: "ENG-4-docs", Prefix: "[{key}] {summary}: "}
+
+	result, err := check.Check("OPS-3: Rotate certs")
+	if err != nil || result.Key != "OPS-3" || result.Ticket != nil {
+		t.Errorf("Expected any project to be accepted offline, got %+v (err %v)", result, err)
+	}
+	if result, _ := check.Check("Update docs"); result.Message != "[ENG-4]: Update docs" {
+		t.Errorf("Expected only the key prepended offline, got %q", result.Message)
+	}
+}
+
+func TestInstallCommitMsg(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "hooks")
+
+	path, err := InstallCommitMsg(dir, "jira hooks check-commit --offline", false)
+	if err != nil {
+		t.Fatalf("InstallCommitMsg failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "#!/bin/sh\n") || !strings.Contains(string(data), `exec jira hooks check-commit --offline "$1"`) {
+		t.Errorf("Unexpected hook:\n%s", data)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm()&0100 == 0 {
+		t.Errorf("Expected the hook to be executable, got %v", info.Mode())
+	}
+
+	// Our own hook is replaced; someone else's needs --force
+	if _, err := InstallCommitMsg(dir, "jira hooks check-commit", false); err != nil {
+		t.Errorf("Expected the installed hook to be replaced, got %v", err)
+	}
+	if err := os.WriteFile(path, []byte("#!/bin/sh\nexit 0\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := InstallCommitMsg(dir, "jira hooks check-commit", false); err == nil {
+		t.Error("Expected an error replacing another hook")
+	}
+	if _, err := InstallCommitMsg(dir, "jira hooks check-commit", true); err != nil {
+		t.Errorf("Expected --force to replace it, got %v", err)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm()&0100 == 0 {
+		t.Errorf("Expected the replaced hook to be executable, got %v", info.Mode())
+	}
+}
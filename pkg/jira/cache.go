@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 )
 
 // Cache holds cached Jira data
@@ -15,8 +16,25 @@ type Cache struct {
 	Releases   []ReleaseParsed        `json:"releases,omitempty"`
 	Users      map[string][]User      `json:"users,omitempty"`      // keyed by search query
 	Components map[string][]Component `json:"components,omitempty"` // keyed by project key
-	mu         sync.RWMutex
-	path       string
+	// Tickets are the user's open tickets, kept for shell completion
+	Tickets          []CachedTicket `json:"tickets,omitempty"`
+	TicketsFetchedAt time.Time      `json:"tickets_fetched_at,omitempty"`
+	// CheckedTickets are tickets looked up by the commit-msg hook, keyed by ticket key
+	CheckedTickets map[string]CachedTicket `json:"checked_tickets,omitempty"`
+	mu             sync.RWMutex
+	path           string
+}
+
+// CachedTicket is the part of a ticket shown when completing ticket keys
+type CachedTicket struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Status  string `json:"status,omitempty"`
+	// StatusCategory is the key of the status's category, e.g. "done"
+	StatusCategory string `json:"status_category,omitempty"`
+	// CheckedAt is when a ticket in CheckedTickets was looked up
+	CheckedAt time.Time `json:"checked_at,omitempty"`
 }
 
 // GetCachePath returns the path for the cache file
@@ -105,6 +123,9 @@ func (c *Cache) Clear() error {
 	c.Releases = nil
 	c.Users = make(map[string][]User)
 	c.Components = make(map[string][]Component)
+	c.Tickets = nil
+	c.TicketsFetchedAt = time.Time{}
+	c.CheckedTickets = nil
 
 	// Delete the cache file
 	if err := os.Remove(c.path); err != nil && !os.IsNotExist(err) {
@@ -114,6 +135,48 @@ func (c *Cache) Clear() error {
 	return nil
 }
 
+// SetTickets replaces the cached open tickets and saves the cache
+func (c *Cache) SetTickets(tickets []CachedTicket, fetchedAt time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Tickets = tickets
+	c.TicketsFetchedAt = fetchedAt
+	return c.saveUnlocked()
+}
+
+// Ticket returns a ticket from the user's open tickets or the checked tickets, if it was fetched
+// within maxAge
+func (c *Cache) Ticket(key string, maxAge time.Duration) (CachedTicket, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if ticket, ok := c.CheckedTickets[key]; ok && time.Since(ticket.CheckedAt) < maxAge {
+		return ticket, true
+	}
+	if time.Since(c.TicketsFetchedAt) < maxAge {
+		for _, ticket := range c.Tickets {
+			if ticket.Key == key {
+				return ticket, true
+			}
+		}
+	}
+	return CachedTicket{}, false
+}
+
+// SetCheckedTicket records a ticket looked up at checkedAt and saves the cache
+func (c *Cache) SetCheckedTicket(ticket CachedTicket, checkedAt time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.CheckedTickets == nil {
+		c.CheckedTickets = make(map[string]CachedTicket)
+	}
+	ticket.CheckedAt = checkedAt
+	c.CheckedTickets[ticket.Key] = ticket
+	return c.saveUnlocked()
+}
+
 // ClearComponentsForProject clears the cached components for a specific project
 func (c *Cache) ClearComponentsForProject(projectKey string) {
 	c.mu.Lock()
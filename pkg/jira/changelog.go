@@ -0,0 +1,143 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// jiraTimeFormat is the timestamp format used by the Jira REST API
+const jiraTimeFormat = "2006-01-02T15:04:05.000-0700"
+
+// StatusChange is a single status transition from a ticket's changelog
+type StatusChange struct {
+	From string
+	To   string
+	At   time.Time
+}
+
+// ChangelogEntry is a change to one field of a ticket
+type ChangelogEntry struct {
+	Author string    `json:"author,omitempty"`
+	At     time.Time `json:"at"`
+	Field  string    `json:"field"`
+	From   string    `json:"from,omitempty"`
+	To     string    `json:"to,omitempty"`
+}
+
+// changelog is the changelog of an issue fetched with expand=changelog
+type changelog struct {
+	Histories []struct {
+		Author struct {
+			DisplayName string `json:"displayName"`
+		} `json:"author"`
+		Created string `json:"created"`
+		Items   []struct {
+			Field      string `json:"field"`
+			FromString string `json:"fromString"`
+			ToString   string `json:"toString"`
+		} `json:"items"`
+	} `json:"histories"`
+}
+
+// changelogResponse is the subset of an issue with expand=changelog that we use
+type changelogResponse struct {
+	Changelog changelog `json:"changelog"`
+}
+
+// entries returns the changes in the changelog, oldest first
+func (cl *changelog) entries() []ChangelogEntry {
+	entries := []ChangelogEntry{}
+	for _, history := range cl.Histories {
+		at, err := time.Parse(jiraTimeFormat, history.Created)
+		if err != nil {
+			continue // Skip entries with unparseable timestamps
+		}
+		for _, item := range history.Items {
+			entries = append(entries, ChangelogEntry{
+				Author: history.Author.DisplayName, At: at,
+				Field: item.Field, From: item.FromString, To: item.ToString,
+			})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].At.Before(entries[j].At)
+	})
+	return entries
+}
+
+// GetStatusHistory returns the status transitions of a ticket, oldest first
+func (c *jiraClient) GetStatusHistory(ticketID string) ([]StatusChange, error) {
+	endpoint, err := buildURL(c.baseURL, fmt.Sprintf("/rest/api/2/issue/%s", ticketID), map[string]string{
+		"expand": "changelog",
+		"fields": "status",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	req, err := http.NewRequest("GET", endpoint, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	c.setAuth(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if resp.StatusCode == 404 {
+			return nil, fmt.Errorf("ticket %s not found", ticketID)
+		}
+		return nil, fmt.Errorf("Jira API returned error: %d %s", resp.StatusCode, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var issue changelogResponse
+	if err := json.Unmarshal(body, &issue); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	changes := []StatusChange{}
+	for _, entry := range issue.Changelog.entries() {
+		if entry.Field == "status" {
+			changes = append(changes, StatusChange{From: entry.From, To: entry.To, At: entry.At})
+		}
+	}
+
+	return changes, nil
+}
+
+// CycleTime returns the time from the first transition into an in-progress status (work started)
+// to the last transition into a done status, using Jira's status categories
+// ok is false if the ticket never started or isn't done
+func CycleTime(changes []StatusChange, categories StatusCategories) (cycle time.Duration, ok bool) {
+	if len(changes) == 0 {
+		return 0, false
+	}
+
+	last := changes[len(changes)-1]
+	if categories.Category(last.To) != StatusCategoryDone {
+		return 0, false
+	}
+
+	for _, change := range changes {
+		if categories.Category(change.To) == StatusCategoryInProgress {
+			return last.At.Sub(change.At), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,110 @@
+package jira
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetStatusHistory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/issue/ENG-123" {
+			t.Errorf("expected path /rest/api/2/issue/ENG-123, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("expand") != "changelog" {
+			t.Errorf("expected expand=changelog, got %s", r.URL.Query().Get("expand"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		// Histories are deliberately out of order
+		_, _ = w.Write([]byte(`{"changelog": {"histories": [
+			{"created": "2025-01-08T17:00:00.000+0000", "items": [
+				{"field": "status", "fromString": "In Progress", "toString": "Done"}]},
+			{"created": "2025-01-06T09:00:00.000+0000", "items": [
+				{"field": "assignee", "fromString": "", "toString": "Alex"},
+				{"field": "status", "fromString": "To Do", "toString": "In Progress"}]}
+		]}}`))
+	}))
+	defer server.Close()
+
+	client := &jiraClient{baseURL: server.URL, httpClient: &http.Client{}, authToken: "test-token"}
+
+	changes, err := client.GetStatusHistory("ENG-123")
+	if err != nil {
+		t.Fatalf("GetStatusHistory failed: %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 status changes, got %d", len(changes))
+	}
+	if changes[0].To != "In Progress" || changes[1].To != "Done" {
+		t.Errorf("expected changes oldest first, got %+v", changes)
+	}
+
+	categories := StatusCategories{
+		"To Do": StatusCategoryToDo, "In Progress": StatusCategoryInProgress, "Done": StatusCategoryDone,
+	}
+	cycle, ok := CycleTime(changes, categories)
+	if !ok {
+		t.Fatal("expected cycle time for a done ticket")
+	}
+	if cycle != 56*time.Hour {
+		t.Errorf("expected cycle time 56h, got %v", cycle)
+	}
+
+	if _, ok := CycleTime(changes[:1], categories); ok {
+		t.Error("expected no cycle time for a ticket that isn't done")
+	}
+}
+
+func TestCycleTime(t *testing.T) {
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	categories := StatusCategories{
+		"Open": StatusCategoryToDo, "Backlog": StatusCategoryToDo,
+		"Doing": StatusCategoryInProgress, "Review": StatusCategoryInProgress, "Shipped": StatusCategoryDone,
+	}
+
+	// Triage before work starts doesn't count; the first in-progress status starts the clock
+	changes := []StatusChange{
+		{From: "Open", To: "Backlog", At: start.Add(-100 * time.Hour)},
+		{From: "Backlog", To: "Doing", At: start},
+		{From: "Doing", To: "Review", At: start.Add(10 * time.Hour)},
+		{From: "Review", To: "Shipped", At: start.Add(30 * time.Hour)},
+	}
+	if cycle, ok := CycleTime(changes, categories); !ok || cycle != 30*time.Hour {
+		t.Errorf("expected cycle time 30h, got %v (ok %v)", cycle, ok)
+	}
+
+	// Closed without ever being worked on
+	skipped := []StatusChange{{From: "Open", To: "Shipped", At: start}}
+	if _, ok := CycleTime(skipped, categories); ok {
+		t.Error("expected no cycle time for a ticket that was never in progress")
+	}
+}
+
+func TestGetStatusCategories(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/status" {
+			t.Errorf("expected path /rest/api/2/status, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"name": "Backlog", "statusCategory": {"key": "new"}},
+			{"name": "Doing", "statusCategory": {"key": "indeterminate"}},
+			{"name": "Shipped", "statusCategory": {"key": "done"}}
+		]`))
+	}))
+	defer server.Close()
+
+	client := &jiraClient{baseURL: server.URL, httpClient: &http.Client{}, authToken: "test-token"}
+
+	categories, err := client.GetStatusCategories()
+	if err != nil {
+		t.Fatalf("GetStatusCategories failed: %v", err)
+	}
+	if categories.Category("shipped") != StatusCategoryDone || categories.Category("Doing") != StatusCategoryInProgress {
+		t.Errorf("unexpected categories %v", categories)
+	}
+	if categories.Category("Unknown") != "" {
+		t.Error("expected no category for an unknown status")
+	}
+}
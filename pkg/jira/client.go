@@ -52,6 +52,17 @@ type JiraClient interface {
 	ClearComponentCache(projectKey string)
 	GetBoardsForProject(projectKey string) ([]Board, error)
 	DetectEpicLinkField(projectKey string) (string, error)
+	GetStatusHistory(ticketID string) ([]StatusChange, error)
+	GetStatusCategories() (StatusCategories, error)
+	GetTicketDetails(ticketID string) (*TicketDetails, error)
+	AddLabels(ticketID string, labels []string) error
+	UpdateTicketFields(ticketID string, fields map[string]interface{}) error
+	GetIssueLinkTypes() ([]IssueLinkType, error)
+	LinkIssues(linkType, fromKey, toKey string) error
+	RankIssues(issueKeys []string, afterKey string) error
+	DeleteTicket(ticketID string) error
+	GetRemoteLinks(ticketID string) ([]RemoteLink, error)
+	AddRemoteLink(ticketID string, link *RemoteLink) error
 }
 
 // Attachment represents a Jira attachment
@@ -59,6 +70,9 @@ type Attachment struct {
 	ID       string `json:"id"`
 	Filename string `json:"filename"`
 	Content  string `json:"content"` // URL to download
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Created  string `json:"created"`
 }
 
 // Comment represents a Jira comment
@@ -151,10 +165,14 @@ type Issue struct {
 	Fields struct {
 		Summary string `json:"summary"`
 		Status  struct {
-			Name string `json:"name"`
+			Name           string `json:"name"`
+			StatusCategory struct {
+				Key string `json:"key"`
+			} `json:"statusCategory"`
 		} `json:"status"`
 		IssueType struct {
-			Name string `json:"name"`
+			Name    string `json:"name"`
+			Subtask bool   `json:"subtask"`
 		} `json:"issuetype"`
 		Priority struct {
 			ID   string `json:"id"`
@@ -0,0 +1,208 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// TicketDetails is everything about a single ticket apart from its comments and children:
+// its fields, description, links, attachments and change history
+type TicketDetails struct {
+	Key         string           `json:"key"`
+	URL         string           `json:"url"`
+	Summary     string           `json:"summary"`
+	Type        string           `json:"type"`
+	Status      string           `json:"status"`
+	Resolution  string           `json:"resolution,omitempty"`
+	Priority    string           `json:"priority,omitempty"`
+	Assignee    string           `json:"assignee,omitempty"`
+	Reporter    string           `json:"reporter,omitempty"`
+	Created     string           `json:"created,omitempty"`
+	Updated     string           `json:"updated,omitempty"`
+	StoryPoints float64          `json:"story_points,omitempty"`
+	Parent      string           `json:"parent,omitempty"`
+	Labels      []string         `json:"labels,omitempty"`
+	Components  []string         `json:"components,omitempty"`
+	FixVersions []string         `json:"fix_versions,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Links       []IssueLink      `json:"links,omitempty"`
+	Attachments []Attachment     `json:"attachments,omitempty"`
+	History     []ChangelogEntry `json:"history,omitempty"`
+}
+
+// IssueLink is a link from a ticket to another, e.g. "blocks ENG-2"
+type IssueLink struct {
+	// Relation is the link type as seen from the ticket, e.g. "blocks" or "is blocked by"
+	Relation string `json:"relation"`
+	Key      string `json:"key"`
+	Summary  string `json:"summary,omitempty"`
+	Status   string `json:"status,omitempty"`
+}
+
+// namedValue is a field value that Jira returns as an object with a name
+type namedValue struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// linkedIssue is the other end of an issue link
+type linkedIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary string     `json:"summary"`
+		Status  namedValue `json:"status"`
+	} `json:"fields"`
+}
+
+// detailsResponse is an issue fetched with expand=changelog
+type detailsResponse struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary     string       `json:"summary"`
+		Description string       `json:"description"`
+		IssueType   namedValue   `json:"issuetype"`
+		Status      namedValue   `json:"status"`
+		Resolution  *namedValue  `json:"resolution"`
+		Priority    *namedValue  `json:"priority"`
+		Assignee    *namedValue  `json:"assignee"`
+		Reporter    *namedValue  `json:"reporter"`
+		Created     string       `json:"created"`
+		Updated     string       `json:"updated"`
+		Labels      []string     `json:"labels"`
+		Components  []namedValue `json:"components"`
+		FixVersions []namedValue `json:"fixVersions"`
+		Parent      *struct {
+			Key string `json:"key"`
+		} `json:"parent"`
+		IssueLinks []struct {
+			Type struct {
+				Inward  string `json:"inward"`
+				Outward string `json:"outward"`
+			} `json:"type"`
+			InwardIssue  *linkedIssue `json:"inwardIssue"`
+			OutwardIssue *linkedIssue `json:"outwardIssue"`
+		} `json:"issuelinks"`
+		Attachment []Attachment `json:"attachment"`
+	} `json:"fields"`
+	Changelog changelog `json:"changelog"`
+}
+
+// GetTicketDetails fetches a ticket's fields, links, attachments and changelog in one request
+func (c *jiraClient) GetTicketDetails(ticketID string) (*TicketDetails, error) {
+	endpoint, err := buildURL(c.baseURL, fmt.Sprintf("/rest/api/2/issue/%s", ticketID), map[string]string{
+		"expand": "changelog",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	req, err := http.NewRequest("GET", endpoint, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	c.setAuth(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if resp.StatusCode == 404 {
+			return nil, fmt.Errorf("ticket %s not found", ticketID)
+		}
+		return nil, fmt.Errorf("Jira API returned error: %d %s", resp.StatusCode, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var issue detailsResponse
+	if err := json.Unmarshal(body, &issue); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	details := newTicketDetails(&issue)
+	details.URL = fmt.Sprintf("%s/browse/%s", strings.TrimSuffix(c.baseURL, "/"), issue.Key)
+
+	// Story points live in a custom field whose ID is configurable
+	var fields struct {
+		Fields map[string]interface{} `json:"fields"`
+	}
+	if err := json.Unmarshal(body, &fields); err == nil {
+		if points, ok := fields.Fields[c.storyPointsFieldID].(float64); ok {
+			details.StoryPoints = points
+		}
+	}
+
+	return details, nil
+}
+
+// newTicketDetails flattens an issue response into TicketDetails
+func newTicketDetails(issue *detailsResponse) *TicketDetails {
+	f := &issue.Fields
+	details := &TicketDetails{
+		Key:         issue.Key,
+		Summary:     f.Summary,
+		Type:        f.IssueType.Name,
+		Status:      f.Status.Name,
+		Resolution:  nameOf(f.Resolution),
+		Priority:    nameOf(f.Priority),
+		Assignee:    nameOf(f.Assignee),
+		Reporter:    nameOf(f.Reporter),
+		Created:     f.Created,
+		Updated:     f.Updated,
+		Labels:      f.Labels,
+		Description: f.Description,
+		Attachments: f.Attachment,
+		History:     issue.Changelog.entries(),
+	}
+	if f.Parent != nil {
+		details.Parent = f.Parent.Key
+	}
+	for _, component := range f.Components {
+		details.Components = append(details.Components, component.Name)
+	}
+	for _, version := range f.FixVersions {
+		details.FixVersions = append(details.FixVersions, version.Name)
+	}
+
+	for _, link := range f.IssueLinks {
+		switch {
+		case link.OutwardIssue != nil:
+			details.Links = append(details.Links, newIssueLink(link.Type.Outward, link.OutwardIssue))
+		case link.InwardIssue != nil:
+			details.Links = append(details.Links, newIssueLink(link.Type.Inward, link.InwardIssue))
+		}
+	}
+
+	return details
+}
+
+func newIssueLink(relation string, issue *linkedIssue) IssueLink {
+	return IssueLink{
+		Relation: relation,
+		Key:      issue.Key,
+		Summary:  issue.Fields.Summary,
+		Status:   issue.Fields.Status.Name,
+	}
+}
+
+// nameOf returns the display name of a user, or the name of any other named value
+func nameOf(value *namedValue) string {
+	if value == nil {
+		return ""
+	}
+	if value.DisplayName != "" {
+		return value.DisplayName
+	}
+	return value.Name
+}
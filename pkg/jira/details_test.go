@@ -0,0 +1,60 @@
+package jira
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetTicketDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("expand") != "changelog" {
+			t.Errorf("expected expand=changelog, got %s", r.URL.Query().Get("expand"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key": "ENG-1", "fields": {
+			"summary": "Crash on start", "description": "h1. Steps",
+			"issuetype": {"name": "Bug"}, "status": {"name": "In Progress"}, "resolution": null,
+			"priority": {"name": "High"}, "assignee": {"displayName": "Jane Doe", "name": "jdoe"},
+			"reporter": null, "labels": ["crash"], "components": [{"name": "Backend"}],
+			"fixVersions": [{"name": "1.2"}], "parent": {"key": "ENG-0"},
+			"customfield_10020": 5,
+			"issuelinks": [
+				{"type": {"inward": "is blocked by", "outward": "blocks"},
+				 "outwardIssue": {"key": "ENG-2", "fields": {"summary": "Release", "status": {"name": "To Do"}}}},
+				{"type": {"inward": "is blocked by", "outward": "blocks"},
+				 "inwardIssue": {"key": "OPS-3", "fields": {"summary": "Infra", "status": {"name": "Done"}}}}
+			],
+			"attachment": [{"id": "9", "filename": "log.txt", "size": 2048}]},
+			"changelog": {"histories": [
+				{"author": {"displayName": "Jane Doe"}, "created": "2025-01-06T09:00:00.000+0000",
+				 "items": [{"field": "status", "fromString": "To Do", "toString": "In Progress"}]}
+			]}}`))
+	}))
+	defer server.Close()
+
+	client := &jiraClient{
+		baseURL: server.URL, httpClient: &http.Client{}, authToken: "test-token", storyPointsFieldID: "customfield_10020",
+	}
+
+	details, err := client.GetTicketDetails("ENG-1")
+	if err != nil {
+		t.Fatalf("GetTicketDetails failed: %v", err)
+	}
+	if details.URL != server.URL+"/browse/ENG-1" || details.Assignee != "Jane Doe" || details.Reporter != "" {
+		t.Errorf("Unexpected header fields: %+v", details)
+	}
+	if details.StoryPoints != 5 || details.Parent != "ENG-0" || details.FixVersions[0] != "1.2" {
+		t.Errorf("Unexpected fields: %+v", details)
+	}
+	if len(details.Links) != 2 || details.Links[0].Relation != "blocks" || details.Links[1].Relation != "is blocked by" ||
+		details.Links[1].Key != "OPS-3" {
+		t.Errorf("Unexpected links: %+v", details.Links)
+	}
+	if len(details.Attachments) != 1 || details.Attachments[0].Size != 2048 {
+		t.Errorf("Unexpected attachments: %+v", details.Attachments)
+	}
+	if len(details.History) != 1 || details.History[0].Author != "Jane Doe" || details.History[0].To != "In Progress" {
+		t.Errorf("Unexpected history: %+v", details.History)
+	}
+}
@@ -0,0 +1,220 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// dryRunKeyMarker is part of the synthetic keys returned for tickets created in a dry run
+const dryRunKeyMarker = "DRYRUN"
+
+// PlannedOperation is a change a dry run would have made
+type PlannedOperation struct {
+	Operation string                 `json:"operation"`
+	Ticket    string                 `json:"ticket,omitempty"`
+	Payload   map[string]interface{} `json:"payload,omitempty"`
+	// Result is the synthetic key returned for created tickets
+	Result string `json:"result,omitempty"`
+}
+
+// String returns a one-line description of the operation
+func (op *PlannedOperation) String() string {
+	parts := []string{op.Operation}
+	if op.Ticket != "" {
+		parts = append(parts, op.Ticket)
+	}
+
+	keys := make([]string, 0, len(op.Payload))
+	for k := range op.Payload {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%v", k, op.Payload[k]))
+	}
+
+	if op.Result != "" {
+		parts = append(parts, "-> "+op.Result)
+	}
+	return strings.Join(parts, " ")
+}
+
+// DryRunPlan collects the operations of a dry run
+// One plan can be shared by several clients, so a command's whole run ends up in one plan
+type DryRunPlan struct {
+	mu         sync.Mutex
+	operations []PlannedOperation
+	created    int
+	// Log, if set, gets a line for each operation as it is planned
+	Log io.Writer
+}
+
+// NewDryRunPlan creates an empty plan
+func NewDryRunPlan() *DryRunPlan {
+	return &DryRunPlan{}
+}
+
+// Operations returns the planned operations in the order they were made
+func (p *DryRunPlan) Operations() []PlannedOperation {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ops := make([]PlannedOperation, len(p.operations))
+	copy(ops, p.operations)
+	return ops
+}
+
+// WriteJSON writes the planned operations as a JSON array
+func (p *DryRunPlan) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(p.Operations()); err != nil {
+		return fmt.Errorf("failed to write dry run plan: %w", err)
+	}
+	return nil
+}
+
+func (p *DryRunPlan) record(op PlannedOperation) {
+	p.mu.Lock()
+	p.operations = append(p.operations, op)
+	p.mu.Unlock()
+
+	if p.Log != nil {
+		fmt.Fprintf(p.Log, "[dry-run] %s\n", op.String())
+	}
+}
+
+// syntheticKey returns a made-up key for a ticket created in project
+func (p *DryRunPlan) syntheticKey(project string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.created++
+	return fmt.Sprintf("%s-%s%d", project, dryRunKeyMarker, p.created)
+}
+
+// IsSyntheticKey checks if a ticket key was made up by a dry run
+func IsSyntheticKey(key string) bool {
+	i := strings.LastIndex(key, "-")
+	return i >= 0 && strings.HasPrefix(key[i+1:], dryRunKeyMarker)
+}
+
+// dryRunClient passes reads through to the real client and records changes in a plan instead of making them
+type dryRunClient struct {
+	JiraClient
+	plan *DryRunPlan
+}
+
+// NewDryRunClient wraps a client so that reads go through but changes are only recorded in plan
+// Creates return synthetic keys (e.g. ENG-DRYRUN1) so later steps can refer to the new tickets
+func NewDryRunClient(client JiraClient, plan *DryRunPlan) JiraClient {
+	return &dryRunClient{JiraClient: client, plan: plan}
+}
+
+func (c *dryRunClient) planned(operation, ticket string, payload map[string]interface{}) error {
+	c.plan.record(PlannedOperation{Operation: operation, Ticket: ticket, Payload: payload})
+	return nil
+}
+
+func (c *dryRunClient) create(operation, project string, payload map[string]interface{}) (string, error) {
+	key := c.plan.syntheticKey(project)
+	payload["project"] = project
+	c.plan.record(PlannedOperation{Operation: operation, Payload: payload, Result: key})
+	return key, nil
+}
+
+// GetIssue returns a stub for tickets created earlier in the dry run, which don't exist in Jira
+func (c *dryRunClient) GetIssue(issueKey string) (*Issue, error) {
+	if IsSyntheticKey(issueKey) {
+		return &Issue{Key: issueKey}, nil
+	}
+	return c.JiraClient.GetIssue(issueKey)
+}
+
+func (c *dryRunClient) UpdateTicketPoints(ticketID string, points int) error {
+	return c.planned("UpdateTicketPoints", ticketID, map[string]interface{}{"points": points})
+}
+
+func (c *dryRunClient) UpdateTicketDescription(ticketID, description string) error {
+	return c.planned("UpdateTicketDescription", ticketID, map[string]interface{}{"description": description})
+}
+
+func (c *dryRunClient) UpdateTicketPriority(ticketID, priorityID string) error {
+	return c.planned("UpdateTicketPriority", ticketID, map[string]interface{}{"priority_id": priorityID})
+}
+
+func (c *dryRunClient) CreateTicket(project, taskType, summary string) (string, error) {
+	return c.create("CreateTicket", project, map[string]interface{}{"type": taskType, "summary": summary})
+}
+
+func (c *dryRunClient) CreateTicketWithParent(project, taskType, summary, parentKey string) (string, error) {
+	return c.create("CreateTicketWithParent", project,
+		map[string]interface{}{"type": taskType, "summary": summary, "parent": parentKey})
+}
+
+func (c *dryRunClient) CreateTicketWithEpicLink(
+	project, taskType, summary, epicKey, epicLinkFieldID string,
+) (string, error) {
+	return c.create("CreateTicketWithEpicLink", project, map[string]interface{}{
+		"type": taskType, "summary": summary, "epic": epicKey, "epic_link_field": epicLinkFieldID,
+	})
+}
+
+func (c *dryRunClient) AssignTicket(ticketID, userAccountID, userName string) error {
+	return c.planned("AssignTicket", ticketID, map[string]interface{}{"account_id": userAccountID, "user": userName})
+}
+
+func (c *dryRunClient) UnassignTicket(ticketID string) error {
+	return c.planned("UnassignTicket", ticketID, nil)
+}
+
+func (c *dryRunClient) TransitionTicket(ticketID, transitionID string) error {
+	return c.planned("TransitionTicket", ticketID, map[string]interface{}{"transition_id": transitionID})
+}
+
+func (c *dryRunClient) AddComment(ticketID, comment string) error {
+	return c.planned("AddComment", ticketID, map[string]interface{}{"comment": comment})
+}
+
+func (c *dryRunClient) AddIssuesToSprint(sprintID int, issueKeys []string) error {
+	return c.planned("AddIssuesToSprint", "", map[string]interface{}{"sprint_id": sprintID, "issues": issueKeys})
+}
+
+func (c *dryRunClient) AddIssuesToRelease(releaseID string, issueKeys []string) error {
+	return c.planned("AddIssuesToRelease", "", map[string]interface{}{"release_id": releaseID, "issues": issueKeys})
+}
+
+func (c *dryRunClient) UpdateTicketComponents(ticketID string, componentIDs []string) error {
+	return c.planned("UpdateTicketComponents", ticketID, map[string]interface{}{"component_ids": componentIDs})
+}
+
+func (c *dryRunClient) UpdateTicketSeverity(ticketID, severityFieldID, severityValue string) error {
+	return c.planned("UpdateTicketSeverity", ticketID,
+		map[string]interface{}{"field": severityFieldID, "severity": severityValue})
+}
+
+func (c *dryRunClient) AddLabels(ticketID string, labels []string) error {
+	return c.planned("AddLabels", ticketID, map[string]interface{}{"labels": labels})
+}
+
+func (c *dryRunClient) UpdateTicketFields(ticketID string, fields map[string]interface{}) error {
+	return c.planned("UpdateTicketFields", ticketID, fields)
+}
+
+func (c *dryRunClient) LinkIssues(linkType, fromKey, toKey string) error {
+	return c.planned("LinkIssues", fromKey, map[string]interface{}{"type": linkType, "to": toKey})
+}
+
+func (c *dryRunClient) RankIssues(issueKeys []string, afterKey string) error {
+	return c.planned("RankIssues", afterKey, map[string]interface{}{"issues": issueKeys})
+}
+
+func (c *dryRunClient) AddRemoteLink(ticketID string, link *RemoteLink) error {
+	return c.planned("AddRemoteLink", ticketID, map[string]interface{}{"url": link.Object.URL, "title": link.Object.Title})
+}
+
+func (c *dryRunClient) DeleteTicket(ticketID string) error {
+	return c.planned("DeleteTicket", ticketID, nil)
+}
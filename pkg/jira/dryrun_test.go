@@ -0,0 +1,80 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// readOnlyClient answers reads; any write reaches the nil embedded client and panics
+type readOnlyClient struct {
+	JiraClient
+	searches int
+}
+
+func (c *readOnlyClient) SearchTickets(_ string) ([]Issue, error) {
+	c.searches++
+	return []Issue{{Key: "ENG-1"}}, nil
+}
+
+func TestDryRunClient(t *testing.T) {
+	base := &readOnlyClient{}
+	plan := NewDryRunPlan()
+	log := &bytes.Buffer{}
+	plan.Log = log
+	client := NewDryRunClient(base, plan)
+
+	// Reads go through
+	issues, err := client.SearchTickets("project = ENG")
+	if err != nil || len(issues) != 1 || base.searches != 1 {
+		t.Fatalf("Expected search to reach the real client, got %v (err %v)", issues, err)
+	}
+
+	// Changes are only recorded
+	if err := client.UpdateTicketPriority("ENG-1", "2"); err != nil {
+		t.Fatalf("UpdateTicketPriority failed: %v", err)
+	}
+	epic, err := client.CreateTicket("ENG", "Epic", "New epic")
+	if err != nil {
+		t.Fatalf("CreateTicket failed: %v", err)
+	}
+	child, err := client.CreateTicketWithParent("ENG", "Sub-task", "Child", epic)
+	if err != nil {
+		t.Fatalf("CreateTicketWithParent failed: %v", err)
+	}
+	if epic != "ENG-DRYRUN1" || child != "ENG-DRYRUN2" {
+		t.Errorf("Expected synthetic keys ENG-DRYRUN1 and ENG-DRYRUN2, got %s and %s", epic, child)
+	}
+	if !IsSyntheticKey(child) || IsSyntheticKey("ENG-1") {
+		t.Error("Expected only the created keys to be synthetic")
+	}
+
+	// Created tickets can be read back
+	issue, err := client.GetIssue(epic)
+	if err != nil || issue.Key != epic {
+		t.Errorf("Expected a stub for the created ticket, got %+v (err %v)", issue, err)
+	}
+
+	ops := plan.Operations()
+	if len(ops) != 3 {
+		t.Fatalf("Expected 3 planned operations, got %d", len(ops))
+	}
+	if ops[0].String() != "UpdateTicketPriority ENG-1 priority_id=2" {
+		t.Errorf("Unexpected operation: %s", ops[0].String())
+	}
+	if ops[2].Payload["parent"] != "ENG-DRYRUN1" || ops[2].Result != "ENG-DRYRUN2" {
+		t.Errorf("Unexpected create operation: %+v", ops[2])
+	}
+	if bytes.Count(log.Bytes(), []byte("[dry-run]")) != 3 {
+		t.Errorf("Expected a log line per operation, got %q", log.String())
+	}
+
+	out := &bytes.Buffer{}
+	if err := plan.WriteJSON(out); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	var decoded []PlannedOperation
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil || len(decoded) != 3 {
+		t.Errorf("Expected 3 operations in the JSON plan, got %d (err %v)", len(decoded), err)
+	}
+}
@@ -124,50 +124,57 @@ func GetChildTickets(client JiraClient, ticketKey, epicLinkFieldID string) ([]st
 		return childSummaries, nil
 	}
 
+	children := GetChildIssues(client, issue, epicLinkFieldID)
+	for i := range children {
+		childSummaries = append(childSummaries, children[i].Fields.Summary)
+	}
+	return childSummaries, nil
+}
+
+// GetChildIssues retrieves the child issues of a ticket in rank order: subtasks, and for Epics,
+// tickets linked via the Epic Link field. Failed searches are skipped.
+func GetChildIssues(client JiraClient, issue *Issue, epicLinkFieldID string) []Issue {
+	children := []Issue{}
+
 	// Get subtasks (for any ticket type)
-	subtasks, err := client.SearchTickets(fmt.Sprintf("parent = %s", ticketKey))
+	subtasks, err := client.SearchTickets(fmt.Sprintf("parent = %s ORDER BY Rank ASC", issue.Key))
 	if err == nil {
-		for i := range subtasks {
-			childSummaries = append(childSummaries, subtasks[i].Fields.Summary)
-		}
+		children = append(children, subtasks...)
 	}
 
 	// If it's an Epic, also get tickets linked via Epic Link
 	if IsEpic(issue) && epicLinkFieldID != "" {
-		// Search for tickets with this Epic Link
-		epicChildren, err := client.SearchTickets(fmt.Sprintf("%s = %s", epicLinkFieldID, ticketKey))
+		epicChildren, err := client.SearchTickets(fmt.Sprintf("%s = %s ORDER BY Rank ASC", epicLinkFieldID, issue.Key))
 		if err == nil {
 			for i := range epicChildren {
 				// Avoid duplicates (in case a ticket is both a subtask and epic child)
-				child := &epicChildren[i]
-				summary := child.Fields.Summary
 				isDuplicate := false
-				for _, existing := range childSummaries {
-					if existing == summary {
+				for j := range children {
+					if children[j].Key == epicChildren[i].Key {
 						isDuplicate = true
 						break
 					}
 				}
 				if !isDuplicate {
-					childSummaries = append(childSummaries, summary)
+					children = append(children, epicChildren[i])
 				}
 			}
 		}
 	}
 
-	return childSummaries, nil
+	return children
 }
 
 // ChildTicketInfo contains full information about a child ticket
 type ChildTicketInfo struct {
-	Key         string
-	Summary     string
-	StoryPoints int
-	Type        string
-	IsSubtask   bool
+	Key         string `json:"key"`
+	Summary     string `json:"summary"`
+	StoryPoints int    `json:"story_points,omitempty"`
+	Type        string `json:"type"`
+	IsSubtask   bool   `json:"is_subtask"`
 }
 
-// GetChildTicketsDetailed retrieves all child tickets with full details
+// GetChildTicketsDetailed retrieves all child tickets with full details, in rank order
 func GetChildTicketsDetailed(client JiraClient, ticketKey, epicLinkFieldID string) ([]ChildTicketInfo, error) {
 	var children []ChildTicketInfo
 
@@ -177,47 +184,15 @@ func GetChildTicketsDetailed(client JiraClient, ticketKey, epicLinkFieldID strin
 		return children, nil
 	}
 
-	// Get subtasks (for any ticket type)
-	subtasks, err := client.SearchTickets(fmt.Sprintf("parent = %s", ticketKey))
-	if err == nil {
-		for i := range subtasks {
-			storyPoints := int(subtasks[i].Fields.StoryPoints)
-			children = append(children, ChildTicketInfo{
-				Key:         subtasks[i].Key,
-				Summary:     subtasks[i].Fields.Summary,
-				StoryPoints: storyPoints,
-				Type:        subtasks[i].Fields.IssueType.Name,
-				IsSubtask:   true,
-			})
-		}
-	}
-
-	// If it's an Epic, also get tickets linked via Epic Link
-	if IsEpic(issue) && epicLinkFieldID != "" {
-		epicChildren, err := client.SearchTickets(fmt.Sprintf("%s = %s", epicLinkFieldID, ticketKey))
-		if err == nil {
-			for i := range epicChildren {
-				// Check if already added as subtask
-				isDuplicate := false
-				for _, existing := range children {
-					if existing.Key == epicChildren[i].Key {
-						isDuplicate = true
-						break
-					}
-				}
-				if !isDuplicate {
-					storyPoints := int(epicChildren[i].Fields.StoryPoints)
-					children = append(children, ChildTicketInfo{
-						Key:         epicChildren[i].Key,
-						Summary:     epicChildren[i].Fields.Summary,
-						StoryPoints: storyPoints,
-						Type:        epicChildren[i].Fields.IssueType.Name,
-						IsSubtask:   false,
-					})
-				}
-			}
-		}
+	issues := GetChildIssues(client, issue, epicLinkFieldID)
+	for i := range issues {
+		children = append(children, ChildTicketInfo{
+			Key:         issues[i].Key,
+			Summary:     issues[i].Fields.Summary,
+			StoryPoints: int(issues[i].Fields.StoryPoints),
+			Type:        issues[i].Fields.IssueType.Name,
+			IsSubtask:   issues[i].Fields.IssueType.Subtask,
+		})
 	}
-
 	return children, nil
 }
@@ -16,10 +16,14 @@ func TestIsEpic(t *testing.T) {
 				Fields: struct {
 					Summary string `json:"summary"`
 					Status  struct {
-						Name string `json:"name"`
+						Name           string `json:"name"`
+						StatusCategory struct {
+							Key string `json:"key"`
+						} `json:"statusCategory"`
 					} `json:"status"`
 					IssueType struct {
-						Name string `json:"name"`
+						Name    string `json:"name"`
+						Subtask bool   `json:"subtask"`
 					} `json:"issuetype"`
 					Priority struct {
 						ID   string `json:"id"`
@@ -40,7 +44,8 @@ func TestIsEpic(t *testing.T) {
 					StoryPoints float64 `json:"customfield_10016"`
 				}{
 					IssueType: struct {
-						Name string `json:"name"`
+						Name    string `json:"name"`
+						Subtask bool   `json:"subtask"`
 					}{Name: "Epic"},
 				},
 			},
@@ -52,10 +57,14 @@ func TestIsEpic(t *testing.T) {
 				Fields: struct {
 					Summary string `json:"summary"`
 					Status  struct {
-						Name string `json:"name"`
+						Name           string `json:"name"`
+						StatusCategory struct {
+							Key string `json:"key"`
+						} `json:"statusCategory"`
 					} `json:"status"`
 					IssueType struct {
-						Name string `json:"name"`
+						Name    string `json:"name"`
+						Subtask bool   `json:"subtask"`
 					} `json:"issuetype"`
 					Priority struct {
 						ID   string `json:"id"`
@@ -76,7 +85,8 @@ func TestIsEpic(t *testing.T) {
 					StoryPoints float64 `json:"customfield_10016"`
 				}{
 					IssueType: struct {
-						Name string `json:"name"`
+						Name    string `json:"name"`
+						Subtask bool   `json:"subtask"`
 					}{Name: "Story"},
 				},
 			},
@@ -88,10 +98,14 @@ func TestIsEpic(t *testing.T) {
 				Fields: struct {
 					Summary string `json:"summary"`
 					Status  struct {
-						Name string `json:"name"`
+						Name           string `json:"name"`
+						StatusCategory struct {
+							Key string `json:"key"`
+						} `json:"statusCategory"`
 					} `json:"status"`
 					IssueType struct {
-						Name string `json:"name"`
+						Name    string `json:"name"`
+						Subtask bool   `json:"subtask"`
 					} `json:"issuetype"`
 					Priority struct {
 						ID   string `json:"id"`
@@ -112,7 +126,8 @@ func TestIsEpic(t *testing.T) {
 					StoryPoints float64 `json:"customfield_10016"`
 				}{
 					IssueType: struct {
-						Name string `json:"name"`
+						Name    string `json:"name"`
+						Subtask bool   `json:"subtask"`
 					}{Name: "Task"},
 				},
 			},
@@ -129,10 +144,14 @@ func TestIsEpic(t *testing.T) {
 				Fields: struct {
 					Summary string `json:"summary"`
 					Status  struct {
-						Name string `json:"name"`
+						Name           string `json:"name"`
+						StatusCategory struct {
+							Key string `json:"key"`
+						} `json:"statusCategory"`
 					} `json:"status"`
 					IssueType struct {
-						Name string `json:"name"`
+						Name    string `json:"name"`
+						Subtask bool   `json:"subtask"`
 					} `json:"issuetype"`
 					Priority struct {
 						ID   string `json:"id"`
@@ -153,7 +172,8 @@ func TestIsEpic(t *testing.T) {
 					StoryPoints float64 `json:"customfield_10016"`
 				}{
 					IssueType: struct {
-						Name string `json:"name"`
+						Name    string `json:"name"`
+						Subtask bool   `json:"subtask"`
 					}{Name: "epic"},
 				},
 			},
@@ -165,10 +185,14 @@ func TestIsEpic(t *testing.T) {
 				Fields: struct {
 					Summary string `json:"summary"`
 					Status  struct {
-						Name string `json:"name"`
+						Name           string `json:"name"`
+						StatusCategory struct {
+							Key string `json:"key"`
+						} `json:"statusCategory"`
 					} `json:"status"`
 					IssueType struct {
-						Name string `json:"name"`
+						Name    string `json:"name"`
+						Subtask bool   `json:"subtask"`
 					} `json:"issuetype"`
 					Priority struct {
 						ID   string `json:"id"`
@@ -189,7 +213,8 @@ func TestIsEpic(t *testing.T) {
 					StoryPoints float64 `json:"customfield_10016"`
 				}{
 					IssueType: struct {
-						Name string `json:"name"`
+						Name    string `json:"name"`
+						Subtask bool   `json:"subtask"`
 					}{Name: "EPIC"},
 				},
 			},
@@ -0,0 +1,167 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// IssueLinkType is a kind of link between issues, e.g. "Blocks" with
+// outward "blocks" and inward "is blocked by"
+type IssueLinkType struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Inward  string `json:"inward"`
+	Outward string `json:"outward"`
+}
+
+// UpdateTicketFields sets fields on a ticket
+// Values are sent as-is, so they must already be in the shape Jira expects for each field
+// (e.g. {"value": "High"} for option fields or "2024-01-31" for dates)
+func (c *jiraClient) UpdateTicketFields(ticketID string, fields map[string]interface{}) error {
+	endpoint := fmt.Sprintf("%s/rest/api/2/issue/%s", c.baseURL, ticketID)
+	payload := map[string]interface{}{"fields": fields}
+
+	return c.sendJSON("PUT", endpoint, payload, ticketID)
+}
+
+// GetIssueLinkTypes returns the issue link types configured in Jira
+func (c *jiraClient) GetIssueLinkTypes() ([]IssueLinkType, error) {
+	endpoint := fmt.Sprintf("%s/rest/api/2/issueLinkType", c.baseURL)
+
+	req, err := http.NewRequest("GET", endpoint, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	c.setAuth(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if resp.StatusCode == 401 || resp.StatusCode == 403 {
+			return nil, fmt.Errorf("authentication failed. Your Jira token may be invalid. Please run 'jira init'")
+		}
+		return nil, fmt.Errorf("Jira API returned error: %d %s", resp.StatusCode, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var result struct {
+		IssueLinkTypes []IssueLinkType `json:"issueLinkTypes"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return result.IssueLinkTypes, nil
+}
+
+// LinkIssues links two issues so that fromKey <outward description> toKey,
+// e.g. with "Blocks", fromKey blocks toKey
+func (c *jiraClient) LinkIssues(linkType, fromKey, toKey string) error {
+	endpoint := fmt.Sprintf("%s/rest/api/2/issueLink", c.baseURL)
+	payload := map[string]interface{}{
+		"type":         map[string]string{"name": linkType},
+		"outwardIssue": map[string]string{"key": fromKey},
+		"inwardIssue":  map[string]string{"key": toKey},
+	}
+
+	return c.sendJSON("POST", endpoint, payload, fromKey)
+}
+
+// RankIssues ranks issues right after afterKey, in the order given
+// Jira ranks at most 50 issues in one request
+func (c *jiraClient) RankIssues(issueKeys []string, afterKey string) error {
+	endpoint := fmt.Sprintf("%s/rest/agile/1.0/issue/rank", c.baseURL)
+	payload := map[string]interface{}{
+		"issues":         issueKeys,
+		"rankAfterIssue": afterKey,
+	}
+
+	return c.sendJSON("PUT", endpoint, payload, afterKey)
+}
+
+// DeleteTicket deletes a ticket
+// Tickets with subtasks are not deleted; Jira returns an error for them instead
+func (c *jiraClient) DeleteTicket(ticketID string) error {
+	endpoint := fmt.Sprintf("%s/rest/api/2/issue/%s", c.baseURL, ticketID)
+
+	req, err := http.NewRequest("DELETE", endpoint, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setAuth(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if resp.StatusCode == 401 || resp.StatusCode == 403 {
+			return fmt.Errorf("not allowed to delete %s: your Jira user may lack the Delete Issues permission", ticketID)
+		}
+		if resp.StatusCode == 404 {
+			return fmt.Errorf("ticket %s not found", ticketID)
+		}
+		body, readErr := io.ReadAll(resp.Body)
+		if readErr != nil {
+			return fmt.Errorf("Jira API returned error: %d %s (failed to read body: %w)", resp.StatusCode, resp.Status, readErr)
+		}
+		return fmt.Errorf("Jira API returned error: %d %s - %s", resp.StatusCode, resp.Status, string(body))
+	}
+
+	return nil
+}
+
+// sendJSON sends a JSON payload and checks the response, for requests whose response body isn't needed
+// ticketID is only used in the not-found error
+func (c *jiraClient) sendJSON(method, endpoint string, payload interface{}, ticketID string) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest(method, endpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	c.setAuth(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if resp.StatusCode == 401 || resp.StatusCode == 403 {
+			return fmt.Errorf("authentication failed. Your Jira token may be invalid. Please run 'jira init'")
+		}
+		if resp.StatusCode == 404 {
+			return fmt.Errorf("ticket %s not found", ticketID)
+		}
+		body, readErr := io.ReadAll(resp.Body)
+		if readErr != nil {
+			return fmt.Errorf("Jira API returned error: %d %s (failed to read body: %w)", resp.StatusCode, resp.Status, readErr)
+		}
+		return fmt.Errorf("Jira API returned error: %d %s - %s", resp.StatusCode, resp.Status, string(body))
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package jira
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUpdateTicketFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/rest/api/2/issue/ENG-123" {
+			t.Errorf("expected PUT /rest/api/2/issue/ENG-123, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var payload map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode payload: %v", err)
+		}
+		if payload["fields"]["duedate"] != "2024-03-01" {
+			t.Errorf("expected duedate field, got %v", payload["fields"])
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := &jiraClient{baseURL: server.URL, httpClient: &http.Client{}, authToken: "test-token"}
+
+	if err := client.UpdateTicketFields("ENG-123", map[string]interface{}{"duedate": "2024-03-01"}); err != nil {
+		t.Fatalf("UpdateTicketFields failed: %v", err)
+	}
+}
+
+func TestLinkIssues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/rest/api/2/issueLink" {
+			t.Errorf("expected POST /rest/api/2/issueLink, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var payload map[string]map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode payload: %v", err)
+		}
+		if payload["type"]["name"] != "Blocks" ||
+			payload["outwardIssue"]["key"] != "ENG-1" || payload["inwardIssue"]["key"] != "ENG-2" {
+			t.Errorf("unexpected link payload: %v", payload)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := &jiraClient{baseURL: server.URL, httpClient: &http.Client{}, authToken: "test-token"}
+
+	if err := client.LinkIssues("Blocks", "ENG-1", "ENG-2"); err != nil {
+		t.Fatalf("LinkIssues failed: %v", err)
+	}
+}
+
+func TestRankIssues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/rest/agile/1.0/issue/rank" {
+			t.Errorf("expected PUT /rest/agile/1.0/issue/rank, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var payload struct {
+			Issues         []string `json:"issues"`
+			RankAfterIssue string   `json:"rankAfterIssue"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode payload: %v", err)
+		}
+		if strings.Join(payload.Issues, ",") != "ENG-3,ENG-2" || payload.RankAfterIssue != "ENG-1" {
+			t.Errorf("unexpected rank payload: %+v", payload)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := &jiraClient{baseURL: server.URL, httpClient: &http.Client{}, authToken: "test-token"}
+
+	if err := client.RankIssues([]string{"ENG-3", "ENG-2"}, "ENG-1"); err != nil {
+		t.Fatalf("RankIssues failed: %v", err)
+	}
+}
+
+func TestDeleteTicket(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Path != "/rest/api/2/issue/ENG-7" {
+			t.Errorf("expected DELETE /rest/api/2/issue/ENG-7, got %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := &jiraClient{baseURL: server.URL, httpClient: &http.Client{}, authToken: "test-token"}
+
+	if err := client.DeleteTicket("ENG-7"); err != nil {
+		t.Fatalf("DeleteTicket failed: %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+package jira
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JournalEntry records one change the tool made in Jira, with the values it replaced
+type JournalEntry struct {
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	// Command is the command line that made the change
+	Command   string `json:"command,omitempty"`
+	Operation string `json:"operation"`
+	Ticket    string `json:"ticket,omitempty"`
+	// Before holds the values fetched just before the change; nil if they couldn't be fetched
+	Before map[string]interface{} `json:"before,omitempty"`
+	After  map[string]interface{} `json:"after,omitempty"`
+	// Reverts is the ID of the entry this one undid
+	Reverts string `json:"reverts,omitempty"`
+}
+
+// Summary returns a one-line description of the change, e.g. "status: New -> In Progress"
+func (e *JournalEntry) Summary() string {
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, values := range []map[string]interface{}{e.Before, e.After} {
+		for k := range values {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		after, changed := e.After[k]
+		before, hadBefore := e.Before[k]
+		switch {
+		case !changed:
+			parts = append(parts, fmt.Sprintf("%s: %s", k, journalValue(before)))
+		case e.Before == nil || !hadBefore:
+			parts = append(parts, fmt.Sprintf("%s: %s", k, journalValue(after)))
+		default:
+			parts = append(parts, fmt.Sprintf("%s: %s -> %s", k, journalValue(before), journalValue(after)))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// journalValue formats a recorded value for display, shortening long text
+func journalValue(v interface{}) string {
+	if v == nil {
+		return "(none)"
+	}
+	var s string
+	switch value := v.(type) {
+	case string:
+		s = value
+		if s == "" {
+			return "(none)"
+		}
+	default:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprint(value)
+		}
+		s = string(data)
+	}
+
+	s = strings.Join(strings.Fields(s), " ")
+	const maxLen = 40
+	if len(s) > maxLen {
+		s = s[:maxLen-3] + "..."
+	}
+	return s
+}
+
+// Journal appends changes made in Jira to a local JSONL file
+type Journal struct {
+	path string
+	mu   sync.Mutex
+}
+
+// GetJournalPath returns the path for the change journal
+// If configDir is empty, uses the default ~/.jira-tool
+func GetJournalPath(configDir string) string {
+	if configDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "./.jira-tool/journal.jsonl"
+		}
+		configDir = filepath.Join(homeDir, ".jira-tool")
+	}
+	return filepath.Join(configDir, "journal.jsonl")
+}
+
+// NewJournal creates a journal backed by the given file
+func NewJournal(path string) *Journal {
+	return &Journal{path: path}
+}
+
+// newJournalID derives a short identifier for a journal entry
+func newJournalID(timestamp time.Time, operation, ticket string) string {
+	sum := sha256.Sum256([]byte(timestamp.Format(time.RFC3339Nano) + operation + ticket))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// Append writes an entry as a single JSON line
+func (j *Journal) Append(entry *JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+	if entry.ID == "" {
+		entry.ID = newJournalID(entry.Timestamp, entry.Operation, entry.Ticket)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(j.path), 0755); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal entry: %w", err)
+	}
+
+	f, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write journal entry: %w", err)
+	}
+
+	return nil
+}
+
+// List returns all entries in the order they were recorded
+// Returns an empty list if the journal doesn't exist yet (not an error)
+func (j *Journal) List() ([]JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	f, err := os.Open(j.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []JournalEntry{}, nil
+		}
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer f.Close()
+
+	entries := []JournalEntry{}
+	scanner := bufio.NewScanner(f)
+	// Descriptions can be large - allow lines up to 16MB
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry JournalEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			// Skip corrupt lines rather than failing the whole listing
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	return entries, nil
+}
+
+// UndoneIDs returns the IDs of the entries that a later entry reverted
+func UndoneIDs(entries []JournalEntry) map[string]bool {
+	undone := map[string]bool{}
+	for i := range entries {
+		if entries[i].Reverts != "" {
+			undone[entries[i].Reverts] = true
+		}
+	}
+	return undone
+}
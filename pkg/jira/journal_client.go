@@ -0,0 +1,368 @@
+package jira
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// journalingClient makes changes through the real client and records each successful one in a journal,
+// along with the values it replaced
+type journalingClient struct {
+	JiraClient
+	journal *Journal
+	command string
+	// warn gets a line when a change can't be journaled; the change itself still goes through
+	warn io.Writer
+}
+
+// NewJournalingClient wraps a client so that every change it makes is recorded in journal
+// command is the command line recorded with each entry
+func NewJournalingClient(client JiraClient, journal *Journal, command string) JiraClient {
+	return &journalingClient{JiraClient: client, journal: journal, command: command, warn: os.Stderr}
+}
+
+func (c *journalingClient) record(operation, ticket string, before, after map[string]interface{}) {
+	entry := &JournalEntry{
+		Timestamp: time.Now(),
+		Command:   c.command,
+		Operation: operation,
+		Ticket:    ticket,
+		Before:    before,
+		After:     after,
+	}
+	if err := c.journal.Append(entry); err != nil && c.warn != nil {
+		fmt.Fprintf(c.warn, "Warning: Could not journal %s on %s: %v\n", operation, ticket, err)
+	}
+}
+
+// issue fetches a ticket for its current values, or nil if it can't be fetched
+func (c *journalingClient) issue(ticketID string) *Issue {
+	issue, err := c.JiraClient.GetIssue(ticketID)
+	if err != nil {
+		return nil
+	}
+	return issue
+}
+
+// rawFields fetches a ticket's fields as returned by Jira, or nil if they can't be fetched
+func (c *journalingClient) rawFields(ticketID string) map[string]interface{} {
+	raw, err := c.JiraClient.GetTicketRaw(ticketID)
+	if err != nil {
+		return nil
+	}
+	fields, ok := raw["fields"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return fields
+}
+
+// idsOf returns the "id" of each object in a raw list field such as components or fixVersions
+func idsOf(value interface{}) []string {
+	ids := []string{}
+	items, _ := value.([]interface{})
+	for _, item := range items {
+		if obj, ok := item.(map[string]interface{}); ok {
+			if id, ok := obj["id"].(string); ok {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+func (c *journalingClient) UpdateTicketPoints(ticketID string, points int) error {
+	var before map[string]interface{}
+	if issue := c.issue(ticketID); issue != nil {
+		before = map[string]interface{}{"story_points": issue.Fields.StoryPoints}
+	}
+	if err := c.JiraClient.UpdateTicketPoints(ticketID, points); err != nil {
+		return err
+	}
+	c.record("UpdateTicketPoints", ticketID, before, map[string]interface{}{"story_points": points})
+	return nil
+}
+
+func (c *journalingClient) UpdateTicketDescription(ticketID, description string) error {
+	var before map[string]interface{}
+	if current, err := c.JiraClient.GetTicketDescription(ticketID); err == nil {
+		before = map[string]interface{}{"description": current}
+	}
+	if err := c.JiraClient.UpdateTicketDescription(ticketID, description); err != nil {
+		return err
+	}
+	c.record("UpdateTicketDescription", ticketID, before, map[string]interface{}{"description": description})
+	return nil
+}
+
+func (c *journalingClient) UpdateTicketPriority(ticketID, priorityID string) error {
+	var before map[string]interface{}
+	if issue := c.issue(ticketID); issue != nil {
+		before = map[string]interface{}{"priority_id": issue.Fields.Priority.ID}
+	}
+	if err := c.JiraClient.UpdateTicketPriority(ticketID, priorityID); err != nil {
+		return err
+	}
+	c.record("UpdateTicketPriority", ticketID, before, map[string]interface{}{"priority_id": priorityID})
+	return nil
+}
+
+func (c *journalingClient) created(operation, key string, after map[string]interface{}) {
+	after["key"] = key
+	c.record(operation, key, nil, after)
+}
+
+func (c *journalingClient) CreateTicket(project, taskType, summary string) (string, error) {
+	key, err := c.JiraClient.CreateTicket(project, taskType, summary)
+	if err != nil {
+		return key, err
+	}
+	c.created("CreateTicket", key, map[string]interface{}{"type": taskType, "summary": summary})
+	return key, nil
+}
+
+func (c *journalingClient) CreateTicketWithParent(project, taskType, summary, parentKey string) (string, error) {
+	key, err := c.JiraClient.CreateTicketWithParent(project, taskType, summary, parentKey)
+	if err != nil {
+		return key, err
+	}
+	c.created("CreateTicketWithParent", key,
+		map[string]interface{}{"type": taskType, "summary": summary, "parent": parentKey})
+	return key, nil
+}
+
+func (c *journalingClient) CreateTicketWithEpicLink(
+	project, taskType, summary, epicKey, epicLinkFieldID string,
+) (string, error) {
+	key, err := c.JiraClient.CreateTicketWithEpicLink(project, taskType, summary, epicKey, epicLinkFieldID)
+	if err != nil {
+		return key, err
+	}
+	c.created("CreateTicketWithEpicLink", key,
+		map[string]interface{}{"type": taskType, "summary": summary, "epic": epicKey})
+	return key, nil
+}
+
+// assignee returns the current assignee of a ticket in the shape recorded in the journal
+func (c *journalingClient) assignee(ticketID string) map[string]interface{} {
+	issue := c.issue(ticketID)
+	if issue == nil {
+		return nil
+	}
+	name := issue.Fields.Assignee.Name
+	if name == "" {
+		name = issue.Fields.Assignee.Key
+	}
+	return map[string]interface{}{
+		"assignee":   issue.Fields.Assignee.DisplayName,
+		"account_id": issue.Fields.Assignee.AccountID,
+		"user":       name,
+	}
+}
+
+func (c *journalingClient) AssignTicket(ticketID, userAccountID, userName string) error {
+	before := c.assignee(ticketID)
+	if err := c.JiraClient.AssignTicket(ticketID, userAccountID, userName); err != nil {
+		return err
+	}
+	assignee := userName
+	if assignee == "" {
+		assignee = userAccountID
+	}
+	c.record("AssignTicket", ticketID, before,
+		map[string]interface{}{"assignee": assignee, "account_id": userAccountID, "user": userName})
+	return nil
+}
+
+func (c *journalingClient) UnassignTicket(ticketID string) error {
+	before := c.assignee(ticketID)
+	if err := c.JiraClient.UnassignTicket(ticketID); err != nil {
+		return err
+	}
+	c.record("UnassignTicket", ticketID, before, map[string]interface{}{"assignee": ""})
+	return nil
+}
+
+func (c *journalingClient) TransitionTicket(ticketID, transitionID string) error {
+	var before map[string]interface{}
+	if issue := c.issue(ticketID); issue != nil {
+		before = map[string]interface{}{"status": issue.Fields.Status.Name}
+	}
+	if err := c.JiraClient.TransitionTicket(ticketID, transitionID); err != nil {
+		return err
+	}
+
+	after := map[string]interface{}{"transition_id": transitionID}
+	if issue := c.issue(ticketID); issue != nil {
+		after["status"] = issue.Fields.Status.Name
+	}
+	c.record("TransitionTicket", ticketID, before, after)
+	return nil
+}
+
+func (c *journalingClient) AddComment(ticketID, comment string) error {
+	if err := c.JiraClient.AddComment(ticketID, comment); err != nil {
+		return err
+	}
+	c.record("AddComment", ticketID, nil, map[string]interface{}{"comment": comment})
+	return nil
+}
+
+func (c *journalingClient) AddIssuesToSprint(sprintID int, issueKeys []string) error {
+	if err := c.JiraClient.AddIssuesToSprint(sprintID, issueKeys); err != nil {
+		return err
+	}
+	for _, key := range issueKeys {
+		c.record("AddIssuesToSprint", key, nil, map[string]interface{}{"sprint_id": sprintID})
+	}
+	return nil
+}
+
+func (c *journalingClient) AddIssuesToRelease(releaseID string, issueKeys []string) error {
+	before := map[string][]string{}
+	for _, key := range issueKeys {
+		if fields := c.rawFields(key); fields != nil {
+			before[key] = idsOf(fields["fixVersions"])
+		}
+	}
+	if err := c.JiraClient.AddIssuesToRelease(releaseID, issueKeys); err != nil {
+		return err
+	}
+
+	// One entry per ticket, so each can be undone on its own
+	for _, key := range issueKeys {
+		previous, ok := before[key]
+		if !ok {
+			c.record("AddIssuesToRelease", key, nil, map[string]interface{}{"release_id": releaseID})
+			continue
+		}
+		c.record("AddIssuesToRelease", key,
+			map[string]interface{}{"fix_versions": previous},
+			map[string]interface{}{"fix_versions": appendMissing(previous, releaseID)})
+	}
+	return nil
+}
+
+func (c *journalingClient) UpdateTicketComponents(ticketID string, componentIDs []string) error {
+	var before map[string]interface{}
+	if issue := c.issue(ticketID); issue != nil {
+		ids := []string{}
+		for _, component := range issue.Fields.Components {
+			ids = append(ids, component.ID)
+		}
+		before = map[string]interface{}{"component_ids": ids}
+	}
+	if err := c.JiraClient.UpdateTicketComponents(ticketID, componentIDs); err != nil {
+		return err
+	}
+	c.record("UpdateTicketComponents", ticketID, before, map[string]interface{}{"component_ids": componentIDs})
+	return nil
+}
+
+func (c *journalingClient) UpdateTicketSeverity(ticketID, severityFieldID, severityValue string) error {
+	var before map[string]interface{}
+	if fields := c.rawFields(ticketID); fields != nil {
+		previous := ""
+		switch value := fields[severityFieldID].(type) {
+		case map[string]interface{}:
+			previous, _ = value["value"].(string)
+		case string:
+			previous = value
+		}
+		before = map[string]interface{}{"severity": previous}
+	}
+	if err := c.JiraClient.UpdateTicketSeverity(ticketID, severityFieldID, severityValue); err != nil {
+		return err
+	}
+	c.record("UpdateTicketSeverity", ticketID, before,
+		map[string]interface{}{"severity": severityValue, "field": severityFieldID})
+	return nil
+}
+
+func (c *journalingClient) AddLabels(ticketID string, labels []string) error {
+	var before map[string]interface{}
+	previous := []string{}
+	if fields := c.rawFields(ticketID); fields != nil {
+		items, _ := fields["labels"].([]interface{})
+		for _, item := range items {
+			if label, ok := item.(string); ok {
+				previous = append(previous, label)
+			}
+		}
+		before = map[string]interface{}{"labels": previous}
+	}
+	if err := c.JiraClient.AddLabels(ticketID, labels); err != nil {
+		return err
+	}
+
+	after := previous
+	for _, label := range labels {
+		after = appendMissing(after, label)
+	}
+	c.record("AddLabels", ticketID, before, map[string]interface{}{"labels": after})
+	return nil
+}
+
+func (c *journalingClient) UpdateTicketFields(ticketID string, fields map[string]interface{}) error {
+	var before map[string]interface{}
+	if current := c.rawFields(ticketID); current != nil {
+		before = map[string]interface{}{}
+		for id := range fields {
+			before[id] = current[id]
+		}
+	}
+	if err := c.JiraClient.UpdateTicketFields(ticketID, fields); err != nil {
+		return err
+	}
+	c.record("UpdateTicketFields", ticketID, before, fields)
+	return nil
+}
+
+func (c *journalingClient) LinkIssues(linkType, fromKey, toKey string) error {
+	if err := c.JiraClient.LinkIssues(linkType, fromKey, toKey); err != nil {
+		return err
+	}
+	c.record("LinkIssues", fromKey, nil, map[string]interface{}{"type": linkType, "to": toKey})
+	return nil
+}
+
+func (c *journalingClient) RankIssues(issueKeys []string, afterKey string) error {
+	if err := c.JiraClient.RankIssues(issueKeys, afterKey); err != nil {
+		return err
+	}
+	c.record("RankIssues", afterKey, nil, map[string]interface{}{"issues": issueKeys})
+	return nil
+}
+
+func (c *journalingClient) AddRemoteLink(ticketID string, link *RemoteLink) error {
+	if err := c.JiraClient.AddRemoteLink(ticketID, link); err != nil {
+		return err
+	}
+	c.record("AddRemoteLink", ticketID, nil, map[string]interface{}{"url": link.Object.URL, "title": link.Object.Title})
+	return nil
+}
+
+func (c *journalingClient) DeleteTicket(ticketID string) error {
+	var before map[string]interface{}
+	if issue := c.issue(ticketID); issue != nil {
+		before = map[string]interface{}{"summary": issue.Fields.Summary, "status": issue.Fields.Status.Name}
+	}
+	if err := c.JiraClient.DeleteTicket(ticketID); err != nil {
+		return err
+	}
+	c.record("DeleteTicket", ticketID, before, nil)
+	return nil
+}
+
+// appendMissing appends value to values unless it's already there, without modifying values
+func appendMissing(values []string, value string) []string {
+	result := append([]string{}, values...)
+	for _, v := range values {
+		if v == value {
+			return result
+		}
+	}
+	return append(result, value)
+}
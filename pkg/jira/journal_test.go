@@ -0,0 +1,173 @@
+package jira
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// fakeTicketClient keeps one ticket in memory so changes and their reverts can be checked
+type fakeTicketClient struct {
+	JiraClient
+	status   string
+	priority string
+	labels   []string
+	deleted  []string
+}
+
+func (c *fakeTicketClient) GetIssue(issueKey string) (*Issue, error) {
+	issue := &Issue{Key: issueKey}
+	issue.Fields.Status.Name = c.status
+	issue.Fields.Priority.ID = c.priority
+	return issue, nil
+}
+
+func (c *fakeTicketClient) GetTicketRaw(_ string) (map[string]interface{}, error) {
+	labels := []interface{}{}
+	for _, label := range c.labels {
+		labels = append(labels, label)
+	}
+	return map[string]interface{}{"fields": map[string]interface{}{"labels": labels}}, nil
+}
+
+func (c *fakeTicketClient) UpdateTicketPriority(_, priorityID string) error {
+	c.priority = priorityID
+	return nil
+}
+
+func (c *fakeTicketClient) GetTransitions(_ string) ([]Transition, error) {
+	var toDone, toNew Transition
+	toDone.ID, toDone.To.Name = "31", "Done"
+	toNew.ID, toNew.To.Name = "11", "New"
+	return []Transition{toDone, toNew}, nil
+}
+
+func (c *fakeTicketClient) TransitionTicket(_, transitionID string) error {
+	c.status = map[string]string{"31": "Done", "11": "New"}[transitionID]
+	return nil
+}
+
+func (c *fakeTicketClient) AddLabels(_ string, labels []string) error {
+	c.labels = append(c.labels, labels...)
+	return nil
+}
+
+func (c *fakeTicketClient) UpdateTicketFields(_ string, fields map[string]interface{}) error {
+	if labels, ok := fields["labels"].([]string); ok {
+		c.labels = labels
+	}
+	return nil
+}
+
+func (c *fakeTicketClient) CreateTicket(project, _, _ string) (string, error) {
+	return project + "-9", nil
+}
+
+func (c *fakeTicketClient) DeleteTicket(ticketID string) error {
+	c.deleted = append(c.deleted, ticketID)
+	return nil
+}
+
+func (c *fakeTicketClient) AddComment(_, _ string) error {
+	return nil
+}
+
+func TestJournalingClientAndRevert(t *testing.T) {
+	journal := NewJournal(filepath.Join(t.TempDir(), "journal.jsonl"))
+	base := &fakeTicketClient{status: "New", priority: "3", labels: []string{"backend"}}
+	client := NewJournalingClient(base, journal, "jira review ENG-1")
+
+	if err := client.TransitionTicket("ENG-1", "31"); err != nil {
+		t.Fatalf("TransitionTicket failed: %v", err)
+	}
+	if err := client.UpdateTicketPriority("ENG-1", "1"); err != nil {
+		t.Fatalf("UpdateTicketPriority failed: %v", err)
+	}
+	if err := client.AddLabels("ENG-1", []string{"urgent"}); err != nil {
+		t.Fatalf("AddLabels failed: %v", err)
+	}
+	if _, err := client.CreateTicket("ENG", "Task", "New task"); err != nil {
+		t.Fatalf("CreateTicket failed: %v", err)
+	}
+	if err := client.AddComment("ENG-1", "Done"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+
+	entries, err := journal.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(entries) != 5 {
+		t.Fatalf("Expected 5 journal entries, got %d", len(entries))
+	}
+	if entries[0].Command != "jira review ENG-1" || entries[0].ID == "" {
+		t.Errorf("Expected command and ID to be recorded, got %+v", entries[0])
+	}
+	if got := entries[0].Summary(); got != "status: New -> Done, transition_id: 31" {
+		t.Errorf("Unexpected transition summary: %q", got)
+	}
+	if entries[3].Ticket != "ENG-9" {
+		t.Errorf("Expected the created ticket to be recorded, got %q", entries[3].Ticket)
+	}
+
+	// The comment can't be undone, so the create is the most recent undoable change
+	undoable := Undoable(entries)
+	if len(undoable) != 4 || undoable[0].Operation != "CreateTicket" {
+		t.Fatalf("Expected 4 undoable changes starting with the create, got %+v", undoable)
+	}
+
+	for i := range undoable {
+		if err := Revert(base, &undoable[i]); err != nil {
+			t.Fatalf("Revert %s failed: %v", undoable[i].Operation, err)
+		}
+		if err := journal.RecordRevert(&undoable[i], "jira utils undo"); err != nil {
+			t.Fatalf("RecordRevert failed: %v", err)
+		}
+	}
+
+	if base.status != "New" || base.priority != "3" {
+		t.Errorf("Expected status New and priority 3 back, got %s and %s", base.status, base.priority)
+	}
+	if len(base.labels) != 1 || base.labels[0] != "backend" {
+		t.Errorf("Expected labels [backend] back, got %v", base.labels)
+	}
+	if len(base.deleted) != 1 || base.deleted[0] != "ENG-9" {
+		t.Errorf("Expected ENG-9 to be deleted, got %v", base.deleted)
+	}
+
+	entries, err = journal.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if remaining := Undoable(entries); len(remaining) != 0 {
+		t.Errorf("Expected nothing left to undo, got %+v", remaining)
+	}
+}
+
+func TestRevertWithoutPreviousValue(t *testing.T) {
+	entry := &JournalEntry{Operation: "UpdateTicketPriority", Ticket: "ENG-1"}
+	if err := Revert(&fakeTicketClient{}, entry); err == nil {
+		t.Error("Expected an error when the previous value wasn't recorded")
+	}
+
+	comment := &JournalEntry{Operation: "AddComment", Ticket: "ENG-1"}
+	if err := Revert(&fakeTicketClient{}, comment); err != ErrNotReversible {
+		t.Errorf("Expected ErrNotReversible for a comment, got %v", err)
+	}
+}
+
+func TestRestorableValue(t *testing.T) {
+	option := map[string]interface{}{"self": "https://jira/option/1", "id": "1", "value": "High"}
+	got, ok := restorableValue(option).(map[string]interface{})
+	if !ok || len(got) != 1 || got["id"] != "1" {
+		t.Errorf("Expected {id: 1}, got %v", got)
+	}
+
+	list, ok := restorableValue([]interface{}{option}).([]interface{})
+	if !ok || len(list) != 1 {
+		t.Fatalf("Expected a list of one, got %v", list)
+	}
+
+	if restorableValue("2024-01-31") != "2024-01-31" || restorableValue(nil) != nil {
+		t.Error("Expected plain values to be kept as-is")
+	}
+}
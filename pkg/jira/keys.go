@@ -0,0 +1,44 @@
+package jira
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ticketKeyPattern matches ticket keys such as ENG-123 that aren't part of a longer word
+var ticketKeyPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9])([A-Z][A-Z0-9_]+-[1-9][0-9]*)(?:$|[^A-Za-z0-9])`)
+
+// FindTicketKeys returns the ticket keys mentioned in text, in order, without repeats
+func FindTicketKeys(text string) []string {
+	keys := []string{}
+	seen := map[string]bool{}
+	// Matches can share the separator between two keys, so search again from each key's end
+	for rest := text; ; {
+		m := ticketKeyPattern.FindStringSubmatchIndex(rest)
+		if m == nil {
+			return keys
+		}
+		key := rest[m[2]:m[3]]
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+		rest = rest[m[3]:]
+	}
+}
+
+// FindTicketKey returns the first ticket key mentioned in text, or ""
+func FindTicketKey(text string) string {
+	if keys := FindTicketKeys(text); len(keys) > 0 {
+		return keys[0]
+	}
+	return ""
+}
+
+// ProjectKey returns the project part of a ticket key (e.g. "ENG" for "ENG-123")
+func ProjectKey(ticketKey string) string {
+	if i := strings.LastIndex(ticketKey, "-"); i > 0 {
+		return ticketKey[:i]
+	}
+	return ticketKey
+}
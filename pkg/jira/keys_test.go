@@ -0,0 +1,36 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindTicketKeys(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"feature/ENG-123-fix-login", "ENG-123"},
+		{"ENG-1 ENG-2,ENG-1 (OPS_2-7)", "ENG-1 ENG-2 OPS_2-7"},
+		{"Fixes ENG-12: crash", "ENG-12"},
+		{"bugfix/eng-123 XENG-12a ENG-0", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(FindTicketKeys(tt.text), " "); got != tt.want {
+			t.Errorf("FindTicketKeys(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+
+	if got := FindTicketKey("main"); got != "" {
+		t.Errorf("Expected no key in main, got %q", got)
+	}
+}
+
+func TestProjectKey(t *testing.T) {
+	for key, want := range map[string]string{"ENG-123": "ENG", "OPS_2-7": "OPS_2", "ENG": "ENG"} {
+		if got := ProjectKey(key); got != want {
+			t.Errorf("ProjectKey(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// AddLabels adds labels to a ticket, keeping its existing labels
+func (c *jiraClient) AddLabels(ticketID string, labels []string) error {
+	endpoint := fmt.Sprintf("%s/rest/api/2/issue/%s", c.baseURL, ticketID)
+
+	// Use the "update" form so existing labels are kept
+	operations := make([]map[string]interface{}, len(labels))
+	for i, label := range labels {
+		operations[i] = map[string]interface{}{"add": label}
+	}
+	payload := map[string]interface{}{
+		"update": map[string]interface{}{
+			"labels": operations,
+		},
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("PUT", endpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	c.setAuth(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if resp.StatusCode == 401 || resp.StatusCode == 403 {
+			return fmt.Errorf("authentication failed. Your Jira token may be invalid. Please run 'jira init'")
+		}
+		if resp.StatusCode == 404 {
+			return fmt.Errorf("ticket %s not found", ticketID)
+		}
+		body, readErr := io.ReadAll(resp.Body)
+		if readErr != nil {
+			return fmt.Errorf("Jira API returned error: %d %s (failed to read body: %w)", resp.StatusCode, resp.Status, readErr)
+		}
+		return fmt.Errorf("Jira API returned error: %d %s - %s", resp.StatusCode, resp.Status, string(body))
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package jira
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAddLabels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/rest/api/2/issue/ENG-123" {
+			t.Errorf("expected PUT /rest/api/2/issue/ENG-123, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var payload map[string]map[string][]map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode payload: %v", err)
+		}
+		ops := payload["update"]["labels"]
+		if len(ops) != 2 || ops[0]["add"] != "crash" || ops[1]["add"] != "customer" {
+			t.Errorf("expected add operations for both labels, got %v", ops)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := &jiraClient{baseURL: server.URL, httpClient: &http.Client{}, authToken: "test-token"}
+
+	if err := client.AddLabels("ENG-123", []string{"crash", "customer"}); err != nil {
+		t.Fatalf("AddLabels failed: %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Remote link states for pull/merge requests
+const (
+	RemoteLinkOpen   = "open"
+	RemoteLinkMerged = "merged"
+	RemoteLinkClosed = "closed"
+)
+
+// RemoteLinkIcon is an icon shown beside a remote link in Jira
+type RemoteLinkIcon struct {
+	URL   string `json:"url16x16,omitempty"`
+	Title string `json:"title,omitempty"`
+}
+
+// RemoteLinkStatus is the state of the linked object, e.g. a merged pull request is resolved
+type RemoteLinkStatus struct {
+	Resolved bool           `json:"resolved"`
+	Icon     RemoteLinkIcon `json:"icon"`
+}
+
+// RemoteLinkObject is the linked object: a pull request, a commit or any other URL
+type RemoteLinkObject struct {
+	URL     string            `json:"url"`
+	Title   string            `json:"title"`
+	Summary string            `json:"summary,omitempty"`
+	Icon    RemoteLinkIcon    `json:"icon"`
+	Status  *RemoteLinkStatus `json:"status,omitempty"`
+}
+
+// RemoteLink is a link from a ticket to something outside Jira
+// GlobalID identifies the link, so adding a link with the same GlobalID again updates it
+type RemoteLink struct {
+	ID       int              `json:"id,omitempty"`
+	GlobalID string           `json:"globalId,omitempty"`
+	Object   RemoteLinkObject `json:"object"`
+}
+
+// State returns the state shown for the link: the status icon's title, "resolved", or "" if it has no status
+func (l *RemoteLink) State() string {
+	if l.Object.Status == nil {
+		return ""
+	}
+	if l.Object.Status.Icon.Title != "" {
+		return l.Object.Status.Icon.Title
+	}
+	if l.Object.Status.Resolved {
+		return "resolved"
+	}
+	return ""
+}
+
+// NewRemoteLink describes a link to a pull/merge request, commit or other URL, with a title
+// and icon worked out from the URL when title is empty. state is one of the RemoteLink states
+// for pull requests, or empty for no status.
+func NewRemoteLink(linkURL, title, state string) (*RemoteLink, error) {
+	parsed, err := url.Parse(linkURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return nil, fmt.Errorf("invalid URL %q", linkURL)
+	}
+
+	kind, name := describeLinkURL(parsed)
+	if title == "" {
+		title = name
+	}
+	link := &RemoteLink{
+		GlobalID: linkURL,
+		Object: RemoteLinkObject{
+			URL:   linkURL,
+			Title: title,
+			Icon: RemoteLinkIcon{
+				URL:   parsed.Scheme + "://" + parsed.Host + "/favicon.ico",
+				Title: kind,
+			},
+		},
+	}
+
+	switch state {
+	case "":
+	case RemoteLinkOpen, RemoteLinkMerged, RemoteLinkClosed:
+		link.Object.Status = &RemoteLinkStatus{
+			Resolved: state != RemoteLinkOpen,
+			Icon:     RemoteLinkIcon{Title: state},
+		}
+	default:
+		return nil, fmt.Errorf("unknown state %q (expected open, merged or closed)", state)
+	}
+	return link, nil
+}
+
+// describeLinkURL returns the kind of object a URL is and a short name for it, e.g.
+// "Pull request" and "org/repo#12" for https://github.com/org/repo/pull/12
+func describeLinkURL(u *url.URL) (kind, name string) {
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 2; i+1 < len(parts); i++ {
+		repo := strings.Join(parts[:i], "/")
+		switch parts[i] {
+		case "pull", "pulls", "pull-requests": // GitHub, Gitea, Bitbucket
+			return "Pull request", repo + "#" + parts[i+1]
+		case "merge_requests": // GitLab, after a "-" path segment
+			return "Merge request", strings.TrimSuffix(repo, "/-") + "!" + parts[i+1]
+		case "commit", "commits":
+			hash := parts[i+1]
+			if len(hash) > 7 {
+				hash = hash[:7]
+			}
+			return "Commit", strings.TrimSuffix(repo, "/-") + "@" + hash
+		}
+	}
+	return "Link", u.Host + u.Path
+}
+
+// GetRemoteLinks returns the links from a ticket to things outside Jira
+func (c *jiraClient) GetRemoteLinks(ticketID string) ([]RemoteLink, error) {
+	endpoint := fmt.Sprintf("%s/rest/api/2/issue/%s/remotelink", c.baseURL, ticketID)
+
+	req, err := http.NewRequest("GET", endpoint, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	c.setAuth(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if resp.StatusCode == 401 || resp.StatusCode == 403 {
+			return nil, fmt.Errorf("authentication failed. Your Jira token may be invalid. Please run 'jira init'")
+		}
+		if resp.StatusCode == 404 {
+			return nil, fmt.Errorf("ticket %s not found", ticketID)
+		}
+		return nil, fmt.Errorf("Jira API returned error: %d %s", resp.StatusCode, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var links []RemoteLink
+	if err := json.Unmarshal(body, &links); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return links, nil
+}
+
+// AddRemoteLink links a ticket to something outside Jira, or updates the ticket's link with the same GlobalID
+func (c *jiraClient) AddRemoteLink(ticketID string, link *RemoteLink) error {
+	endpoint := fmt.Sprintf("%s/rest/api/2/issue/%s/remotelink", c.baseURL, ticketID)
+	payload := map[string]interface{}{"object": link.Object}
+	if link.GlobalID != "" {
+		payload["globalId"] = link.GlobalID
+	}
+
+	return c.sendJSON("POST", endpoint, payload, ticketID)
+}
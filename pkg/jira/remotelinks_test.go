@@ -0,0 +1,92 @@
+package jira
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewRemoteLink(t *testing.T) {
+	tests := []struct {
+		url, state       string
+		wantTitle, kind  string
+		resolved, status bool
+	}{
+		{"https://github.com/org/repo/pull/12", "merged", "org/repo#12", "Pull request", true, true},
+		{"https://gitlab.com/group/sub/project/-/merge_requests/5", "open", "group/sub/project!5", "Merge request", false, true},
+		{"https://github.com/org/repo/commit/0123456789abcdef", "", "org/repo@0123456", "Commit", false, false},
+		{"https://example.com/docs/design", "", "example.com/docs/design", "Link", false, false},
+	}
+	for _, tt := range tests {
+		link, err := NewRemoteLink(tt.url, "", tt.state)
+		if err != nil {
+			t.Fatalf("NewRemoteLink(%q) failed: %v", tt.url, err)
+		}
+		if link.Object.Title != tt.wantTitle || link.Object.Icon.Title != tt.kind || link.GlobalID != tt.url {
+			t.Errorf("NewRemoteLink(%q) = %+v, want title %q and kind %q", tt.url, link, tt.wantTitle, tt.kind)
+		}
+		if (link.Object.Status != nil) != tt.status || (tt.status && link.Object.Status.Resolved != tt.resolved) {
+			t.Errorf("NewRemoteLink(%q) status = %+v", tt.url, link.Object.Status)
+		}
+		if tt.status && link.State() != tt.state {
+			t.Errorf("Expected state %q, got %q", tt.state, link.State())
+		}
+	}
+
+	if link, _ := NewRemoteLink("https://github.com/org/repo/pull/1", "Fix crash", ""); link.Object.Title != "Fix crash" {
+		t.Errorf("Expected the given title, got %q", link.Object.Title)
+	}
+	if _, err := NewRemoteLink("github.com/org/repo", "", ""); err == nil {
+		t.Error("Expected an error for a URL without a scheme")
+	}
+	if _, err := NewRemoteLink("https://github.com/org/repo/pull/1", "", "draft"); err == nil {
+		t.Error("Expected an error for an unknown state")
+	}
+}
+
+func TestRemoteLinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/issue/ENG-1/remotelink" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		switch r.Method {
+		case "GET":
+			_, _ = w.Write([]byte(`[{"id": 10, "globalId": "https://github.com/org/repo/pull/3",
+				"object": {"url": "https://github.com/org/repo/pull/3", "title": "org/repo#3",
+				"status": {"resolved": true, "icon": {"title": "merged"}}}}]`))
+		case "POST":
+			var payload struct {
+				GlobalID string           `json:"globalId"`
+				Object   RemoteLinkObject `json:"object"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("failed to decode payload: %v", err)
+			}
+			if payload.GlobalID != "https://github.com/org/repo/pull/4" || payload.Object.Title != "org/repo#4" ||
+				payload.Object.Icon.URL != "https://github.com/favicon.ico" {
+				t.Errorf("unexpected remote link payload: %+v", payload)
+			}
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+
+	client := &jiraClient{baseURL: server.URL, httpClient: &http.Client{}, authToken: "test-token"}
+
+	links, err := client.GetRemoteLinks("ENG-1")
+	if err != nil {
+		t.Fatalf("GetRemoteLinks failed: %v", err)
+	}
+	if len(links) != 1 || links[0].Object.Title != "org/repo#3" || links[0].State() != "merged" {
+		t.Errorf("unexpected links: %+v", links)
+	}
+
+	link, err := NewRemoteLink("https://github.com/org/repo/pull/4", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AddRemoteLink("ENG-1", link); err != nil {
+		t.Fatalf("AddRemoteLink failed: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Status category keys; Jira puts every status, whatever its name, in one of these
+const (
+	StatusCategoryToDo       = "new"
+	StatusCategoryInProgress = "indeterminate"
+	StatusCategoryDone       = "done"
+)
+
+// StatusCategories maps status names to the key of their category
+type StatusCategories map[string]string
+
+// Category returns the category key of a status, or "" if it isn't known
+func (s StatusCategories) Category(status string) string {
+	for name, category := range s {
+		if strings.EqualFold(name, status) {
+			return category
+		}
+	}
+	return ""
+}
+
+// IsDone reports whether an issue's status is in the done category
+func IsDone(issue *Issue) bool {
+	return IsDoneCategory(issue.Fields.Status.StatusCategory.Key)
+}
+
+// IsDoneCategory reports whether a status category key means the work is finished
+func IsDoneCategory(category string) bool {
+	return category == StatusCategoryDone
+}
+
+// GetStatusCategories returns the category of every status in Jira
+func (c *jiraClient) GetStatusCategories() (StatusCategories, error) {
+	endpoint := fmt.Sprintf("%s/rest/api/2/status", c.baseURL)
+
+	req, err := http.NewRequest("GET", endpoint, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	c.setAuth(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if resp.StatusCode == 401 || resp.StatusCode == 403 {
+			return nil, fmt.Errorf("authentication failed. Your Jira token may be invalid. Please run 'jira init'")
+		}
+		return nil, fmt.Errorf("Jira API returned error: %d %s", resp.StatusCode, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var statuses []struct {
+		Name           string `json:"name"`
+		StatusCategory struct {
+			Key string `json:"key"`
+		} `json:"statusCategory"`
+	}
+	if err := json.Unmarshal(body, &statuses); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	categories := StatusCategories{}
+	for _, status := range statuses {
+		categories[status.Name] = status.StatusCategory.Key
+	}
+	return categories, nil
+}
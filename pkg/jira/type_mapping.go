@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/prompt"
 )
 
 // getDefaultChildType returns the default child type for a parent type
@@ -30,13 +31,11 @@ func GetChildTicketType(parentType string, reader *bufio.Reader, _ *config.Confi
 
 	// Unknown type - prompt user
 	fmt.Printf("Parent ticket type \"%s\" has no default child type mapping.\n", parentType)
-	fmt.Print("What type should child tickets be? [Task/Story/Sub-task/Other]: ")
-
-	choice, err := reader.ReadString('\n')
+	choice, err := prompt.Line(reader, "child_type", "What type should child tickets be? [Task/Story/Sub-task/Other]: ")
 	if err != nil {
 		return "", err
 	}
-	choice = strings.TrimSpace(strings.ToLower(choice))
+	choice = strings.ToLower(choice)
 
 	switch choice {
 	case "task":
@@ -46,12 +45,7 @@ func GetChildTicketType(parentType string, reader *bufio.Reader, _ *config.Confi
 	case "sub-task", "subtask":
 		return "Sub-task", nil
 	case "other":
-		fmt.Print("Enter custom ticket type: ")
-		customType, err := reader.ReadString('\n')
-		if err != nil {
-			return "", err
-		}
-		return strings.TrimSpace(customType), nil
+		return prompt.Line(reader, "custom_child_type", "Enter custom ticket type: ")
 	default:
 		// Default to Task if invalid input
 		fmt.Printf("Invalid choice, defaulting to Task\n")
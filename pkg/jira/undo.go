@@ -0,0 +1,198 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// ErrNotReversible is returned by Revert for changes that can't be taken back
+var ErrNotReversible = errors.New("this change can't be undone")
+
+// revertible lists the operations Revert knows how to undo
+var revertible = map[string]bool{
+	"UpdateTicketPoints":       true,
+	"UpdateTicketDescription":  true,
+	"UpdateTicketPriority":     true,
+	"CreateTicket":             true,
+	"CreateTicketWithParent":   true,
+	"CreateTicketWithEpicLink": true,
+	"AssignTicket":             true,
+	"UnassignTicket":           true,
+	"TransitionTicket":         true,
+	"AddIssuesToRelease":       true,
+	"UpdateTicketComponents":   true,
+	"UpdateTicketSeverity":     true,
+	"AddLabels":                true,
+	"UpdateTicketFields":       true,
+}
+
+// CanRevert checks if an entry is a change Revert can undo
+// Comments, links, sprint moves and deletions can't be undone, nor can undos themselves
+func CanRevert(entry *JournalEntry) bool {
+	return entry.Reverts == "" && revertible[entry.Operation]
+}
+
+// Undoable returns the entries that can still be undone, most recent first
+func Undoable(entries []JournalEntry) []JournalEntry {
+	undone := UndoneIDs(entries)
+	result := []JournalEntry{}
+	for i := len(entries) - 1; i >= 0; i-- {
+		if CanRevert(&entries[i]) && !undone[entries[i].ID] {
+			result = append(result, entries[i])
+		}
+	}
+	return result
+}
+
+// RecordRevert journals that entry was undone, so it isn't offered for undo again
+func (j *Journal) RecordRevert(entry *JournalEntry, command string) error {
+	return j.Append(&JournalEntry{
+		Timestamp: time.Now(),
+		Command:   command,
+		Operation: "Undo" + entry.Operation,
+		Ticket:    entry.Ticket,
+		Before:    entry.After,
+		After:     entry.Before,
+		Reverts:   entry.ID,
+	})
+}
+
+// Revert undoes a journaled change by writing back the values recorded before it
+// Transitions are undone with a transition back to the previous status, if the workflow has one,
+// and created tickets are deleted
+func Revert(client JiraClient, entry *JournalEntry) error {
+	if !CanRevert(entry) {
+		return ErrNotReversible
+	}
+
+	switch entry.Operation {
+	case "CreateTicket", "CreateTicketWithParent", "CreateTicketWithEpicLink":
+		return client.DeleteTicket(entry.Ticket)
+	}
+
+	if entry.Before == nil {
+		return fmt.Errorf("the previous value wasn't recorded, so %s on %s can't be undone",
+			entry.Operation, entry.Ticket)
+	}
+
+	switch entry.Operation {
+	case "UpdateTicketPoints":
+		points, _ := entry.Before["story_points"].(float64)
+		return client.UpdateTicketPoints(entry.Ticket, int(math.Round(points)))
+	case "UpdateTicketDescription":
+		return client.UpdateTicketDescription(entry.Ticket, stringValue(entry.Before["description"]))
+	case "UpdateTicketPriority":
+		priorityID := stringValue(entry.Before["priority_id"])
+		if priorityID == "" {
+			return fmt.Errorf("%s had no priority before, and Jira can't clear it", entry.Ticket)
+		}
+		return client.UpdateTicketPriority(entry.Ticket, priorityID)
+	case "AssignTicket", "UnassignTicket":
+		accountID := stringValue(entry.Before["account_id"])
+		user := stringValue(entry.Before["user"])
+		if accountID == "" && user == "" {
+			return client.UnassignTicket(entry.Ticket)
+		}
+		return client.AssignTicket(entry.Ticket, accountID, user)
+	case "TransitionTicket":
+		return revertTransition(client, entry)
+	case "AddIssuesToRelease":
+		return client.UpdateTicketFields(entry.Ticket,
+			map[string]interface{}{"fixVersions": idObjects(stringList(entry.Before["fix_versions"]))})
+	case "UpdateTicketComponents":
+		return client.UpdateTicketComponents(entry.Ticket, stringList(entry.Before["component_ids"]))
+	case "UpdateTicketSeverity":
+		fieldID := stringValue(entry.After["field"])
+		severity := stringValue(entry.Before["severity"])
+		if severity == "" {
+			return client.UpdateTicketFields(entry.Ticket, map[string]interface{}{fieldID: nil})
+		}
+		return client.UpdateTicketSeverity(entry.Ticket, fieldID, severity)
+	case "AddLabels":
+		return client.UpdateTicketFields(entry.Ticket,
+			map[string]interface{}{"labels": stringList(entry.Before["labels"])})
+	default: // UpdateTicketFields
+		fields := map[string]interface{}{}
+		for id, value := range entry.Before {
+			fields[id] = restorableValue(value)
+		}
+		return client.UpdateTicketFields(entry.Ticket, fields)
+	}
+}
+
+// revertTransition moves a ticket back to the status it had before a transition
+func revertTransition(client JiraClient, entry *JournalEntry) error {
+	status := stringValue(entry.Before["status"])
+	if status == "" {
+		return fmt.Errorf("the previous status of %s wasn't recorded", entry.Ticket)
+	}
+
+	transitions, err := client.GetTransitions(entry.Ticket)
+	if err != nil {
+		return fmt.Errorf("failed to get transitions: %w", err)
+	}
+	for _, t := range transitions {
+		if strings.EqualFold(t.To.Name, status) {
+			return client.TransitionTicket(entry.Ticket, t.ID)
+		}
+	}
+	return fmt.Errorf("the workflow has no transition from the current status of %s back to %q", entry.Ticket, status)
+}
+
+// restorableValue turns a field value as Jira returns it into one it accepts in an update,
+// e.g. a full option object {"self": ..., "id": "1", "value": "High"} becomes {"id": "1"}
+func restorableValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range []string{"id", "accountId", "name", "key", "value"} {
+			if ref, ok := v[key]; ok {
+				return map[string]interface{}{key: ref}
+			}
+		}
+		return v
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = restorableValue(item)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+// stringValue returns a recorded value as a string, or "" if it isn't one
+func stringValue(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
+
+// stringList returns a recorded list of strings; lists read back from the journal are []interface{}
+func stringList(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		result := []string{}
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return []string{}
+	}
+}
+
+// idObjects turns IDs into the {"id": ...} objects Jira expects for list fields
+func idObjects(ids []string) []map[string]string {
+	result := make([]map[string]string, len(ids))
+	for i, id := range ids {
+		result[i] = map[string]string{"id": id}
+	}
+	return result
+}
@@ -0,0 +1,190 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is how serious a rule violation is
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+	SeverityOff     Severity = "off" // Rule disabled
+)
+
+// rank orders severities so thresholds can be compared; off ranks lowest
+func (s Severity) rank() int {
+	switch s {
+	case SeverityError:
+		return 3
+	case SeverityWarning:
+		return 2
+	case SeverityInfo:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// AtLeast reports whether s is as serious as threshold
+func (s Severity) AtLeast(threshold Severity) bool {
+	return s != SeverityOff && s.rank() >= threshold.rank()
+}
+
+// ParseSeverity parses a severity name (case-insensitive)
+func ParseSeverity(name string) (Severity, error) {
+	switch s := Severity(strings.ToLower(strings.TrimSpace(name))); s {
+	case SeverityError, SeverityWarning, SeverityInfo, SeverityOff:
+		return s, nil
+	default:
+		return "", fmt.Errorf("invalid severity %q (expected error, warning, info or off)", name)
+	}
+}
+
+// RuleConfig configures a single rule
+// Zero values inherit from the less specific level (built-in defaults < rules file < project override)
+type RuleConfig struct {
+	Severity Severity `yaml:"severity,omitempty"`
+	// MinLength is the minimum description length (description rule only, default: description_min_length)
+	MinLength int `yaml:"min_length,omitempty"`
+	// IssueTypes limits the rule to these issue types
+	IssueTypes []string `yaml:"issue_types,omitempty"`
+	// ExcludeIssueTypes skips the rule for these issue types
+	ExcludeIssueTypes []string `yaml:"exclude_issue_types,omitempty"`
+}
+
+// merge returns c with the non-zero fields of override applied
+func (c RuleConfig) merge(override RuleConfig) RuleConfig {
+	if override.Severity != "" {
+		c.Severity = override.Severity
+	}
+	if override.MinLength != 0 {
+		c.MinLength = override.MinLength
+	}
+	if override.IssueTypes != nil {
+		c.IssueTypes = override.IssueTypes
+	}
+	if override.ExcludeIssueTypes != nil {
+		c.ExcludeIssueTypes = override.ExcludeIssueTypes
+	}
+	return c
+}
+
+// AppliesTo reports whether the rule should run for an issue type
+func (c RuleConfig) AppliesTo(issueType string) bool {
+	for _, t := range c.ExcludeIssueTypes {
+		if strings.EqualFold(t, issueType) {
+			return false
+		}
+	}
+	if len(c.IssueTypes) == 0 {
+		return true
+	}
+	for _, t := range c.IssueTypes {
+		if strings.EqualFold(t, issueType) {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectRules holds rule overrides for one project
+type ProjectRules struct {
+	Rules map[string]RuleConfig `yaml:"rules,omitempty"`
+}
+
+// RuleSet is the lint configuration loaded from the rules file
+type RuleSet struct {
+	Rules    map[string]RuleConfig   `yaml:"rules,omitempty"`
+	Projects map[string]ProjectRules `yaml:"projects,omitempty"`
+}
+
+// GetRulesPath returns the path for the lint rules file
+// If configDir is empty, uses the default ~/.jira-tool
+func GetRulesPath(configDir string) string {
+	if configDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "./.jira-tool/lint.yaml"
+		}
+		configDir = filepath.Join(homeDir, ".jira-tool")
+	}
+	return filepath.Join(configDir, "lint.yaml")
+}
+
+// LoadRuleSet loads the lint rules file from path
+// Returns an empty rule set (built-in defaults only) if the file doesn't exist
+func LoadRuleSet(path string) (*RuleSet, error) {
+	ruleSet := &RuleSet{}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ruleSet, nil
+		}
+		return nil, fmt.Errorf("failed to read lint rules: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, ruleSet); err != nil {
+		return nil, fmt.Errorf("failed to parse lint rules: %w", err)
+	}
+
+	if err := ruleSet.validate(); err != nil {
+		return nil, fmt.Errorf("invalid lint rules in %s: %w", path, err)
+	}
+
+	return ruleSet, nil
+}
+
+// validate checks that rule IDs and severities are known
+func (rs *RuleSet) validate() error {
+	check := func(rules map[string]RuleConfig, where string) error {
+		for id, rc := range rules {
+			if findRule(id) == nil {
+				return fmt.Errorf("unknown rule %q%s", id, where)
+			}
+			if rc.Severity != "" {
+				if _, err := ParseSeverity(string(rc.Severity)); err != nil {
+					return fmt.Errorf("rule %q%s: %w", id, where, err)
+				}
+			}
+		}
+		return nil
+	}
+
+	if err := check(rs.Rules, ""); err != nil {
+		return err
+	}
+	for project, pr := range rs.Projects {
+		if err := check(pr.Rules, fmt.Sprintf(" (project %s)", project)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Effective returns the configuration of a rule for a project, applying the
+// built-in defaults, then the rules file, then the project's overrides
+func (rs *RuleSet) Effective(rule *Rule, project string) RuleConfig {
+	rc := rule.Defaults
+	if rs == nil {
+		return rc
+	}
+	if override, ok := rs.Rules[rule.ID]; ok {
+		rc = rc.merge(override)
+	}
+	if pr, ok := rs.Projects[project]; ok {
+		if override, ok := pr.Rules[rule.ID]; ok {
+			rc = rc.merge(override)
+		}
+	}
+	rc.Severity = Severity(strings.ToLower(string(rc.Severity)))
+	return rc
+}
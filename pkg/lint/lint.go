@@ -0,0 +1,334 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/gemini"
+	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/review"
+)
+
+// Rule is a ticket hygiene check
+type Rule struct {
+	ID          string
+	Description string
+	Defaults    RuleConfig
+	// Check returns a message describing the violation, or an empty string if the issue passes
+	Check func(ctx *Context, issue *jira.Issue, rc RuleConfig) (string, error)
+}
+
+// Rules are the built-in rules, in the order they are reported
+var Rules = []*Rule{
+	{
+		ID:          "description",
+		Description: "Description is missing or shorter than min_length",
+		Defaults:    RuleConfig{Severity: SeverityError},
+		Check:       checkDescription,
+	},
+	{
+		ID:          "component",
+		Description: "No component set",
+		Defaults:    RuleConfig{Severity: SeverityWarning, ExcludeIssueTypes: []string{"Epic"}},
+		Check:       checkComponent,
+	},
+	{
+		ID:          "story_points",
+		Description: "No story points set",
+		Defaults:    RuleConfig{Severity: SeverityWarning, ExcludeIssueTypes: []string{"Epic", "Sub-task"}},
+		Check:       checkStoryPoints,
+	},
+	{
+		ID:          "unassigned_in_sprint",
+		Description: "In an active sprint but unassigned",
+		Defaults:    RuleConfig{Severity: SeverityError},
+		Check:       checkUnassignedInSprint,
+	},
+	{
+		ID:          "epic_unestimated_children",
+		Description: "Epic has open children without story points",
+		Defaults:    RuleConfig{Severity: SeverityWarning},
+		Check:       checkEpicUnestimatedChildren,
+	},
+	{
+		ID:          "spike_without_timebox",
+		Description: "Spike has no timebox (story points, due date, or a timebox in the description)",
+		Defaults:    RuleConfig{Severity: SeverityWarning},
+		Check:       checkSpikeTimebox,
+	},
+	{
+		ID:          "closed_parent_open_children",
+		Description: "Closed ticket still has open children",
+		Defaults:    RuleConfig{Severity: SeverityError},
+		Check:       checkClosedParent,
+	},
+}
+
+// findRule returns the built-in rule with the given ID, or nil
+func findRule(id string) *Rule {
+	for _, r := range Rules {
+		if r.ID == id {
+			return r
+		}
+	}
+	return nil
+}
+
+// Context holds the clients and per-run caches shared by the rules
+type Context struct {
+	Client jira.JiraClient
+	Config *config.Config
+
+	keys         []string // All issues being linted, for batched lookups
+	sprintKeys   map[string]bool
+	descriptions map[string]string
+	children     map[string][]jira.Issue
+}
+
+// NewContext creates a lint context for a set of issues
+func NewContext(client jira.JiraClient, cfg *config.Config, issues []jira.Issue) *Context {
+	keys := make([]string, len(issues))
+	for i := range issues {
+		keys[i] = issues[i].Key
+	}
+	return &Context{
+		Client:       client,
+		Config:       cfg,
+		keys:         keys,
+		descriptions: map[string]string{},
+		children:     map[string][]jira.Issue{},
+	}
+}
+
+// description returns an issue's description, fetching it once per run
+func (ctx *Context) description(key string) string {
+	if desc, ok := ctx.descriptions[key]; ok {
+		return desc
+	}
+	desc, err := ctx.Client.GetTicketDescription(key)
+	if err != nil {
+		desc = "" // Treat an unreadable description as missing
+	}
+	ctx.descriptions[key] = desc
+	return desc
+}
+
+// childIssues returns an issue's children, fetching them once per run
+func (ctx *Context) childIssues(issue *jira.Issue) []jira.Issue {
+	if children, ok := ctx.children[issue.Key]; ok {
+		return children
+	}
+	children := jira.GetChildIssues(ctx.Client, issue, ctx.Config.EpicLinkFieldID)
+	ctx.children[issue.Key] = children
+	return children
+}
+
+// sprintBatchSize limits the number of keys in a single JQL query
+const sprintBatchSize = 100
+
+// inActiveSprint reports whether an issue is in an open sprint
+// Membership is looked up for all linted issues at once, on first use
+func (ctx *Context) inActiveSprint(key string) (bool, error) {
+	if ctx.sprintKeys == nil {
+		sprintKeys := map[string]bool{}
+		for start := 0; start < len(ctx.keys); start += sprintBatchSize {
+			end := start + sprintBatchSize
+			if end > len(ctx.keys) {
+				end = len(ctx.keys)
+			}
+			jql := fmt.Sprintf("key in (%s) AND sprint in openSprints()", strings.Join(ctx.keys[start:end], ", "))
+			issues, err := ctx.Client.SearchTickets(jql)
+			if err != nil {
+				return false, fmt.Errorf("failed to look up active sprints: %w", err)
+			}
+			for i := range issues {
+				sprintKeys[issues[i].Key] = true
+			}
+		}
+		ctx.sprintKeys = sprintKeys
+	}
+	return ctx.sprintKeys[key], nil
+}
+
+func checkDescription(ctx *Context, issue *jira.Issue, rc RuleConfig) (string, error) {
+	description := ctx.description(issue.Key)
+	if strings.TrimSpace(description) == "" {
+		return "no description", nil
+	}
+
+	minLength := rc.MinLength
+	if minLength == 0 {
+		minLength = ctx.Config.DescriptionMinLength
+	}
+	if reason := review.DescriptionLengthIssue(description, minLength); reason != "" {
+		return "description " + reason, nil
+	}
+	return "", nil
+}
+
+func checkComponent(_ *Context, issue *jira.Issue, _ RuleConfig) (string, error) {
+	if review.HasComponent(issue) {
+		return "", nil
+	}
+	return "no component", nil
+}
+
+func checkStoryPoints(_ *Context, issue *jira.Issue, _ RuleConfig) (string, error) {
+	if review.HasStoryPoints(issue) || jira.IsDone(issue) {
+		return "", nil
+	}
+	return "no story points", nil
+}
+
+func checkUnassignedInSprint(ctx *Context, issue *jira.Issue, _ RuleConfig) (string, error) {
+	if review.IsAssigned(issue) || jira.IsDone(issue) {
+		return "", nil
+	}
+	inSprint, err := ctx.inActiveSprint(issue.Key)
+	if err != nil || !inSprint {
+		return "", err
+	}
+	return "unassigned in an active sprint", nil
+}
+
+func checkEpicUnestimatedChildren(ctx *Context, issue *jira.Issue, _ RuleConfig) (string, error) {
+	if !jira.IsEpic(issue) || jira.IsDone(issue) {
+		return "", nil
+	}
+
+	var unestimated []string
+	children := ctx.childIssues(issue)
+	for i := range children {
+		if !review.HasStoryPoints(&children[i]) && !jira.IsDone(&children[i]) {
+			unestimated = append(unestimated, children[i].Key)
+		}
+	}
+	if len(unestimated) == 0 {
+		return "", nil
+	}
+	return fmt.Sprintf("%d unestimated children: %s", len(unestimated), formatKeys(unestimated)), nil
+}
+
+// timeboxPattern matches a timebox mentioned in a description, e.g. "Timebox: 3 days"
+var timeboxPattern = regexp.MustCompile(`(?i)time[- ]?box`)
+
+func checkSpikeTimebox(ctx *Context, issue *jira.Issue, _ RuleConfig) (string, error) {
+	if !gemini.IsSpike(issue.Fields.Summary, issue.Key) || jira.IsDone(issue) {
+		return "", nil
+	}
+	if review.HasStoryPoints(issue) || timeboxPattern.MatchString(ctx.description(issue.Key)) {
+		return "", nil
+	}
+
+	// Fall back to the due date, which isn't part of the search results
+	if raw, err := ctx.Client.GetTicketRaw(issue.Key); err == nil {
+		if fields, ok := raw["fields"].(map[string]interface{}); ok {
+			if due, ok := fields["duedate"].(string); ok && due != "" {
+				return "", nil
+			}
+		}
+	}
+	return "spike has no timebox", nil
+}
+
+func checkClosedParent(ctx *Context, issue *jira.Issue, _ RuleConfig) (string, error) {
+	if !jira.IsDone(issue) {
+		return "", nil
+	}
+
+	var open []string
+	children := ctx.childIssues(issue)
+	for i := range children {
+		if !jira.IsDone(&children[i]) {
+			open = append(open, children[i].Key)
+		}
+	}
+	if len(open) == 0 {
+		return "", nil
+	}
+	return fmt.Sprintf("%s with %d open children: %s", issue.Fields.Status.Name, len(open), formatKeys(open)), nil
+}
+
+// maxListedKeys limits how many ticket keys are listed in a message
+const maxListedKeys = 5
+
+func formatKeys(keys []string) string {
+	if len(keys) <= maxListedKeys {
+		return strings.Join(keys, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(keys[:maxListedKeys], ", "), len(keys)-maxListedKeys)
+}
+
+// Finding is a single rule violation
+type Finding struct {
+	Key      string   `json:"key"`
+	Summary  string   `json:"summary"`
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// Result is the outcome of linting a set of issues
+type Result struct {
+	Checked  int       `json:"checked"`
+	Findings []Finding `json:"findings"`
+}
+
+// Count returns the number of findings with the given severity
+func (r *Result) Count(severity Severity) int {
+	count := 0
+	for _, f := range r.Findings {
+		if f.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// Failed reports whether any finding is at least as serious as threshold
+func (r *Result) Failed(threshold Severity) bool {
+	for _, f := range r.Findings {
+		if f.Severity.AtLeast(threshold) {
+			return true
+		}
+	}
+	return false
+}
+
+// Run checks every issue against the enabled rules
+// Findings are in the order of the issues, then the order of the rules
+func Run(ctx *Context, ruleSet *RuleSet, issues []jira.Issue) (*Result, error) {
+	result := &Result{Checked: len(issues), Findings: []Finding{}}
+
+	for i := range issues {
+		issue := &issues[i]
+		project := jira.ProjectKey(issue.Key)
+
+		for _, rule := range Rules {
+			rc := ruleSet.Effective(rule, project)
+			if rc.Severity == SeverityOff || !rc.AppliesTo(issue.Fields.IssueType.Name) {
+				continue
+			}
+
+			message, err := rule.Check(ctx, issue, rc)
+			if err != nil {
+				return nil, fmt.Errorf("rule %s failed on %s: %w", rule.ID, issue.Key, err)
+			}
+			if message == "" {
+				continue
+			}
+
+			result.Findings = append(result.Findings, Finding{
+				Key:      issue.Key,
+				Summary:  issue.Fields.Summary,
+				Rule:     rule.ID,
+				Severity: rc.Severity,
+				Message:  message,
+			})
+		}
+	}
+
+	return result, nil
+}
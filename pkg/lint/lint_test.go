@@ -0,0 +1,182 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+// fakeClient answers searches and descriptions from fixed data; other methods are not used
+type fakeClient struct {
+	jira.JiraClient
+	descriptions map[string]string
+	searches     map[string][]jira.Issue // Keyed by a substring of the JQL
+}
+
+func (c *fakeClient) GetTicketDescription(key string) (string, error) {
+	return c.descriptions[key], nil
+}
+
+func (c *fakeClient) SearchTickets(jql string) ([]jira.Issue, error) {
+	for fragment, issues := range c.searches {
+		if strings.Contains(jql, fragment) {
+			return issues, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *fakeClient) GetTicketRaw(_ string) (map[string]interface{}, error) {
+	return map[string]interface{}{"fields": map[string]interface{}{}}, nil
+}
+
+func newIssue(key, summary, issueType, status string, points float64) jira.Issue {
+	issue := jira.Issue{Key: key}
+	issue.Fields.Summary = summary
+	issue.Fields.IssueType.Name = issueType
+	issue.Fields.Status.Name = status
+	issue.Fields.Status.StatusCategory.Key = testStatusCategories[status]
+	issue.Fields.StoryPoints = points
+	return issue
+}
+
+// testStatusCategories are the categories Jira would return with the statuses used in tests
+var testStatusCategories = map[string]string{
+	"To Do": jira.StatusCategoryToDo, "In Progress": jira.StatusCategoryInProgress, "Done": jira.StatusCategoryDone,
+}
+
+func withComponent(issue jira.Issue) jira.Issue {
+	issue.Fields.Components = append(issue.Fields.Components, struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}{ID: "1", Name: "API"})
+	return issue
+}
+
+func findingsFor(result *Result, key string) map[string]Severity {
+	rules := map[string]Severity{}
+	for _, f := range result.Findings {
+		if f.Key == key {
+			rules[f.Rule] = f.Severity
+		}
+	}
+	return rules
+}
+
+func TestRun(t *testing.T) {
+	longDesc := strings.Repeat("x", 50)
+	issues := []jira.Issue{
+		withComponent(newIssue("ENG-1", "Ready story", "Story", "To Do", 3)),
+		newIssue("ENG-2", "Bare story", "Story", "In Progress", 0),
+		withComponent(newIssue("ENG-3", "SPIKE: investigate caching", "Task", "To Do", 0)),
+		newIssue("ENG-4", "Closed epic", "Epic", "Done", 0),
+	}
+	child := newIssue("ENG-5", "Open child", "Story", "In Progress", 2)
+
+	client := &fakeClient{
+		descriptions: map[string]string{
+			"ENG-1": longDesc,
+			"ENG-2": "short",
+			"ENG-3": longDesc,
+			"ENG-4": longDesc,
+		},
+		searches: map[string][]jira.Issue{
+			"openSprints()":    {issues[1]},
+			"parent = ENG-4":   {child},
+			"customfield_1 = ": nil,
+		},
+	}
+	cfg := &config.Config{DescriptionMinLength: 20, EpicLinkFieldID: "customfield_1"}
+
+	result, err := Run(NewContext(client, cfg, issues), &RuleSet{}, issues)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if got := findingsFor(result, "ENG-1"); len(got) != 0 {
+		t.Errorf("Expected no findings for ENG-1, got %v", got)
+	}
+
+	got := findingsFor(result, "ENG-2")
+	for _, rule := range []string{"description", "component", "story_points", "unassigned_in_sprint"} {
+		if _, ok := got[rule]; !ok {
+			t.Errorf("Expected %s finding for ENG-2, got %v", rule, got)
+		}
+	}
+
+	if got := findingsFor(result, "ENG-3"); got["spike_without_timebox"] != SeverityWarning {
+		t.Errorf("Expected spike_without_timebox warning for ENG-3, got %v", got)
+	}
+
+	if got := findingsFor(result, "ENG-4"); got["closed_parent_open_children"] != SeverityError || len(got) != 1 {
+		t.Errorf("Expected only closed_parent_open_children for ENG-4, got %v", got)
+	}
+
+	if !result.Failed(SeverityError) {
+		t.Error("Expected result to fail on errors")
+	}
+}
+
+func TestRuleSetOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lint.yaml")
+	data := `rules:
+  component:
+    severity: error
+  description:
+    min_length: 10
+projects:
+  OPS:
+    rules:
+      component:
+        severity: off
+`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatalf("Failed to write rules: %v", err)
+	}
+
+	ruleSet, err := LoadRuleSet(path)
+	if err != nil {
+		t.Fatalf("LoadRuleSet failed: %v", err)
+	}
+
+	component := findRule("component")
+	if rc := ruleSet.Effective(component, "ENG"); rc.Severity != SeverityError {
+		t.Errorf("Expected component to be an error for ENG, got %s", rc.Severity)
+	}
+	if rc := ruleSet.Effective(component, "OPS"); rc.Severity != SeverityOff {
+		t.Errorf("Expected component to be off for OPS, got %s", rc.Severity)
+	}
+	// Fields not overridden keep their defaults
+	if rc := ruleSet.Effective(component, "ENG"); !rc.AppliesTo("Story") || rc.AppliesTo("Epic") {
+		t.Errorf("Expected default issue type exclusions to be kept, got %+v", rc)
+	}
+	if rc := ruleSet.Effective(findRule("description"), "ENG"); rc.MinLength != 10 || rc.Severity != SeverityError {
+		t.Errorf("Expected description min_length 10 at default severity, got %+v", rc)
+	}
+}
+
+func TestLoadRuleSetInvalid(t *testing.T) {
+	dir := t.TempDir()
+
+	missing, err := LoadRuleSet(filepath.Join(dir, "missing.yaml"))
+	if err != nil || len(missing.Rules) != 0 {
+		t.Errorf("Expected empty rule set for a missing file, got %+v, %v", missing, err)
+	}
+
+	for name, data := range map[string]string{
+		"unknown rule": "rules:\n  no_such_rule:\n    severity: error\n",
+		"bad severity": "projects:\n  ENG:\n    rules:\n      component:\n        severity: fatal\n",
+	} {
+		path := filepath.Join(dir, strings.ReplaceAll(name, " ", "_")+".yaml")
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatalf("Failed to write rules: %v", err)
+		}
+		if _, err := LoadRuleSet(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
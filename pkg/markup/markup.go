@@ -0,0 +1,242 @@
+// Package markup renders ticket text written in Jira wiki markup or Markdown for a terminal
+package markup
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// style holds the escape codes used for emphasis; the zero style renders plain text
+type style struct {
+	bold, italic, code, reset string
+}
+
+var ansiStyle = style{bold: "\x1b[1m", italic: "\x1b[3m", code: "\x1b[36m", reset: "\x1b[0m"}
+
+// wikiPattern matches constructs that only appear in Jira wiki markup
+var wikiPattern = regexp.MustCompile(
+	`(?m)^\s*h[1-6]\.\s|^\s*bq\.\s|\{code|\{noformat|\{quote\}|\{color|\[~|\{\{|^\s*\|\||\[[^\]|]+\|[^\]]+\]`)
+
+var (
+	wikiHeading  = regexp.MustCompile(`^h([1-6])\.\s+(.*)$`)
+	mdHeading    = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	wikiList     = regexp.MustCompile(`^([*#-]+)\s+(.*)$`)
+	mdList       = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	mdRule       = regexp.MustCompile(`^(-{3,}|\*{3,}|_{3,})$`)
+	mdTableRule  = regexp.MustCompile(`^\|?[\s:|-]*-[\s:|-]*\|?$`)
+	wikiLink     = regexp.MustCompile(`\[([^|\]]+)\|([^\]]+)\]`)
+	wikiMention  = regexp.MustCompile(`\[~([^\]]+)\]`)
+	wikiURL      = regexp.MustCompile(`\[((?:https?|mailto):[^\]]+)\]`)
+	wikiImage    = regexp.MustCompile(`!([^!\s|]+\.(?i:png|jpe?g|gif|svg))(\|[^!]*)?!`)
+	wikiColor    = regexp.MustCompile(`\{color(:[^}]*)?\}`)
+	wikiCode     = regexp.MustCompile(`\{\{(.+?)\}\}`)
+	wikiBold     = regexp.MustCompile(`(^|[^\w*])\*([^*\s](?:[^*]*[^*\s])?)\*($|[^\w*])`)
+	mdLink       = regexp.MustCompile(`!?\[([^\]]*)\]\(([^)\s]+)[^)]*\)`)
+	mdCode       = regexp.MustCompile("`([^`]+)`")
+	mdBold       = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	mdItalic     = regexp.MustCompile(`(^|[^\w*])\*([^*\s](?:[^*]*[^*\s])?)\*($|[^\w*])`)
+	italicMarker = regexp.MustCompile(`(^|[^\w_])_([^_\s](?:[^_]*[^_\s])?)_($|[^\w_])`)
+)
+
+// IsWiki reports whether text looks like Jira wiki markup rather than Markdown
+func IsWiki(text string) bool {
+	return wikiPattern.MatchString(text)
+}
+
+// Render lays out ticket text for a terminal: headings, lists, quotes, tables, code blocks
+// and links are rewritten, and emphasis is shown with escape codes if styled is true
+// The markup language is detected from the text
+func Render(text string, styled bool) string {
+	r := &renderer{wiki: IsWiki(text)}
+	if styled {
+		r.style = ansiStyle
+	}
+
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if rendered, ok := r.line(line); ok {
+			out = append(out, rendered)
+		}
+	}
+	return strings.TrimRight(strings.Join(out, "\n"), "\n")
+}
+
+// renderer holds the state carried between lines
+type renderer struct {
+	wiki    bool
+	style   style
+	inCode  bool
+	inQuote bool
+	// numbers are the counters of the enclosing numbered wiki lists
+	numbers []int
+}
+
+// line renders one line; ok is false for lines that only carry markup, such as code fences
+func (r *renderer) line(line string) (rendered string, ok bool) {
+	trimmed := strings.TrimSpace(line)
+
+	if r.isCodeFence(trimmed) {
+		r.inCode = !r.inCode
+		return "", false
+	}
+	if r.inCode {
+		return "    " + r.style.code + line + r.style.reset, true
+	}
+	if r.wiki && trimmed == "{quote}" {
+		r.inQuote = !r.inQuote
+		return "", false
+	}
+
+	rendered, ok = r.block(trimmed, line)
+	if r.inQuote {
+		rendered = "│ " + rendered
+	}
+	return rendered, ok
+}
+
+func (r *renderer) isCodeFence(trimmed string) bool {
+	if r.wiki {
+		return strings.HasPrefix(trimmed, "{code") || strings.HasPrefix(trimmed, "{noformat")
+	}
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
+
+// block renders headings, rules, quotes, lists and tables; anything else is a paragraph line
+func (r *renderer) block(trimmed, line string) (string, bool) {
+	isList := false
+	defer func() {
+		if !isList {
+			r.numbers = nil
+		}
+	}()
+
+	if m := r.heading(trimmed); m != "" {
+		return r.style.bold + r.inline(m) + r.style.reset, true
+	}
+
+	switch {
+	case trimmed == "----" || (!r.wiki && mdRule.MatchString(trimmed)):
+		return strings.Repeat("─", 40), true
+	case r.wiki && strings.HasPrefix(trimmed, "bq. "):
+		return "│ " + r.inline(strings.TrimPrefix(trimmed, "bq. ")), true
+	case !r.wiki && strings.HasPrefix(trimmed, ">"):
+		return "│ " + r.inline(strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))), true
+	case strings.HasPrefix(trimmed, "|"):
+		return r.table(trimmed)
+	}
+
+	if item, ok := r.listItem(trimmed, line); ok {
+		isList = true
+		return item, true
+	}
+
+	return r.inline(line), true
+}
+
+func (r *renderer) heading(trimmed string) string {
+	if r.wiki {
+		if m := wikiHeading.FindStringSubmatch(trimmed); m != nil {
+			return m[2]
+		}
+		return ""
+	}
+	if m := mdHeading.FindStringSubmatch(trimmed); m != nil {
+		return m[2]
+	}
+	return ""
+}
+
+func (r *renderer) listItem(trimmed, line string) (string, bool) {
+	if r.wiki {
+		m := wikiList.FindStringSubmatch(trimmed)
+		if m == nil {
+			return "", false
+		}
+		depth := len(m[1])
+		indent := strings.Repeat("  ", depth-1)
+		if !strings.HasSuffix(m[1], "#") {
+			r.numbers = r.numbers[:minInt(len(r.numbers), depth)]
+			return indent + "• " + r.inline(m[2]), true
+		}
+
+		for len(r.numbers) < depth {
+			r.numbers = append(r.numbers, 0)
+		}
+		r.numbers = r.numbers[:depth]
+		r.numbers[depth-1]++
+		return fmt.Sprintf("%s%d. %s", indent, r.numbers[depth-1], r.inline(m[2])), true
+	}
+
+	m := mdList.FindStringSubmatch(line)
+	if m == nil {
+		return "", false
+	}
+	indent := strings.Repeat("  ", len(strings.ReplaceAll(m[1], "\t", "  "))/2)
+	marker := "•"
+	if m[2][0] >= '0' && m[2][0] <= '9' {
+		marker = strings.TrimRight(m[2], ".)") + "."
+	}
+	return indent + marker + " " + r.inline(m[3]), true
+}
+
+// table renders a table row with its cells separated by bars; header cells are bold
+func (r *renderer) table(trimmed string) (string, bool) {
+	if !r.wiki && mdTableRule.MatchString(trimmed) {
+		return "", false
+	}
+
+	header := r.wiki && strings.HasPrefix(trimmed, "||")
+	separator := "|"
+	if header {
+		separator = "||"
+	}
+
+	cells := []string{}
+	for _, cell := range strings.Split(strings.Trim(trimmed, "|"), separator) {
+		cell = r.inline(strings.TrimSpace(cell))
+		if header {
+			cell = r.style.bold + cell + r.style.reset
+		}
+		cells = append(cells, cell)
+	}
+	return strings.Join(cells, " │ "), true
+}
+
+// inline rewrites links and emphasis within a line
+func (r *renderer) inline(s string) string {
+	b, i, c, reset := r.style.bold, r.style.italic, r.style.code, r.style.reset
+
+	if r.wiki {
+		s = wikiColor.ReplaceAllString(s, "")
+		s = wikiImage.ReplaceAllString(s, "[image: $1]")
+		s = wikiMention.ReplaceAllString(s, "@$1")
+		s = wikiLink.ReplaceAllString(s, "$1 ($2)")
+		s = wikiURL.ReplaceAllString(s, "$1")
+		s = wikiCode.ReplaceAllString(s, c+"$1"+reset)
+		s = wikiBold.ReplaceAllString(s, "${1}"+b+"${2}"+reset+"${3}")
+	} else {
+		s = mdLink.ReplaceAllStringFunc(s, func(link string) string {
+			m := mdLink.FindStringSubmatch(link)
+			if strings.HasPrefix(link, "!") {
+				return fmt.Sprintf("[image: %s]", m[2])
+			}
+			if m[1] == "" || m[1] == m[2] {
+				return m[2]
+			}
+			return fmt.Sprintf("%s (%s)", m[1], m[2])
+		})
+		s = mdCode.ReplaceAllString(s, c+"$1"+reset)
+		s = mdBold.ReplaceAllString(s, b+"$1$2"+reset)
+		s = mdItalic.ReplaceAllString(s, "${1}"+i+"${2}"+reset+"${3}")
+	}
+	return italicMarker.ReplaceAllString(s, "${1}"+i+"${2}"+reset+"${3}")
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,57 @@
+package markup
+
+import "testing"
+
+func TestIsWiki(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"h1. Overview\nSome text", true},
+		{"Call {{doThing()}} first", true},
+		{"See [the docs|https://example.com]", true},
+		{"# Overview\n\nSome **bold** text", false},
+		{"Plain text with a [link](https://example.com)", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsWiki(tt.text); got != tt.want {
+			t.Errorf("IsWiki(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestRenderWiki(t *testing.T) {
+	text := "h2. Steps\n# Open *the* app\n# Click [Save|https://x.test/save]\n## Ask [~jdoe]\n" +
+		"* snake_case_name stays\n\n||Key||Value||\n|a|b|\n{code:java}\nint x = 1;\n{code}\n" +
+		"{quote}\nquoted\n{quote}\n----"
+	want := "Steps\n1. Open the app\n2. Click Save (https://x.test/save)\n  1. Ask @jdoe\n" +
+		"• snake_case_name stays\n\nKey │ Value\na │ b\n    int x = 1;\n│ quoted\n" +
+		"────────────────────────────────────────"
+
+	if got := Render(text, false); got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	text := "## Steps\n1. Open **the** app\n   - use `--verbose`\n> note\n\n" +
+		"| Key | Value |\n|-----|-------|\n| a | b |\n```\ngo test\n```\n![shot](https://x.test/a.png) " +
+		"and [docs](https://x.test)"
+	want := "Steps\n1. Open the app\n  • use --verbose\n│ note\n\nKey │ Value\na │ b\n    go test\n" +
+		"[image: https://x.test/a.png] and docs (https://x.test)"
+
+	if got := Render(text, false); got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderStyled(t *testing.T) {
+	want := "Some \x1b[1mbold\x1b[0m and \x1b[3mitalic\x1b[0m"
+	if got := Render("Some **bold** and _italic_", true); got != want {
+		t.Errorf("Unexpected styled output %q", got)
+	}
+	if got := Render("h3. Title", true); got != "\x1b[1mTitle\x1b[0m" {
+		t.Errorf("Unexpected styled heading %q", got)
+	}
+}
@@ -0,0 +1,173 @@
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+// Runner compares plans with Jira and applies them
+type Runner struct {
+	client   jira.JiraClient
+	resolver Resolver
+	// Project is where new tickets go when the plan doesn't name one
+	Project string
+	// EpicLinkField, if set, links the children of Epics with this Epic Link field
+	// instead of the parent field
+	EpicLinkField string
+
+	linkTypes []jira.IssueLinkType
+}
+
+// NewRunner creates a runner that resolves users and components with resolver
+func NewRunner(client jira.JiraClient, resolver Resolver) *Runner {
+	return &Runner{client: client, resolver: resolver}
+}
+
+func (r *Runner) project(p *Plan) string {
+	if p.Project != "" {
+		return strings.ToUpper(p.Project)
+	}
+	return r.Project
+}
+
+// Apply makes the changes of a plan, parents before their children, then links and ranks.
+// saved is called after every ticket is created, so its key can be written back at once
+// and a failed run can be re-run without creating tickets twice.
+func (r *Runner) Apply(p *Plan, changes []*Change, saved func() error) error {
+	for _, change := range changes {
+		item := change.Item
+		if change.Create {
+			key, err := r.create(p, change)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", item.name(), err)
+			}
+			item.Key = key
+			fmt.Printf("Created %s %s: %s\n", item.Type, key, item.Summary)
+			if err := saved(); err != nil {
+				return err
+			}
+		}
+
+		for _, field := range change.Fields {
+			if err := r.setField(p, item, field.Name); err != nil {
+				return fmt.Errorf("failed to set %s of %s: %w", field.Name, item.Key, err)
+			}
+		}
+		if !change.Create && len(change.Fields) > 0 {
+			fmt.Printf("Updated %s\n", item.Key)
+		}
+	}
+
+	for _, change := range changes {
+		for _, link := range change.Links {
+			if err := r.link(p, change.Item, link); err != nil {
+				return fmt.Errorf("failed to link %s %s %s: %w", change.Item.Key, link.Relation, link.Target, err)
+			}
+		}
+		if change.Reorder {
+			if err := r.rank(change.Item); err != nil {
+				return fmt.Errorf("failed to reorder the children of %s: %w", change.Item.Key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// rank ranks the children of an item one after the other, in the order of the plan
+func (r *Runner) rank(item *Item) error {
+	keys := make([]string, len(item.Children))
+	for i, child := range item.Children {
+		keys[i] = child.Key
+	}
+	// Jira ranks at most 50 issues at a time, so rank in chunks after the last one ranked
+	for start := 1; start < len(keys); start += 50 {
+		end := minInt(start+50, len(keys))
+		if err := r.client.RankIssues(keys[start:end], keys[start-1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Runner) create(p *Plan, change *Change) (string, error) {
+	item, project := change.Item, r.project(p)
+	if project == "" {
+		return "", fmt.Errorf("no project: set one in the plan or configure default_project")
+	}
+	if change.Parent == nil {
+		return r.client.CreateTicket(project, item.Type, item.Summary)
+	}
+	if change.Parent.Key == "" {
+		return "", fmt.Errorf("parent %s has not been created", change.Parent.name())
+	}
+	if r.EpicLinkField != "" && strings.EqualFold(change.Parent.Type, "Epic") {
+		return r.client.CreateTicketWithEpicLink(project, item.Type, item.Summary, change.Parent.Key, r.EpicLinkField)
+	}
+	return r.client.CreateTicketWithParent(project, item.Type, item.Summary, change.Parent.Key)
+}
+
+func (r *Runner) setField(p *Plan, item *Item, name string) error {
+	switch name {
+	case FieldSummary:
+		return r.client.UpdateTicketFields(item.Key, map[string]interface{}{"summary": item.Summary})
+	case FieldDescription:
+		return r.client.UpdateTicketDescription(item.Key, item.Description)
+	case FieldPoints:
+		return r.client.UpdateTicketPoints(item.Key, item.Points)
+	case FieldComponents:
+		ids := make([]string, len(item.Components))
+		for i, component := range item.Components {
+			id, err := r.resolver.ComponentID(r.project(p), component)
+			if err != nil {
+				return err
+			}
+			ids[i] = id
+		}
+		return r.client.UpdateTicketComponents(item.Key, ids)
+	case FieldAssignee:
+		user, err := r.resolver.User(item.Assignee)
+		if err != nil {
+			return err
+		}
+		return r.client.AssignTicket(item.Key, user.AccountID, user.Name)
+	}
+	return fmt.Errorf("unknown field %q", name)
+}
+
+// link adds a link between an item and its target
+func (r *Runner) link(p *Plan, item *Item, link Link) error {
+	target := TargetKey(p, link.Target)
+	if target == "" {
+		return fmt.Errorf("%s has not been created", link.Target)
+	}
+	name, outward, err := r.linkType(link.Relation)
+	if err != nil {
+		return err
+	}
+	if outward {
+		return r.client.LinkIssues(name, item.Key, target)
+	}
+	return r.client.LinkIssues(name, target, item.Key)
+}
+
+// linkType finds the link type whose outward or inward description is a relation
+func (r *Runner) linkType(relation string) (name string, outward bool, err error) {
+	if r.linkTypes == nil {
+		linkTypes, err := r.client.GetIssueLinkTypes()
+		if err != nil {
+			return "", false, fmt.Errorf("failed to get link types: %w", err)
+		}
+		r.linkTypes = linkTypes
+	}
+	for _, lt := range r.linkTypes {
+		switch {
+		case strings.EqualFold(lt.Outward, relation):
+			return lt.Name, true, nil
+		case strings.EqualFold(lt.Inward, relation):
+			return lt.Name, false, nil
+		}
+	}
+	return "", false, fmt.Errorf("no link type reads %q", relation)
+}
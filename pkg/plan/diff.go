@@ -0,0 +1,245 @@
+package plan
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+// Fields an item can change, in the order they are applied
+const (
+	FieldSummary     = "summary"
+	FieldDescription = "description"
+	FieldPoints      = "points"
+	FieldComponents  = "components"
+	FieldAssignee    = "assignee"
+)
+
+// Change is what applying a plan does to one item
+type Change struct {
+	Item   *Item
+	Parent *Item
+	Depth  int
+	// Create is set for items without a key, which are created before their fields are set
+	Create bool
+	Fields []FieldChange
+	// Links are the item's links that don't exist yet
+	Links []Link
+	// Reorder ranks the item's children in the order of the plan
+	Reorder bool
+}
+
+// FieldChange is a field whose value in the plan differs from Jira
+type FieldChange struct {
+	Name string
+	Old  string
+	New  string
+}
+
+// Empty checks if the item is already as the plan declares it
+func (c *Change) Empty() bool {
+	return !c.Create && len(c.Fields) == 0 && len(c.Links) == 0 && !c.Reorder
+}
+
+// Resolver resolves the names in a plan to Jira users and component IDs
+type Resolver interface {
+	User(query string) (jira.User, error)
+	ComponentID(project, name string) (string, error)
+}
+
+// Diff compares every item of a plan with its ticket, in the order of the plan
+// Users, components and link types are resolved here, so a typo fails before anything is changed
+func (r *Runner) Diff(p *Plan) ([]*Change, error) {
+	var changes []*Change
+	var err error
+	p.Walk(func(item, parent *Item, depth int) {
+		if err != nil {
+			return
+		}
+		change := &Change{Item: item, Parent: parent, Depth: depth, Create: item.Key == ""}
+		err = r.diffItem(p, change)
+		changes = append(changes, change)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (r *Runner) diffItem(p *Plan, change *Change) error {
+	item := change.Item
+	current := &jira.TicketDetails{}
+	if !change.Create {
+		details, err := r.client.GetTicketDetails(item.Key)
+		if err != nil {
+			return fmt.Errorf("failed to get %s: %w", item.Key, err)
+		}
+		current = details
+		change.Fields = append(change.Fields, changed(FieldSummary, current.Summary, item.Summary)...)
+	}
+
+	if item.Description != "" {
+		change.Fields = append(change.Fields,
+			changed(FieldDescription, normalizeText(current.Description), normalizeText(item.Description))...)
+	}
+	if item.Points > 0 {
+		change.Fields = append(change.Fields,
+			changed(FieldPoints, pointsText(int(current.StoryPoints)), pointsText(item.Points))...)
+	}
+	if len(item.Components) > 0 {
+		for _, name := range item.Components {
+			if _, err := r.resolver.ComponentID(r.project(p), name); err != nil {
+				return fmt.Errorf("%s: %w", item.name(), err)
+			}
+		}
+		if old, value := listText(current.Components), listText(item.Components); !strings.EqualFold(old, value) {
+			change.Fields = append(change.Fields, FieldChange{Name: FieldComponents, Old: old, New: value})
+		}
+	}
+	if item.Assignee != "" && !strings.EqualFold(item.Assignee, current.Assignee) {
+		user, err := r.resolver.User(item.Assignee)
+		if err != nil {
+			return fmt.Errorf("%s: %w", item.name(), err)
+		}
+		if !strings.EqualFold(user.DisplayName, current.Assignee) {
+			change.Fields = append(change.Fields, FieldChange{Name: FieldAssignee, Old: current.Assignee, New: item.Assignee})
+		}
+	}
+
+	for _, text := range item.Links {
+		link, err := ParseLink(text)
+		if err != nil {
+			return fmt.Errorf("%s: %w", item.name(), err)
+		}
+		if _, _, err := r.linkType(link.Relation); err != nil {
+			return fmt.Errorf("%s: %w", item.name(), err)
+		}
+		if !hasLink(current.Links, link, p) {
+			change.Links = append(change.Links, link)
+		}
+	}
+	return nil
+}
+
+// changed returns the change of a field, if its value differs
+func changed(name, old, value string) []FieldChange {
+	if old == value {
+		return nil
+	}
+	return []FieldChange{{Name: name, Old: old, New: value}}
+}
+
+func normalizeText(text string) string {
+	return strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+}
+
+func pointsText(points int) string {
+	if points == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", points)
+}
+
+// listText is a list as a comparable string, ignoring order
+func listText(list []string) string {
+	sorted := append([]string{}, list...)
+	sort.Slice(sorted, func(i, j int) bool { return strings.ToLower(sorted[i]) < strings.ToLower(sorted[j]) })
+	return strings.Join(sorted, ", ")
+}
+
+// hasLink checks if a ticket already has a link, by its relation and target's key
+func hasLink(links []jira.IssueLink, link Link, p *Plan) bool {
+	target := TargetKey(p, link.Target)
+	for _, existing := range links {
+		if strings.EqualFold(existing.Relation, link.Relation) && existing.Key == target {
+			return true
+		}
+	}
+	return false
+}
+
+// TargetKey returns the key of a link target, which is a key or the ID of an item ("" until it's created)
+func TargetKey(p *Plan, target string) string {
+	if item := p.Find(target); item != nil {
+		return item.Key
+	}
+	return target
+}
+
+// Summary counts the items to create and update, and those already up to date
+func Summary(changes []*Change) (create, update, unchanged int) {
+	for _, change := range changes {
+		switch {
+		case change.Create:
+			create++
+		case change.Empty():
+			unchanged++
+		default:
+			update++
+		}
+	}
+	return create, update, unchanged
+}
+
+// Print writes the changes like a Terraform plan: "+" for tickets to create, "~" for tickets
+// to update with the fields that change, and a count at the end
+func Print(w io.Writer, p *Plan, changes []*Change) {
+	for _, change := range changes {
+		if change.Empty() {
+			continue
+		}
+		item := change.Item
+		indent := strings.Repeat("    ", change.Depth)
+		if change.Create {
+			fmt.Fprintf(w, "%s+ %s %q\n", indent, item.Type, item.Summary)
+		} else {
+			fmt.Fprintf(w, "%s~ %s %q\n", indent, item.Key, item.Summary)
+		}
+		for _, field := range change.Fields {
+			fmt.Fprintf(w, "%s      %s\n", indent, fieldText(field, change.Create))
+		}
+		for _, link := range change.Links {
+			target := link.Target
+			if key := TargetKey(p, target); key != "" {
+				target = key
+			}
+			fmt.Fprintf(w, "%s      + link: %s %s\n", indent, link.Relation, target)
+		}
+		if change.Reorder {
+			fmt.Fprintf(w, "%s      order: %s\n", indent, childNames(item))
+		}
+	}
+
+	create, update, unchanged := Summary(changes)
+	fmt.Fprintf(w, "\nPlan: %d to create, %d to update, %d unchanged.\n", create, update, unchanged)
+}
+
+// childNames lists an item's children by key, or by summary until they're created
+func childNames(item *Item) string {
+	names := make([]string, len(item.Children))
+	for i, child := range item.Children {
+		names[i] = child.Key
+		if names[i] == "" {
+			names[i] = strconv.Quote(child.Summary)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func fieldText(field FieldChange, create bool) string {
+	if field.Name == FieldDescription {
+		lines := strings.Count(field.New, "\n") + 1
+		if create {
+			return fmt.Sprintf("%s: (%d line(s))", field.Name, lines)
+		}
+		return fmt.Sprintf("%s: changed (%d line(s))", field.Name, lines)
+	}
+	if create || field.Old == "" {
+		return fmt.Sprintf("%s: %s", field.Name, field.New)
+	}
+	return fmt.Sprintf("%s: %s -> %s", field.Name, field.Old, field.New)
+}
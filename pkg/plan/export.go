@@ -0,0 +1,61 @@
+package plan
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+// Export builds a plan of a ticket and its children (and theirs), as they are in Jira now,
+// with the sync state to detect conflicts when the plan is synced back
+func Export(client jira.JiraClient, key, epicLinkField, format string) (*Plan, error) {
+	if format != FormatMarkdown && format != FormatYAML {
+		return nil, fmt.Errorf("unknown plan format %q", format)
+	}
+	root, err := exportItem(client, key, epicLinkField)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Plan{Project: jira.ProjectKey(root.Key), Items: []*Item{root}, format: format}
+	p.Synced = NewSyncState(p, time.Now())
+	return p, nil
+}
+
+func exportItem(client jira.JiraClient, key, epicLinkField string) (*Item, error) {
+	details, err := client.GetTicketDetails(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", key, err)
+	}
+	item := &Item{
+		Key:         details.Key,
+		Type:        details.Type,
+		Status:      details.Status,
+		Summary:     details.Summary,
+		Description: normalizeText(details.Description),
+		Points:      int(details.StoryPoints),
+		Assignee:    details.Assignee,
+	}
+	if isSubtaskType(details.Type) {
+		return item, nil
+	}
+
+	children, err := jira.GetChildTicketsDetailed(client, key, epicLinkField)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the children of %s: %w", key, err)
+	}
+	for i := range children {
+		child, err := exportItem(client, children[i].Key, epicLinkField)
+		if err != nil {
+			return nil, err
+		}
+		item.Children = append(item.Children, child)
+	}
+	return item, nil
+}
+
+func isSubtaskType(issueType string) bool {
+	return strings.EqualFold(issueType, "Sub-task") || strings.EqualFold(issueType, "Subtask")
+}
@@ -0,0 +1,301 @@
+package plan
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// syncMarker starts the comment that holds the sync state at the end of a Markdown plan
+const syncMarker = "<!-- jira-sync:"
+
+var (
+	headingPattern    = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	headingKeyPattern = regexp.MustCompile(`\s*\(([A-Z][A-Z0-9_]+-[1-9][0-9]*)\)$`)
+	fieldPattern      = regexp.MustCompile(`^([A-Za-z]+):\s*(.*?)\s*$`)
+)
+
+// markdownFields are the "Name: value" lines read after a heading (and, for project, before the first)
+var markdownFields = map[string]bool{
+	"type": true, "id": true, "status": true, "points": true, "components": true, "assignee": true, "links": true,
+}
+
+// parseMarkdown parses a plan where each heading is an item, nested by heading level:
+//
+//	Project: ENG
+//
+//	# Epic: Onboarding (ENG-100)
+//	Components: UI
+//
+//	Description of the Epic...
+//
+//	## Story: Welcome page
+//	Points: 3
+//	Assignee: jane@example.com
+//	Links: blocks ENG-50, relates to login
+//
+// The type in front of the summary is optional; the key is added once the ticket exists.
+// Field lines end at the first blank line after them, and a description line that would
+// read as a heading is escaped with a backslash ("\\# not a heading").
+func parseMarkdown(text string) (*Plan, error) {
+	p := &Plan{format: FormatMarkdown, lines: strings.Split(text, "\n"), syncStart: -1, syncEnd: -1}
+
+	type open struct {
+		item  *Item
+		level int
+	}
+	var stack []open
+	var current *Item
+	var description []string
+	// Field lines come before the description, and before the first heading for the project
+	inFields, sawField, inFence := true, false, false
+
+	finish := func() {
+		if current != nil {
+			current.Description = strings.TrimSpace(strings.Join(description, "\n"))
+		}
+		description = nil
+	}
+
+	for i := 0; i < len(p.lines); i++ {
+		line := p.lines[i]
+		if strings.HasPrefix(line, syncMarker) && !inFence {
+			end, err := p.parseSyncState(i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+			continue
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+		}
+		if m := headingPattern.FindStringSubmatch(line); m != nil && !inFence {
+			finish()
+			current = parseHeading(m[2])
+			current.line = i + 1
+			level := len(m[1])
+
+			for len(stack) > 0 && stack[len(stack)-1].level >= level {
+				stack = stack[:len(stack)-1]
+			}
+			if len(stack) == 0 {
+				p.Items = append(p.Items, current)
+			} else {
+				parent := stack[len(stack)-1].item
+				parent.Children = append(parent.Children, current)
+			}
+			stack = append(stack, open{item: current, level: level})
+			inFields, sawField = true, false
+			continue
+		}
+
+		if inFields && !inFence {
+			if m := fieldPattern.FindStringSubmatch(line); m != nil {
+				if name := strings.ToLower(m[1]); markdownFields[name] || (current == nil && name == "project") {
+					if err := setField(p, current, name, m[2]); err != nil {
+						return nil, fmt.Errorf("line %d: %w", i+1, err)
+					}
+					sawField = current != nil
+					continue
+				}
+			}
+			if strings.TrimSpace(line) != "" || sawField {
+				inFields = current == nil
+			}
+		}
+		if current != nil {
+			if isEscapedHeading(line) && !inFence {
+				line = line[1:]
+			}
+			description = append(description, line)
+		}
+	}
+	finish()
+	return p, nil
+}
+
+// parseHeading reads the optional type, summary and optional key of a heading
+func parseHeading(text string) *Item {
+	item := &Item{}
+	if m := headingKeyPattern.FindStringSubmatchIndex(text); m != nil {
+		item.Key = text[m[2]:m[3]]
+		text = text[:m[0]]
+	}
+	if i := strings.Index(text, ":"); i > 0 {
+		for _, known := range KnownTypes {
+			if strings.EqualFold(strings.TrimSpace(text[:i]), known) {
+				item.Type = known
+				text = text[i+1:]
+				break
+			}
+		}
+	}
+	item.Summary = strings.TrimSpace(text)
+	return item
+}
+
+// setField sets a field read from a "Name: value" line; without an item, only the project
+func setField(p *Plan, item *Item, name, value string) error {
+	if item == nil {
+		p.Project = value
+		return nil
+	}
+	switch name {
+	case "type":
+		item.Type = value
+	case "id":
+		item.ID = value
+	case "status":
+		item.Status = value
+	case "assignee":
+		item.Assignee = value
+	case "points":
+		points, err := strconv.Atoi(value)
+		if err != nil || points < 0 {
+			return fmt.Errorf("invalid points %q", value)
+		}
+		item.Points = points
+	case "components":
+		item.Components = splitList(value)
+	case "links":
+		item.Links = splitList(value)
+	}
+	return nil
+}
+
+// splitList splits a comma-separated list, dropping empty entries
+func splitList(value string) []string {
+	var list []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			list = append(list, entry)
+		}
+	}
+	return list
+}
+
+// parseSyncState reads the sync state comment starting at a line, returning the line that ends it
+func (p *Plan) parseSyncState(start int) (int, error) {
+	for end := start + 1; end < len(p.lines); end++ {
+		if strings.TrimSpace(p.lines[end]) != "-->" {
+			continue
+		}
+		p.Synced = &SyncState{}
+		if err := yaml.Unmarshal([]byte(strings.Join(p.lines[start+1:end], "\n")), p.Synced); err != nil {
+			return 0, fmt.Errorf("failed to parse sync state on line %d: %w", start+1, err)
+		}
+		p.syncStart, p.syncEnd = start, end
+		return end, nil
+	}
+	return 0, fmt.Errorf("sync state on line %d isn't closed with -->", start+1)
+}
+
+// isEscapedHeading checks for a description line escaped so it doesn't read as a heading
+func isEscapedHeading(line string) bool {
+	return strings.HasPrefix(line, `\`) && strings.HasPrefix(strings.TrimLeft(line, `\`), "#")
+}
+
+// syncStateLines returns the comment that holds the sync state
+func (p *Plan) syncStateLines() ([]string, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(p.Synced); err != nil {
+		return nil, fmt.Errorf("failed to encode sync state: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode sync state: %w", err)
+	}
+	lines := []string{syncMarker + " the tickets as last exported or synced, to detect conflicts; don't edit"}
+	lines = append(lines, strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")...)
+	return append(lines, "-->"), nil
+}
+
+// markdownWithKeys returns the Markdown file with the keys of new tickets added to their headings
+// and the current sync state
+func (p *Plan) markdownWithKeys() (string, error) {
+	lines := append([]string{}, p.lines...)
+	p.Walk(func(item, _ *Item, _ int) {
+		if item.Key == "" || item.line == 0 || item.line > len(lines) {
+			return
+		}
+		heading := strings.TrimRight(lines[item.line-1], " \t")
+		if m := headingKeyPattern.FindStringSubmatchIndex(heading); m != nil {
+			heading = heading[:m[0]]
+		}
+		lines[item.line-1] = fmt.Sprintf("%s (%s)", heading, item.Key)
+	})
+
+	if p.Synced != nil {
+		synced, err := p.syncStateLines()
+		if err != nil {
+			return "", err
+		}
+		if p.syncStart >= 0 {
+			lines = append(append(lines[:p.syncStart:p.syncStart], synced...), lines[p.syncEnd+1:]...)
+		} else {
+			for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+				lines = lines[:len(lines)-1]
+			}
+			lines = append(append(append(lines, ""), synced...), "")
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// renderMarkdown writes a plan in the Markdown format parseMarkdown reads
+func renderMarkdown(p *Plan) (string, error) {
+	var b strings.Builder
+	if p.Project != "" {
+		fmt.Fprintf(&b, "Project: %s\n\n", p.Project)
+	}
+	p.Walk(func(item, _ *Item, depth int) {
+		heading := fmt.Sprintf("%s %s: %s", strings.Repeat("#", minInt(depth+1, 6)), item.Type, item.Summary)
+		if item.Key != "" {
+			heading += fmt.Sprintf(" (%s)", item.Key)
+		}
+		b.WriteString(heading + "\n")
+
+		fields := [][2]string{
+			{"ID", item.ID}, {"Status", item.Status}, {"Points", pointsText(item.Points)},
+			{"Assignee", item.Assignee}, {"Components", strings.Join(item.Components, ", ")},
+			{"Links", strings.Join(item.Links, ", ")},
+		}
+		for _, field := range fields {
+			if field[1] != "" {
+				fmt.Fprintf(&b, "%s: %s\n", field[0], field[1])
+			}
+		}
+
+		if description := normalizeText(item.Description); description != "" {
+			b.WriteString("\n")
+			for _, line := range strings.Split(description, "\n") {
+				if headingPattern.MatchString(line) || isEscapedHeading(line) {
+					line = `\` + line
+				}
+				b.WriteString(line + "\n")
+			}
+		}
+		b.WriteString("\n")
+	})
+	if p.Synced != nil {
+		synced, err := p.syncStateLines()
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(strings.Join(synced, "\n") + "\n")
+	}
+	return b.String(), nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
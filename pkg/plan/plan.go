@@ -0,0 +1,322 @@
+// Package plan declares a hierarchy of epics, stories and tasks in a YAML or Markdown file
+// and applies it to Jira, creating what is missing and updating what changed
+package plan
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/beekhof/jira-tool/pkg/jira"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Plan file formats
+const (
+	FormatYAML     = "yaml"
+	FormatMarkdown = "md"
+)
+
+// KnownTypes are the issue types recognised in front of a Markdown heading, e.g. "Story: Login page"
+var KnownTypes = []string{"Epic", "Feature", "Story", "Task", "Sub-task", "Subtask", "Bug", "Spike", "Improvement"}
+
+// Plan is a backlog hierarchy as declared in a plan file
+type Plan struct {
+	// Project new tickets are created in; empty uses the default project
+	Project string  `yaml:"project,omitempty"`
+	Items   []*Item `yaml:"items"`
+	// Synced is the state of the tickets at the last export or sync, for 'jira sync'
+	Synced *SyncState `yaml:"synced,omitempty"`
+
+	format string
+	// node is the parsed YAML file, kept so keys can be written back without losing comments
+	node *yaml.Node
+	// lines are the lines of a Markdown file, kept so keys can be written back into the headings
+	lines []string
+	// syncStart and syncEnd are the first and last lines of the sync state in a Markdown file, or -1
+	syncStart, syncEnd int
+}
+
+// Item is one ticket in a plan
+type Item struct {
+	// Key is the ticket's key, written back into the file once the ticket is created
+	Key string `yaml:"key,omitempty"`
+	// ID names the item so links can refer to it before it has a key
+	ID   string `yaml:"id,omitempty"`
+	Type string `yaml:"type,omitempty"`
+	// Status is only for reference; it isn't applied
+	Status string `yaml:"status,omitempty"`
+
+	Summary     string   `yaml:"summary"`
+	Description string   `yaml:"description,omitempty"`
+	Points      int      `yaml:"points,omitempty"`
+	Components  []string `yaml:"components,omitempty"`
+	Assignee    string   `yaml:"assignee,omitempty"`
+	// Links are relations to other tickets as read from this one, e.g. "blocks ENG-5"
+	// The target is a ticket key or the ID of another item in the plan
+	Links    []string `yaml:"links,omitempty"`
+	Children []*Item  `yaml:"children,omitempty"`
+
+	// line is the line number (from 1) of the item's heading in a Markdown file, or 0
+	line int
+}
+
+// Link is a parsed link of an item
+type Link struct {
+	Relation string
+	Target   string
+}
+
+// ParseLink splits a link into its relation and target, e.g. "is blocked by ENG-5"
+func ParseLink(link string) (Link, error) {
+	link = strings.TrimSpace(link)
+	i := strings.LastIndexAny(link, " \t")
+	if i < 0 {
+		return Link{}, fmt.Errorf("invalid link %q, expected e.g. \"blocks ENG-5\"", link)
+	}
+	return Link{Relation: strings.TrimSpace(link[:i]), Target: link[i+1:]}, nil
+}
+
+// FormatFor returns the format of a plan file from its extension
+func FormatFor(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		return FormatMarkdown
+	default:
+		return FormatYAML
+	}
+}
+
+// Load reads a plan file in the format given by its extension
+func Load(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %w", err)
+	}
+	return Parse(data, FormatFor(path))
+}
+
+// Parse parses a plan in the given format, filling in the default issue types
+func Parse(data []byte, format string) (*Plan, error) {
+	var p *Plan
+	var err error
+	switch format {
+	case FormatMarkdown:
+		p, err = parseMarkdown(string(data))
+	case FormatYAML:
+		p, err = parseYAML(data)
+	default:
+		return nil, fmt.Errorf("unknown plan format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func parseYAML(data []byte) (*Plan, error) {
+	p := &Plan{format: FormatYAML, node: &yaml.Node{}}
+	if err := yaml.Unmarshal(data, p.node); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+	if err := p.node.Decode(p); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+	return p, nil
+}
+
+// validate fills in default types and checks summaries, IDs and links
+func (p *Plan) validate() error {
+	ids := map[string]bool{}
+	var all []*Item
+	p.Walk(func(item, parent *Item, _ int) {
+		if item.Type == "" {
+			item.Type = DefaultType(parent)
+		}
+		all = append(all, item)
+	})
+
+	for _, item := range all {
+		if strings.TrimSpace(item.Summary) == "" {
+			return fmt.Errorf("%s has no summary", item.name())
+		}
+		if item.ID != "" {
+			if ids[item.ID] {
+				return fmt.Errorf("ID %q is used by more than one item", item.ID)
+			}
+			ids[item.ID] = true
+		}
+	}
+	for _, item := range all {
+		for _, text := range item.Links {
+			link, err := ParseLink(text)
+			if err != nil {
+				return fmt.Errorf("%s: %w", item.name(), err)
+			}
+			if !ids[link.Target] && jira.FindTicketKey(link.Target) != link.Target {
+				return fmt.Errorf("%s: link target %q is neither a ticket key nor the ID of an item", item.name(), link.Target)
+			}
+		}
+	}
+	return nil
+}
+
+// DefaultType is the issue type of an item that doesn't give one: Epics at the top,
+// Stories in an Epic and Sub-tasks in anything else
+func DefaultType(parent *Item) string {
+	switch {
+	case parent == nil:
+		return "Epic"
+	case strings.EqualFold(parent.Type, "Epic"):
+		return "Story"
+	default:
+		return "Sub-task"
+	}
+}
+
+// Walk calls fn for every item, parents before their children, with the depth from 0 at the top
+func (p *Plan) Walk(fn func(item, parent *Item, depth int)) {
+	var walk func(items []*Item, parent *Item, depth int)
+	walk = func(items []*Item, parent *Item, depth int) {
+		for _, item := range items {
+			fn(item, parent, depth)
+			walk(item.Children, item, depth+1)
+		}
+	}
+	walk(p.Items, nil, 0)
+}
+
+// Find returns the item with a key or ID, or nil
+func (p *Plan) Find(keyOrID string) *Item {
+	var found *Item
+	p.Walk(func(item, _ *Item, _ int) {
+		if found == nil && (item.ID == keyOrID || (item.Key != "" && item.Key == keyOrID)) {
+			found = item
+		}
+	})
+	return found
+}
+
+func (i *Item) name() string {
+	switch {
+	case i.Key != "":
+		return i.Key
+	case i.Summary != "":
+		return fmt.Sprintf("%s %q", i.Type, i.Summary)
+	default:
+		return "an item"
+	}
+}
+
+// Save writes the plan back to a file, with the keys of the items created since it was loaded
+func (p *Plan) Save(path string) error {
+	data, err := p.Bytes()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write plan: %w", err)
+	}
+	return nil
+}
+
+// Bytes returns the plan file as loaded, with the keys of the items created since and the
+// current sync state. A plan that wasn't loaded from a file is written out in full.
+func (p *Plan) Bytes() ([]byte, error) {
+	if p.format == FormatMarkdown {
+		render := p.markdownWithKeys
+		if p.lines == nil {
+			render = func() (string, error) { return renderMarkdown(p) }
+		}
+		text, err := render()
+		return []byte(text), err
+	}
+	if p.node == nil {
+		p.node = &yaml.Node{}
+		if err := p.node.Encode(p); err != nil {
+			return nil, fmt.Errorf("failed to encode plan: %w", err)
+		}
+	} else if root := documentRoot(p.node); root != nil {
+		setKeys(mappingValue(root, "items"), p.Items)
+		if p.Synced != nil {
+			synced := &yaml.Node{}
+			if err := synced.Encode(p.Synced); err != nil {
+				return nil, fmt.Errorf("failed to encode sync state: %w", err)
+			}
+			setMappingValue(root, "synced", synced)
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(p.node); err != nil {
+		return nil, fmt.Errorf("failed to encode plan: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode plan: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func documentRoot(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	return node
+}
+
+// mappingValue returns the value of a key in a YAML mapping, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue sets the value of a key in a YAML mapping, adding the key at the end if it's missing
+func setMappingValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// setKeys writes the keys of items into the YAML sequence they were decoded from
+// A new key goes first in its item, where it's easy to spot
+func setKeys(sequence *yaml.Node, items []*Item) {
+	if sequence == nil || sequence.Kind != yaml.SequenceNode {
+		return
+	}
+	for i, node := range sequence.Content {
+		if i >= len(items) || node.Kind != yaml.MappingNode {
+			continue
+		}
+		item := items[i]
+		if item.Key != "" {
+			if value := mappingValue(node, "key"); value != nil {
+				value.Value = item.Key
+			} else {
+				node.Content = append([]*yaml.Node{
+					{Kind: yaml.ScalarNode, Tag: "!!str", Value: "key"},
+					{Kind: yaml.ScalarNode, Tag: "!!str", Value: item.Key},
+				}, node.Content...)
+			}
+		}
+		setKeys(mappingValue(node, "children"), item.Children)
+	}
+}
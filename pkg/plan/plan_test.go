@@ -0,0 +1,365 @@
+package plan
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+const testYAML = `# Onboarding plan
+project: ENG
+items:
+  - summary: Onboarding
+    description: |
+      Get new users started.
+    children:
+      - id: welcome
+        summary: Welcome page
+        points: 3
+        assignee: jane@example.com
+        links: [blocks login]
+        children:
+          - summary: Copy text # the words
+      - key: ENG-7
+        id: login
+        summary: Login page
+        components: [UI]
+`
+
+const testMarkdown = `Project: ENG
+
+# Epic: Onboarding
+Get new users started.
+
+## Story: Welcome page
+ID: welcome
+Points: 3
+Links: blocks ENG-7
+
+Greets the user.
+
+` + "```" + `
+# not a heading
+` + "```" + `
+
+### Copy text
+
+## Login page (ENG-7)
+Components: UI, Backend
+`
+
+// fakeClient serves ticket details and records the changes made
+type fakeClient struct {
+	jira.JiraClient
+	tickets map[string]*jira.TicketDetails
+	// rank is the order of the tickets, for searches of children
+	rank    []string
+	created int
+	calls   []string
+}
+
+func newFakeClient(tickets ...*jira.TicketDetails) *fakeClient {
+	c := &fakeClient{tickets: map[string]*jira.TicketDetails{}}
+	for _, t := range tickets {
+		c.tickets[t.Key] = t
+		c.rank = append(c.rank, t.Key)
+	}
+	return c
+}
+
+func (c *fakeClient) issue(t *jira.TicketDetails) jira.Issue {
+	issue := jira.Issue{Key: t.Key}
+	issue.Fields.Summary = t.Summary
+	issue.Fields.IssueType.Name = t.Type
+	return issue
+}
+
+func (c *fakeClient) GetIssue(key string) (*jira.Issue, error) {
+	t, ok := c.tickets[key]
+	if !ok {
+		return nil, fmt.Errorf("issue %s not found", key)
+	}
+	issue := c.issue(t)
+	return &issue, nil
+}
+
+// SearchTickets only answers "parent = KEY ORDER BY Rank ASC"
+func (c *fakeClient) SearchTickets(jql string) ([]jira.Issue, error) {
+	var parent string
+	if _, err := fmt.Sscanf(jql, "parent = %s ORDER BY Rank ASC", &parent); err != nil {
+		return nil, fmt.Errorf("unexpected JQL %q", jql)
+	}
+	var issues []jira.Issue
+	for _, key := range c.rank {
+		if t := c.tickets[key]; t.Parent == parent {
+			issues = append(issues, c.issue(t))
+		}
+	}
+	return issues, nil
+}
+
+func (c *fakeClient) record(format string, args ...interface{}) {
+	c.calls = append(c.calls, fmt.Sprintf(format, args...))
+}
+
+func (c *fakeClient) GetTicketDetails(ticketID string) (*jira.TicketDetails, error) {
+	if t, ok := c.tickets[ticketID]; ok {
+		return t, nil
+	}
+	return nil, fmt.Errorf("ticket %s not found", ticketID)
+}
+
+// next adds a ticket, ranked last
+func (c *fakeClient) next(taskType, summary, parentKey string) string {
+	c.created++
+	key := fmt.Sprintf("ENG-%d", 100+c.created)
+	c.tickets[key] = &jira.TicketDetails{Key: key, Type: taskType, Summary: summary, Parent: parentKey}
+	c.rank = append(c.rank, key)
+	return key
+}
+
+func (c *fakeClient) CreateTicket(_, taskType, summary string) (string, error) {
+	key := c.next(taskType, summary, "")
+	c.record("create %s %s %q", key, taskType, summary)
+	return key, nil
+}
+
+func (c *fakeClient) CreateTicketWithParent(_, taskType, summary, parentKey string) (string, error) {
+	key := c.next(taskType, summary, parentKey)
+	c.record("create %s %s %q parent %s", key, taskType, summary, parentKey)
+	return key, nil
+}
+
+func (c *fakeClient) CreateTicketWithEpicLink(_, taskType, summary, epicKey, _ string) (string, error) {
+	key := c.next(taskType, summary, epicKey)
+	c.record("create %s %s %q epic %s", key, taskType, summary, epicKey)
+	return key, nil
+}
+
+func (c *fakeClient) UpdateTicketFields(ticketID string, fields map[string]interface{}) error {
+	c.record("fields %s %v", ticketID, fields)
+	if summary, ok := fields["summary"].(string); ok {
+		c.tickets[ticketID].Summary = summary
+	}
+	return nil
+}
+
+func (c *fakeClient) RankIssues(issueKeys []string, afterKey string) error {
+	c.record("rank %v after %s", issueKeys, afterKey)
+	moved := map[string]bool{}
+	for _, key := range issueKeys {
+		moved[key] = true
+	}
+	var rank []string
+	for _, key := range c.rank {
+		if !moved[key] {
+			rank = append(rank, key)
+		}
+		if key == afterKey {
+			rank = append(rank, issueKeys...)
+		}
+	}
+	c.rank = rank
+	return nil
+}
+
+func (c *fakeClient) UpdateTicketDescription(ticketID, _ string) error {
+	c.record("description %s", ticketID)
+	return nil
+}
+
+func (c *fakeClient) UpdateTicketPoints(ticketID string, points int) error {
+	c.record("points %s %d", ticketID, points)
+	c.tickets[ticketID].StoryPoints = float64(points)
+	return nil
+}
+
+func (c *fakeClient) UpdateTicketComponents(ticketID string, ids []string) error {
+	c.record("components %s %v", ticketID, ids)
+	return nil
+}
+
+func (c *fakeClient) AssignTicket(ticketID, accountID, _ string) error {
+	c.record("assign %s %s", ticketID, accountID)
+	return nil
+}
+
+func (c *fakeClient) GetIssueLinkTypes() ([]jira.IssueLinkType, error) {
+	return []jira.IssueLinkType{{Name: "Blocks", Outward: "blocks", Inward: "is blocked by"}}, nil
+}
+
+func (c *fakeClient) LinkIssues(linkType, fromKey, toKey string) error {
+	c.record("link %s %s %s", fromKey, linkType, toKey)
+	return nil
+}
+
+type fakeResolver struct{}
+
+func (fakeResolver) User(query string) (jira.User, error) {
+	if query == "jane@example.com" {
+		return jira.User{AccountID: "jane-id", DisplayName: "Jane Doe"}, nil
+	}
+	return jira.User{}, fmt.Errorf("no user found for %q", query)
+}
+
+func (fakeResolver) ComponentID(_, name string) (string, error) {
+	if name == "UI" || name == "Backend" {
+		return "id-" + name, nil
+	}
+	return "", fmt.Errorf("component %q not found", name)
+}
+
+func TestParseYAML(t *testing.T) {
+	p, err := Parse([]byte(testYAML), FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	epic := p.Items[0]
+	if p.Project != "ENG" || epic.Type != "Epic" || epic.Description != "Get new users started.\n" {
+		t.Errorf("Unexpected epic: %+v", epic)
+	}
+	if welcome := epic.Children[0]; welcome.Type != "Story" || welcome.Points != 3 || welcome.Children[0].Type != "Sub-task" {
+		t.Errorf("Expected a Story with a Sub-task, got %+v", welcome)
+	}
+	if p.Find("login").Key != "ENG-7" {
+		t.Errorf("Expected to find the login page by its ID")
+	}
+
+	if _, err := Parse([]byte("items:\n  - summary: A\n    links: [blocks nowhere]\n"), FormatYAML); err == nil {
+		t.Error("Expected an error for a link to an unknown ID")
+	}
+}
+
+func TestParseMarkdown(t *testing.T) {
+	p, err := Parse([]byte(testMarkdown), FormatMarkdown)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if p.Project != "ENG" || len(p.Items) != 1 {
+		t.Fatalf("Expected one Epic in ENG, got %+v", p)
+	}
+	epic := p.Items[0]
+	if epic.Summary != "Onboarding" || epic.Description != "Get new users started." || len(epic.Children) != 2 {
+		t.Fatalf("Unexpected epic: %+v", epic)
+	}
+
+	welcome := epic.Children[0]
+	if welcome.Type != "Story" || welcome.ID != "welcome" || welcome.Points != 3 ||
+		strings.Join(welcome.Links, ",") != "blocks ENG-7" {
+		t.Errorf("Unexpected story fields: %+v", welcome)
+	}
+	if !strings.HasPrefix(welcome.Description, "Greets the user.") || !strings.Contains(welcome.Description, "# not a heading") {
+		t.Errorf("Expected the code block in the description, got %q", welcome.Description)
+	}
+	if len(welcome.Children) != 1 || welcome.Children[0].Type != "Sub-task" {
+		t.Errorf("Expected a Sub-task under the story, got %+v", welcome.Children)
+	}
+
+	login := epic.Children[1]
+	if login.Key != "ENG-7" || login.Summary != "Login page" || login.Type != "Story" ||
+		strings.Join(login.Components, ",") != "UI,Backend" {
+		t.Errorf("Unexpected login page: %+v", login)
+	}
+}
+
+func TestDiffAndApply(t *testing.T) {
+	p, err := Parse([]byte(testYAML), FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := newFakeClient(&jira.TicketDetails{Key: "ENG-7", Summary: "Login", Components: []string{"ui"}})
+	runner := NewRunner(client, fakeResolver{})
+	runner.EpicLinkField = "customfield_10014"
+
+	changes, err := runner.Diff(p)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	var out bytes.Buffer
+	Print(&out, p, changes)
+	for _, want := range []string{
+		`+ Epic "Onboarding"`,
+		`    + Story "Welcome page"`,
+		`          + link: blocks ENG-7`,
+		`    ~ ENG-7 "Login page"`,
+		`          summary: Login -> Login page`,
+		"Plan: 3 to create, 1 to update, 0 unchanged.",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected %q in the plan, got:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "components") {
+		t.Errorf("Expected components differing only in case to be unchanged, got:\n%s", out.String())
+	}
+
+	saves := 0
+	if err := runner.Apply(p, changes, func() error { saves++; return nil }); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	want := []string{
+		`create ENG-101 Epic "Onboarding"`,
+		`description ENG-101`,
+		`create ENG-102 Story "Welcome page" epic ENG-101`,
+		`points ENG-102 3`,
+		`assign ENG-102 jane-id`,
+		`create ENG-103 Sub-task "Copy text" parent ENG-102`,
+		`fields ENG-7 map[summary:Login page]`,
+		`link ENG-102 Blocks ENG-7`,
+	}
+	if strings.Join(client.calls, "\n") != strings.Join(want, "\n") || saves != 3 {
+		t.Errorf("Unexpected calls (%d saves):\n%s", saves, strings.Join(client.calls, "\n"))
+	}
+
+	data, err := p.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# Onboarding plan", "  - key: ENG-101\n    summary: Onboarding", "key: ENG-103", "# the words"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected %q in the saved plan, got:\n%s", want, data)
+		}
+	}
+}
+
+func TestDiffUnknownNames(t *testing.T) {
+	for name, yaml := range map[string]string{
+		"assignee":  "items:\n  - summary: A\n    assignee: nobody\n",
+		"component": "items:\n  - summary: A\n    components: [Mobile]\n",
+		"link":      "items:\n  - summary: A\n    links: [duplicates ENG-1]\n",
+	} {
+		p, err := Parse([]byte(yaml), FormatYAML)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := NewRunner(newFakeClient(), fakeResolver{}).Diff(p); err == nil {
+			t.Errorf("Expected an unknown %s to fail the diff", name)
+		}
+	}
+}
+
+func TestMarkdownKeysWrittenBack(t *testing.T) {
+	p, err := Parse([]byte(testMarkdown), FormatMarkdown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Items[0].Key = "ENG-101"
+	p.Items[0].Children[0].Children[0].Key = "ENG-103"
+
+	data, err := p.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(data)
+	for _, want := range []string{"# Epic: Onboarding (ENG-101)\n", "### Copy text (ENG-103)\n", "## Login page (ENG-7)\n", "# not a heading\n"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in the saved plan, got:\n%s", want, text)
+		}
+	}
+	if reparsed, err := Parse(data, FormatMarkdown); err != nil || reparsed.Items[0].Key != "ENG-101" {
+		t.Errorf("Expected the saved plan to parse with its keys, got %v", err)
+	}
+}
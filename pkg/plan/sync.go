@@ -0,0 +1,229 @@
+package plan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+// FieldOrder names the order of an item's children in conflicts
+const FieldOrder = "order"
+
+// ErrNotSynced is returned when syncing a plan that wasn't exported
+var ErrNotSynced = errors.New("the plan has no sync state: export it with 'jira export' first, or use 'jira apply'")
+
+// SyncState is the state of a plan's tickets at its last export or sync. Comparing it with the
+// file and with Jira tells changes made in the file from changes made in Jira.
+type SyncState struct {
+	At      time.Time            `yaml:"at"`
+	Tickets map[string]*Snapshot `yaml:"tickets"`
+}
+
+// Snapshot is the state of one ticket at the last export or sync
+type Snapshot struct {
+	Summary  string `yaml:"summary"`
+	Points   int    `yaml:"points,omitempty"`
+	Assignee string `yaml:"assignee,omitempty"`
+	// Description is a hash of the description, which is too long to repeat
+	Description string `yaml:"description,omitempty"`
+	// Children are the keys of the ticket's children, in order
+	Children []string `yaml:"children,omitempty"`
+}
+
+// NewSyncState records the tickets of a plan as they are in the file, which must match Jira
+func NewSyncState(p *Plan, at time.Time) *SyncState {
+	state := &SyncState{At: at.UTC().Truncate(time.Second), Tickets: map[string]*Snapshot{}}
+	p.Walk(func(item, _ *Item, _ int) {
+		if item.Key == "" {
+			return
+		}
+		state.Tickets[item.Key] = &Snapshot{
+			Summary:     item.Summary,
+			Points:      item.Points,
+			Assignee:    item.Assignee,
+			Description: descriptionHash(item.Description),
+			Children:    childKeys(item),
+		}
+	})
+	return state
+}
+
+// value returns the snapshot's value of a field as it appears in a FieldChange,
+// or false for fields that aren't tracked
+func (s *Snapshot) value(field string) (string, bool) {
+	switch field {
+	case FieldSummary:
+		return s.Summary, true
+	case FieldDescription:
+		return s.Description, true
+	case FieldPoints:
+		return pointsText(s.Points), true
+	case FieldAssignee:
+		return s.Assignee, true
+	}
+	return "", false
+}
+
+// snapshotValue returns a field's value as it's kept in a Snapshot
+func snapshotValue(field, value string) string {
+	if field == FieldDescription {
+		return descriptionHash(value)
+	}
+	return value
+}
+
+func descriptionHash(description string) string {
+	description = normalizeText(description)
+	if description == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(description))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+func childKeys(item *Item) []string {
+	var keys []string
+	for _, child := range item.Children {
+		if child.Key != "" {
+			keys = append(keys, child.Key)
+		}
+	}
+	return keys
+}
+
+// Conflict is a field changed both in the file and in Jira since the last export or sync
+type Conflict struct {
+	Key   string
+	Field string
+	File  string
+	Jira  string
+}
+
+func (c Conflict) String() string {
+	if c.Field == FieldDescription {
+		return fmt.Sprintf("%s: the description was changed in the file and in Jira", c.Key)
+	}
+	return fmt.Sprintf("%s: %s is %q in the file but was changed to %q in Jira", c.Key, c.Field, c.File, c.Jira)
+}
+
+// SyncResult is what syncing a plan does
+type SyncResult struct {
+	Changes   []*Change
+	Conflicts []Conflict
+	// InJira are the fields changed only in Jira, which are left as they are, e.g. "ENG-5 summary"
+	InJira []string
+}
+
+// Sync compares an exported plan with its last sync state and Jira. Fields changed only in the
+// file are pushed, fields changed only in Jira are left alone, and fields changed in both are
+// conflicts, which force settles in favour of the file. New items are created, and children
+// the file puts in a new order are ranked that way.
+func (r *Runner) Sync(p *Plan, force bool) (*SyncResult, error) {
+	if p.Synced == nil {
+		return nil, ErrNotSynced
+	}
+	changes, err := r.Diff(p)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SyncResult{Changes: changes}
+	for _, change := range changes {
+		snapshot := p.Synced.Tickets[change.Item.Key]
+		if change.Create || snapshot == nil {
+			// New in the file since the export, so there is nothing in Jira to conflict with
+			continue
+		}
+
+		var fields []FieldChange
+		for _, field := range change.Fields {
+			base, tracked := snapshot.value(field.Name)
+			local, remote := snapshotValue(field.Name, field.New), snapshotValue(field.Name, field.Old)
+			switch {
+			case !tracked:
+				fields = append(fields, field)
+			case sameValue(field.Name, local, base):
+				result.InJira = append(result.InJira, fmt.Sprintf("%s %s", change.Item.Key, field.Name))
+			case sameValue(field.Name, remote, base) || force:
+				fields = append(fields, field)
+			default:
+				result.Conflicts = append(result.Conflicts,
+					Conflict{Key: change.Item.Key, Field: field.Name, File: field.New, Jira: field.Old})
+			}
+		}
+		change.Fields = fields
+
+		if err := r.syncOrder(change, snapshot, force, result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func sameValue(field, a, b string) bool {
+	if field == FieldAssignee {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+// syncOrder ranks an item's children when the file reorders them or puts new ones between them
+func (r *Runner) syncOrder(change *Change, snapshot *Snapshot, force bool, result *SyncResult) error {
+	item := change.Item
+	local := childKeys(item)
+	base := only(snapshot.Children, local)
+	reordered := strings.Join(only(local, snapshot.Children), ",") != strings.Join(base, ",")
+
+	// New children are created last, so they only need ranking when they go before others
+	inserted, sawNew := false, false
+	for _, child := range item.Children {
+		if child.Key == "" {
+			sawNew = true
+		} else if sawNew {
+			inserted = true
+		}
+	}
+	if !reordered && !inserted {
+		return nil
+	}
+
+	if reordered && !force {
+		children, err := jira.GetChildTicketsDetailed(r.client, item.Key, r.EpicLinkField)
+		if err != nil {
+			return fmt.Errorf("failed to get the children of %s: %w", item.Key, err)
+		}
+		remote := make([]string, len(children))
+		for i := range children {
+			remote[i] = children[i].Key
+		}
+		remote = only(remote, local)
+		if order := strings.Join(remote, ","); order != strings.Join(base, ",") && order != strings.Join(local, ",") {
+			result.Conflicts = append(result.Conflicts, Conflict{
+				Key: item.Key, Field: FieldOrder, File: strings.Join(local, ", "), Jira: strings.Join(remote, ", "),
+			})
+			return nil
+		}
+	}
+	change.Reorder = true
+	return nil
+}
+
+// only returns the keys that are also in allowed, in their order
+func only(keys, allowed []string) []string {
+	in := map[string]bool{}
+	for _, key := range allowed {
+		in[key] = true
+	}
+	var kept []string
+	for _, key := range keys {
+		if in[key] {
+			kept = append(kept, key)
+		}
+	}
+	return kept
+}
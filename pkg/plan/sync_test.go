@@ -0,0 +1,192 @@
+package plan
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+func exportClient() *fakeClient {
+	return newFakeClient(
+		&jira.TicketDetails{Key: "ENG-1", Type: "Epic", Status: "In Progress", Summary: "Onboarding",
+			Description: "Get users started:\n# Sign up\n# Log in"},
+		&jira.TicketDetails{Key: "ENG-2", Type: "Story", Status: "To Do", Summary: "Welcome", Parent: "ENG-1",
+			StoryPoints: 3, Assignee: "Jane Doe"},
+		&jira.TicketDetails{Key: "ENG-3", Type: "Story", Status: "To Do", Summary: "Login", Parent: "ENG-1",
+			StoryPoints: 5},
+		&jira.TicketDetails{Key: "ENG-4", Type: "Sub-task", Status: "Done", Summary: "Copy", Parent: "ENG-2"},
+	)
+}
+
+// exported exports ENG-1 and parses the file back, as sync would load it
+func exported(t *testing.T, client *fakeClient, format string) *Plan {
+	t.Helper()
+	p, err := Export(client, "ENG-1", "", format)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	data, err := p.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := Parse(data, format)
+	if err != nil {
+		t.Fatalf("Failed to parse the exported plan: %v\n%s", err, data)
+	}
+	return parsed
+}
+
+func TestExportRoundTrip(t *testing.T) {
+	for _, format := range []string{FormatMarkdown, FormatYAML} {
+		p := exported(t, exportClient(), format)
+
+		epic := p.Items[0]
+		if p.Project != "ENG" || epic.Key != "ENG-1" || epic.Status != "In Progress" ||
+			epic.Description != "Get users started:\n# Sign up\n# Log in" {
+			t.Errorf("%s: unexpected epic %+v", format, epic)
+		}
+		if len(epic.Children) != 2 {
+			t.Fatalf("%s: expected two children, got %+v", format, epic.Children)
+		}
+		welcome := epic.Children[0]
+		if welcome.Key != "ENG-2" || welcome.Points != 3 || welcome.Assignee != "Jane Doe" ||
+			len(welcome.Children) != 1 || welcome.Children[0].Type != "Sub-task" {
+			t.Errorf("%s: unexpected story %+v", format, welcome)
+		}
+		if p.Synced == nil || p.Synced.Tickets["ENG-1"].Children[1] != "ENG-3" || p.Synced.Tickets["ENG-2"].Points != 3 {
+			t.Errorf("%s: unexpected sync state %+v", format, p.Synced)
+		}
+	}
+}
+
+// edited edits an exported Markdown plan: renames ENG-2, re-estimates ENG-3 and moves it first,
+// and adds a new story at the end
+func edited(t *testing.T, data []byte) *Plan {
+	t.Helper()
+	text := string(data)
+	welcome := strings.Index(text, "## Story: Welcome (ENG-2)")
+	login := strings.Index(text, "## Story: Login (ENG-3)")
+	synced := strings.Index(text, syncMarker)
+	if welcome < 0 || login < welcome || synced < login {
+		t.Fatalf("Unexpected export:\n%s", text)
+	}
+	text = text[:welcome] + strings.Replace(text[login:synced], "Points: 5", "Points: 8", 1) +
+		strings.Replace(text[welcome:login], "Welcome", "Welcome page", 1) + "## Story: Logout\n\n" + text[synced:]
+
+	p, err := Parse([]byte(text), FormatMarkdown)
+	if err != nil {
+		t.Fatalf("Failed to parse the edited plan: %v\n%s", err, text)
+	}
+	return p
+}
+
+func TestSync(t *testing.T) {
+	client := exportClient()
+	exportedPlan, err := Export(client, "ENG-1", "", FormatMarkdown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := exportedPlan.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := edited(t, data)
+
+	// Meanwhile in Jira
+	client.tickets["ENG-3"].Summary = "Sign in"
+	client.tickets["ENG-3"].StoryPoints = 13
+
+	runner := NewRunner(client, fakeResolver{})
+	result, err := runner.Sync(p, false)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0].Key != "ENG-3" || result.Conflicts[0].Field != FieldPoints {
+		t.Errorf("Expected the points of ENG-3 to conflict, got %+v", result.Conflicts)
+	}
+	if strings.Join(result.InJira, ",") != "ENG-3 summary" {
+		t.Errorf("Expected the summary of ENG-3 to be left as Jira has it, got %v", result.InJira)
+	}
+
+	result, err = runner.Sync(p, true)
+	if err != nil || len(result.Conflicts) != 0 {
+		t.Fatalf("Expected --force to settle the conflict, got %+v (err %v)", result, err)
+	}
+	var out bytes.Buffer
+	Print(&out, p, result.Changes)
+	if !strings.Contains(out.String(), `order: ENG-3, ENG-2, "Logout"`) {
+		t.Errorf("Expected the new order in the plan, got:\n%s", out.String())
+	}
+
+	if err := runner.Apply(p, result.Changes, func() error { return nil }); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	want := []string{
+		`points ENG-3 8`,
+		`fields ENG-2 map[summary:Welcome page]`,
+		`create ENG-101 Story "Logout" parent ENG-1`,
+		`rank [ENG-2 ENG-101] after ENG-3`,
+	}
+	if strings.Join(client.calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Unexpected calls:\n%s", strings.Join(client.calls, "\n"))
+	}
+
+	// Once synced, only Jira's own change is left
+	p.Synced = NewSyncState(p, p.Synced.At)
+	if data, err = p.Bytes(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "## Story: Welcome page (ENG-2)\n") || !strings.Contains(string(data), "## Story: Logout (ENG-101)\n") {
+		t.Errorf("Expected the new key in the file, got:\n%s", data)
+	}
+	resynced, err := Parse(data, FormatMarkdown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err = runner.Sync(resynced, false)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if create, update, _ := Summary(result.Changes); create+update != 0 || len(result.Conflicts) != 0 {
+		t.Errorf("Expected nothing left to sync, got %d to create, %d to update, conflicts %v", create, update, result.Conflicts)
+	}
+}
+
+func TestSyncOrderConflict(t *testing.T) {
+	client := exportClient()
+	p := exported(t, client, FormatYAML)
+
+	epic := p.Items[0]
+	epic.Children[0], epic.Children[1] = epic.Children[1], epic.Children[0]
+	// Someone adds a ticket in Jira and ranks it first; the order of ENG-2 and ENG-3 is unchanged
+	client.tickets["ENG-5"] = &jira.TicketDetails{Key: "ENG-5", Type: "Story", Summary: "Audit", Parent: "ENG-1"}
+	client.rank = append([]string{"ENG-5"}, client.rank...)
+
+	result, err := NewRunner(client, fakeResolver{}).Sync(p, false)
+	if err != nil || len(result.Conflicts) != 0 {
+		t.Fatalf("Expected a ticket added in Jira not to conflict, got %+v (err %v)", result, err)
+	}
+
+	// After exporting again, the file puts ENG-3 first while Jira puts ENG-2 first
+	p = exported(t, client, FormatYAML)
+	epic = p.Items[0]
+	epic.Children = []*Item{epic.Children[2], epic.Children[0], epic.Children[1]}
+	client.rank = []string{"ENG-1", "ENG-2", "ENG-5", "ENG-3", "ENG-4"}
+
+	result, err = NewRunner(client, fakeResolver{}).Sync(p, false)
+	if err != nil || len(result.Conflicts) != 1 || result.Conflicts[0].Field != FieldOrder {
+		t.Errorf("Expected an order conflict, got %+v (err %v)", result, err)
+	}
+}
+
+func TestSyncNotExported(t *testing.T) {
+	p, err := Parse([]byte(testYAML), FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewRunner(newFakeClient(), fakeResolver{}).Sync(p, false); err != ErrNotSynced {
+		t.Errorf("Expected ErrNotSynced, got %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Answers are pre-set answers to prompts, by prompt name
+type Answers map[string]string
+
+// settings decide how prompts are answered; set once per run with Configure
+var settings = struct {
+	mu          sync.Mutex
+	answers     Answers
+	interactive bool
+	assumeYes   bool
+}{interactive: true}
+
+// Configure sets how prompts are answered for the rest of the run
+// Prompts with an entry in answers use it. Otherwise they are read from the terminal if
+// interactive is set, and fail with a MissingInputError if not. With assumeYes, confirmations
+// are answered yes.
+func Configure(answers Answers, interactive, assumeYes bool) {
+	settings.mu.Lock()
+	defer settings.mu.Unlock()
+	settings.answers = answers
+	settings.interactive = interactive
+	settings.assumeYes = assumeYes
+}
+
+// Interactive checks if prompts can be answered at the terminal
+func Interactive() bool {
+	settings.mu.Lock()
+	defer settings.mu.Unlock()
+	return settings.interactive
+}
+
+func answer(name string) (string, bool) {
+	settings.mu.Lock()
+	defer settings.mu.Unlock()
+	value, ok := settings.answers[name]
+	return value, ok
+}
+
+// Answer returns the pre-set answer to the named prompt, if there is one
+func Answer(name string) (string, bool) {
+	return answer(name)
+}
+
+func assumeYes() bool {
+	settings.mu.Lock()
+	defer settings.mu.Unlock()
+	return settings.assumeYes
+}
+
+// LoadAnswers reads an answers file: a YAML map of prompt names to answers
+func LoadAnswers(path string) (Answers, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read answers file: %w", err)
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse answers file %s: %w", path, err)
+	}
+
+	answers := Answers{}
+	for name, value := range raw {
+		if value == nil {
+			answers[name] = ""
+			continue
+		}
+		answers[name] = fmt.Sprint(value)
+	}
+	return answers, nil
+}
+
+// MissingInputError is returned for a prompt that has no pre-set answer when stdin isn't a terminal
+type MissingInputError struct {
+	Name     string
+	Question string
+}
+
+func (e *MissingInputError) Error() string {
+	return fmt.Sprintf("input needed for %q (%s) but stdin is not a terminal; "+
+		"pass it with a flag or set %q in an --answers file", e.Name, e.Question, e.Name)
+}
+
+// Require fails with a MissingInputError if the named input can neither come from the answers
+// nor be typed in, for input read some other way than Line (e.g. with readline or an editor)
+func Require(name, question string) error {
+	if _, ok := answer(name); ok || Interactive() {
+		return nil
+	}
+	return missing(name, question)
+}
+
+// missing reports a prompt that can't be answered, naming it by its question without the "> " or ": " cue
+func missing(name, question string) *MissingInputError {
+	return &MissingInputError{Name: name, Question: strings.TrimRight(strings.TrimSpace(question), ">: ")}
+}
+
+// Line asks the named prompt and returns the answer without surrounding whitespace
+func Line(reader *bufio.Reader, name, question string) (string, error) {
+	if value, ok := answer(name); ok {
+		fmt.Printf("%s%s\n", question, value)
+		return strings.TrimSpace(value), nil
+	}
+	if !Interactive() {
+		return "", missing(name, question)
+	}
+
+	fmt.Print(question)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// Choose asks a question to go ahead with an action that has other answers besides yes,
+// e.g. [Y/n/e(dit)]. With --yes it is answered "y". The answer is returned in lower case
+func Choose(reader *bufio.Reader, name, question string) (string, error) {
+	if assumeYes() {
+		if _, ok := answer(name); !ok {
+			fmt.Printf("%sy\n", question)
+			return "y", nil
+		}
+	}
+
+	response, err := Line(reader, name, question)
+	return strings.ToLower(response), err
+}
+
+// Confirm asks a yes/no question to go ahead with an action; with --yes it is answered yes
+// An empty answer gives defaultYes
+func Confirm(reader *bufio.Reader, name, question string, defaultYes bool) (bool, error) {
+	response, err := Choose(reader, name, question)
+	if err != nil {
+		return false, err
+	}
+	return isYes(response, defaultYes), nil
+}
+
+// Offer asks a yes/no question about an optional extra (e.g. adding a ticket to a sprint)
+// When it can't be asked, the offer is declined rather than failing
+func Offer(reader *bufio.Reader, name, question string) (bool, error) {
+	if _, ok := answer(name); !ok && !Interactive() {
+		fmt.Printf("%sn\n", question)
+		return false, nil
+	}
+
+	response, err := Line(reader, name, question)
+	if err != nil {
+		return false, err
+	}
+	return isYes(response, false), nil
+}
+
+func isYes(response string, defaultYes bool) bool {
+	switch strings.ToLower(response) {
+	case "":
+		return defaultYes
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
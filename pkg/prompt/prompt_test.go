@@ -0,0 +1,97 @@
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLine(t *testing.T) {
+	defer Configure(nil, true, false)
+
+	t.Run("interactive", func(t *testing.T) {
+		Configure(nil, true, false)
+		got, err := Line(bufio.NewReader(strings.NewReader("  ENG-1 \n")), "parent", "> ")
+		if err != nil || got != "ENG-1" {
+			t.Errorf("Expected ENG-1, got %q (%v)", got, err)
+		}
+	})
+
+	t.Run("answered", func(t *testing.T) {
+		Configure(Answers{"parent": "ENG-2"}, false, false)
+		got, err := Line(bufio.NewReader(strings.NewReader("")), "parent", "> ")
+		if err != nil || got != "ENG-2" {
+			t.Errorf("Expected ENG-2, got %q (%v)", got, err)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		Configure(nil, false, false)
+		_, err := Line(bufio.NewReader(strings.NewReader("ENG-3\n")), "parent", "Parent ticket > ")
+		var missing *MissingInputError
+		if !errors.As(err, &missing) {
+			t.Fatalf("Expected a MissingInputError, got %v", err)
+		}
+		if missing.Name != "parent" || missing.Question != "Parent ticket" {
+			t.Errorf("Unexpected error details: %+v", missing)
+		}
+	})
+}
+
+func TestConfirmAndOffer(t *testing.T) {
+	defer Configure(nil, true, false)
+	empty := func() *bufio.Reader { return bufio.NewReader(strings.NewReader("")) }
+
+	Configure(nil, false, true)
+	if ok, err := Confirm(empty(), "apply", "Apply? [y/N] ", false); err != nil || !ok {
+		t.Errorf("Expected --yes to confirm, got %v (%v)", ok, err)
+	}
+	if choice, err := Choose(empty(), "update", "Update? [Y/n/e] "); err != nil || choice != "y" {
+		t.Errorf("Expected --yes to choose y, got %q (%v)", choice, err)
+	}
+
+	// A pre-set answer wins over --yes
+	Configure(Answers{"apply": "n"}, false, true)
+	if ok, err := Confirm(empty(), "apply", "Apply? [y/N] ", false); err != nil || ok {
+		t.Errorf("Expected the answer to decline, got %v (%v)", ok, err)
+	}
+
+	Configure(nil, false, false)
+	if _, err := Confirm(empty(), "apply", "Apply? [y/N] ", false); err == nil {
+		t.Error("Expected an unanswered confirmation to fail without a terminal")
+	}
+	if ok, err := Offer(empty(), "review", "Review? [y/N] "); err != nil || ok {
+		t.Errorf("Expected an unanswered offer to be declined, got %v (%v)", ok, err)
+	}
+
+	Configure(nil, true, false)
+	if ok, err := Confirm(bufio.NewReader(strings.NewReader("\n")), "apply", "Apply? [Y/n] ", true); err != nil || !ok {
+		t.Errorf("Expected an empty answer to give the default, got %v (%v)", ok, err)
+	}
+}
+
+func TestLoadAnswers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.yaml")
+	content := "parent: ENG-1\nmax_points: 5\nuse_ai: false\nticket_filter:\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	answers, err := LoadAnswers(path)
+	if err != nil {
+		t.Fatalf("LoadAnswers failed: %v", err)
+	}
+	want := Answers{"parent": "ENG-1", "max_points": "5", "use_ai": "false", "ticket_filter": ""}
+	for name, value := range want {
+		if answers[name] != value {
+			t.Errorf("Expected %s = %q, got %q", name, value, answers[name])
+		}
+	}
+
+	if _, err := LoadAnswers(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected an error for a missing answers file")
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/beekhof/jira-tool/pkg/gemini"
 	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/prompt"
 )
 
 const (
@@ -36,17 +37,34 @@ func RunQnAFlow(
 	client gemini.GeminiClient, initialContext string, maxQuestions int,
 	summaryOrKey, issueTypeName, existingDescription string,
 	jiraClient jira.JiraClient, ticketKey, epicLinkFieldID, answerInputMethod string,
+) (string, error) {
+	session := NewSession("", initialContext, summaryOrKey, issueTypeName, existingDescription,
+		jiraClient, ticketKey, epicLinkFieldID)
+	return RunSessionQnAFlow(client, session, nil, maxQuestions, answerInputMethod)
+}
+
+// RunSessionQnAFlow runs the Q&A flow for a new or resumed session
+// Questions already answered in the session count towards maxQuestions.
+// If store is non-nil, the session is saved after each answer so it can be resumed
+// if the terminal dies or Gemini fails; the caller deletes it once the result is used.
+func RunSessionQnAFlow(
+	client gemini.GeminiClient, session *Session, store *SessionStore,
+	maxQuestions int, answerInputMethod string,
 ) (string, error) {
 	answerInputMethod = validateInputMethod(answerInputMethod)
-	enhancedContext := buildEnhancedContext(initialContext, existingDescription, jiraClient, ticketKey, epicLinkFieldID)
 	maxQuestions = normalizeMaxQuestions(maxQuestions)
 
-	history, err := runQuestionLoop(client, enhancedContext, summaryOrKey, issueTypeName, maxQuestions, answerInputMethod)
-	if err != nil {
+	if err := runQuestionLoop(client, session, store, maxQuestions, answerInputMethod); err != nil {
 		return "", err
 	}
 
-	description, err := client.GenerateDescription(history, enhancedContext, summaryOrKey, issueTypeName)
+	return GenerateFromSession(client, session)
+}
+
+// GenerateFromSession generates the final description from the answers collected in a session
+func GenerateFromSession(client gemini.GeminiClient, session *Session) (string, error) {
+	description, err := client.GenerateDescription(
+		session.History, session.Context, session.SummaryOrKey, session.IssueType)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate description: %w", err)
 	}
@@ -113,48 +131,57 @@ func normalizeMaxQuestions(maxQuestions int) int {
 }
 
 func runQuestionLoop(
-	client gemini.GeminiClient, enhancedContext, summaryOrKey, issueTypeName string,
+	client gemini.GeminiClient, session *Session, store *SessionStore,
 	maxQuestions int, answerInputMethod string,
-) ([]string, error) {
-	history := []string{}
-
-	for i := 0; i < maxQuestions; i++ {
-		question, err := client.GenerateQuestion(history, enhancedContext, summaryOrKey, issueTypeName)
+) error {
+	for session.AnsweredCount() < maxQuestions {
+		question, err := client.GenerateQuestion(session.History, session.Context, session.SummaryOrKey, session.IssueType)
 		if err != nil {
-			return nil, fmt.Errorf("failed to generate question: %w", err)
+			return fmt.Errorf("failed to generate question: %w", err)
 		}
 
-		answer, shouldSkip, shouldDone, err := processQuestionAnswer(client, question, answerInputMethod)
+		answer, rejected, done, err := processQuestionAnswer(client, question, answerInputMethod)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		if shouldSkip {
-			if shouldDone {
-				break
-			}
-			history = append(history, fmt.Sprintf("Q: %s - REJECTED", question))
-			i--
-			continue
+		if done {
+			break
 		}
 
-		if shouldDone {
-			break
+		if rejected {
+			session.History = append(session.History, fmt.Sprintf("Q: %s - REJECTED", question))
+			continue
 		}
 
-		history = append(history, fmt.Sprintf("Q: %s", question), fmt.Sprintf("A: %s", answer))
+		session.History = append(session.History, fmt.Sprintf("Q: %s", question), fmt.Sprintf("A: %s", answer))
+
+		if store != nil {
+			if err := store.Save(session); err != nil {
+				_ = err // Ignore - resuming is optional
+			}
+		}
 	}
 
-	return history, nil
+	return nil
 }
 
 func processQuestionAnswer(
 	_ gemini.GeminiClient, question, answerInputMethod string,
-) (answer string, shouldSkip, shouldDone bool, err error) {
-	prompt := fmt.Sprintf("Gemini asks: %s? > ", question)
-	answer, err = ReadAnswerWithReadline(prompt, answerInputMethod)
-	if err != nil {
-		return "", false, false, fmt.Errorf("failed to read answer: %w", err)
+) (answer string, rejected, done bool, err error) {
+	question = fmt.Sprintf("Gemini asks: %s? > ", question)
+	if preset, ok := prompt.Answer("qa"); ok {
+		// The same pre-set answer goes to every question, so it is normally "done"
+		fmt.Printf("%s%s\n", question, preset)
+		answer = preset
+	} else {
+		if err := prompt.Require("qa", question); err != nil {
+			return "", false, false, err
+		}
+		answer, err = ReadAnswerWithReadline(question, answerInputMethod)
+		if err != nil {
+			return "", false, false, fmt.Errorf("failed to read answer: %w", err)
+		}
 	}
 
 	answer = trimSpace(answer)
@@ -166,14 +193,14 @@ func processQuestionAnswer(
 
 	if answer == "" || strings.EqualFold(answer, "reject") {
 		fmt.Println("Question rejected, generating a new one...")
-		return "", false, false, nil
+		return "", true, false, nil
 	}
 
 	if answer == "skip" || answer == "done" {
-		return "", true, true, nil
+		return "", false, true, nil
 	}
 
-	return answer, true, false, nil
+	return answer, false, false, nil
 }
 
 func addDescriptionFooter(description string) string {
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/beekhof/jira-tool/pkg/editor"
+	"github.com/beekhof/jira-tool/pkg/prompt"
 	"github.com/chzyer/readline"
 )
 
@@ -109,16 +110,13 @@ func PreviewAndEditLoop(answer, method string) (string, error) {
 
 	for {
 		fmt.Printf("\nYour answer: %s\n", answer)
-		fmt.Print("Edit? [y/N] ")
 
-		response, err := reader.ReadString('\n')
+		edit, err := prompt.Offer(reader, "qa_edit", "Edit? [y/N] ")
 		if err != nil {
 			return answer, err
 		}
 
-		response = strings.TrimSpace(strings.ToLower(response))
-
-		if response == "y" || response == "yes" {
+		if edit {
 			// Open editor
 			edited, err := editor.OpenInEditor(answer)
 			if err != nil {
@@ -0,0 +1,180 @@
+package qa
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+// Session is an in-progress Q&A flow, saved after each answer so it can be resumed
+type Session struct {
+	ID           string    `json:"id"`
+	Command      string    `json:"command"`
+	TicketKey    string    `json:"ticket_key"`
+	SummaryOrKey string    `json:"summary_or_key"`
+	IssueType    string    `json:"issue_type"`
+	Context      string    `json:"context"`
+	History      []string  `json:"history"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// SessionStore saves Q&A sessions as one JSON file per session
+type SessionStore struct {
+	dir string
+}
+
+// GetSessionsDir returns the directory used for saved Q&A sessions
+// If configDir is empty, uses the default ~/.jira-tool
+func GetSessionsDir(configDir string) string {
+	if configDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "./.jira-tool/sessions"
+		}
+		configDir = filepath.Join(homeDir, ".jira-tool")
+	}
+	return filepath.Join(configDir, "sessions")
+}
+
+// NewSessionStore creates a session store in dir
+func NewSessionStore(dir string) *SessionStore {
+	return &SessionStore{dir: dir}
+}
+
+// SessionID returns the ID of the session for a command and ticket
+// There is at most one unfinished session per command and ticket
+func SessionID(command, ticketKey string) string {
+	return command + "-" + ticketKey
+}
+
+// NewSession starts a new Q&A session, building the same context RunQnAFlow would
+func NewSession(
+	command, initialContext, summaryOrKey, issueTypeName, existingDescription string,
+	jiraClient jira.JiraClient, ticketKey, epicLinkFieldID string,
+) *Session {
+	now := time.Now()
+	return &Session{
+		ID:           SessionID(command, ticketKey),
+		Command:      command,
+		TicketKey:    ticketKey,
+		SummaryOrKey: summaryOrKey,
+		IssueType:    issueTypeName,
+		Context:      buildEnhancedContext(initialContext, existingDescription, jiraClient, ticketKey, epicLinkFieldID),
+		History:      []string{},
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// AnsweredCount returns the number of questions answered so far
+func (s *Session) AnsweredCount() int {
+	count := 0
+	for _, entry := range s.History {
+		if strings.HasPrefix(entry, "A: ") {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *SessionStore) sessionPath(id string) string {
+	// Ticket keys are safe file names, but guard against path separators anyway
+	return filepath.Join(s.dir, strings.ReplaceAll(id, string(filepath.Separator), "_")+".json")
+}
+
+// Save writes a session, replacing any previous copy
+func (s *SessionStore) Save(session *Session) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create sessions directory: %w", err)
+	}
+
+	session.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	if err := os.WriteFile(s.sessionPath(session.ID), data, 0600); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+
+	return nil
+}
+
+// Load reads a session by ID
+// Returns nil (not an error) if no such session exists
+func (s *SessionStore) Load(id string) (*Session, error) {
+	data, err := os.ReadFile(s.sessionPath(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to parse session %s: %w", id, err)
+	}
+
+	return &session, nil
+}
+
+// Delete removes a session; deleting a missing session is not an error
+func (s *SessionStore) Delete(id string) error {
+	if err := os.Remove(s.sessionPath(id)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// List returns all saved sessions, most recently updated first
+func (s *SessionStore) List() ([]*Session, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Session{}, nil
+		}
+		return nil, fmt.Errorf("failed to read sessions directory: %w", err)
+	}
+
+	sessions := []*Session{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		session, err := s.Load(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil || session == nil {
+			// Skip unreadable sessions rather than failing the whole listing
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].UpdatedAt.After(sessions[j].UpdatedAt)
+	})
+
+	return sessions, nil
+}
+
+// Latest returns the most recently updated session for a command, or nil if there is none
+func (s *SessionStore) Latest(command string) (*Session, error) {
+	sessions, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		if session.Command == command {
+			return session, nil
+		}
+	}
+	return nil, nil
+}
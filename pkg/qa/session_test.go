@@ -0,0 +1,76 @@
+package qa
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionStore(t *testing.T) {
+	store := NewSessionStore(t.TempDir())
+
+	// Nothing saved yet
+	session, err := store.Load(SessionID("describe", "ENG-1"))
+	if err != nil {
+		t.Fatalf("Failed to load missing session: %v", err)
+	}
+	if session != nil {
+		t.Fatal("Expected nil session when none is saved")
+	}
+
+	first := NewSession("describe", "Fix login", "Fix login", "Task", "", nil, "ENG-1", "")
+	first.History = append(first.History, "Q: Why? - REJECTED", "Q: What?", "A: The login button")
+	if err := store.Save(first); err != nil {
+		t.Fatalf("Failed to save session: %v", err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	second := NewSession("create", "Add logout", "Add logout", "Task", "", nil, "ENG-2", "")
+	if err := store.Save(second); err != nil {
+		t.Fatalf("Failed to save session: %v", err)
+	}
+
+	loaded, err := store.Load("describe-ENG-1")
+	if err != nil || loaded == nil {
+		t.Fatalf("Failed to load saved session: %v", err)
+	}
+	if loaded.AnsweredCount() != 1 {
+		t.Errorf("Expected 1 answered question, got %d", loaded.AnsweredCount())
+	}
+	if loaded.Context != "Fix login" || loaded.IssueType != "Task" {
+		t.Errorf("Unexpected loaded session: %+v", loaded)
+	}
+
+	sessions, err := store.List()
+	if err != nil {
+		t.Fatalf("Failed to list sessions: %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].ID != "create-ENG-2" {
+		t.Errorf("Expected 2 sessions, most recent first, got %d", len(sessions))
+	}
+
+	latest, err := store.Latest("describe")
+	if err != nil || latest == nil || latest.TicketKey != "ENG-1" {
+		t.Errorf("Expected latest describe session for ENG-1, got %+v (err %v)", latest, err)
+	}
+
+	if err := store.Delete("describe-ENG-1"); err != nil {
+		t.Fatalf("Failed to delete session: %v", err)
+	}
+	if err := store.Delete("describe-ENG-1"); err != nil {
+		t.Errorf("Deleting a missing session should not fail: %v", err)
+	}
+	latest, err = store.Latest("describe")
+	if err != nil || latest != nil {
+		t.Errorf("Expected no describe session after delete, got %+v (err %v)", latest, err)
+	}
+}
+
+func TestNewSessionIncludesExistingDescription(t *testing.T) {
+	session := NewSession("describe", "Summary", "Summary", "Task", "Old text", nil, "ENG-3", "")
+	if session.ID != "describe-ENG-3" {
+		t.Errorf("Expected ID describe-ENG-3, got %s", session.ID)
+	}
+	if session.Context == "Summary" {
+		t.Error("Expected context to include the existing description")
+	}
+}
@@ -0,0 +1,201 @@
+// Package releasenotes builds release notes from the tickets in a release and the commits made for it
+package releasenotes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/beekhof/jira-tool/pkg/git"
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+// NoComponent names the group of tickets without a component
+const NoComponent = "Other"
+
+// typeOrder is the order of the usual issue types in the notes; others follow alphabetically
+var typeOrder = []string{"Epic", "Feature", "Story", "Improvement", "Bug", "Task", "Sub-task"}
+
+// Entry is a ticket in the notes, with the commits that mention it
+type Entry struct {
+	Key     string
+	Summary string
+	Status  string
+	Commits []git.Commit
+}
+
+// ComponentGroup is the tickets of one issue type in one component
+type ComponentGroup struct {
+	Name    string
+	Entries []Entry
+}
+
+// Group is the tickets of one issue type, by component
+type Group struct {
+	Type       string
+	Summary    string // Optional summary for users, e.g. written by AI
+	Components []ComponentGroup
+}
+
+// Entries returns the tickets of every component in the group
+func (g *Group) Entries() []Entry {
+	var entries []Entry
+	for _, component := range g.Components {
+		entries = append(entries, component.Entries...)
+	}
+	return entries
+}
+
+// Notes are the release notes for a version
+type Notes struct {
+	Version string
+	BaseURL string // Jira URL to link tickets to; empty for no links
+	Groups  []Group
+
+	// CrossReferenced is set when commits were checked, and the lists below are filled in
+	CrossReferenced bool
+	// CommitsWithoutTickets mention no ticket key
+	CommitsWithoutTickets []git.Commit
+	// CommitsForOtherTickets only mention tickets that aren't in the release
+	CommitsForOtherTickets []git.Commit
+	// TicketsWithoutCommits aren't mentioned by any commit
+	TicketsWithoutCommits []Entry
+}
+
+// TicketURL returns the link to a ticket, or "" without a base URL
+func (n *Notes) TicketURL(key string) string {
+	if n.BaseURL == "" {
+		return ""
+	}
+	return strings.TrimRight(n.BaseURL, "/") + "/browse/" + key
+}
+
+// Build groups the tickets of a release by issue type and component. With commits (crossReference set),
+// each ticket lists the commits that mention its key, and commits and tickets that don't match up are
+// listed separately. A ticket with several components appears under each of them.
+func Build(version string, issues []jira.Issue, commits []git.Commit, crossReference bool) *Notes {
+	notes := &Notes{Version: version, CrossReferenced: crossReference}
+
+	inRelease := make(map[string]bool, len(issues))
+	for i := range issues {
+		inRelease[issues[i].Key] = true
+	}
+
+	commitsByKey := map[string][]git.Commit{}
+	for _, commit := range commits {
+		keys := jira.FindTicketKeys(commit.Message())
+		if len(keys) == 0 {
+			notes.CommitsWithoutTickets = append(notes.CommitsWithoutTickets, commit)
+			continue
+		}
+		matched := false
+		for _, key := range keys {
+			if inRelease[key] {
+				commitsByKey[key] = append(commitsByKey[key], commit)
+				matched = true
+			}
+		}
+		if !matched {
+			notes.CommitsForOtherTickets = append(notes.CommitsForOtherTickets, commit)
+		}
+	}
+
+	byType := map[string]map[string][]Entry{}
+	for i := range issues {
+		issue := &issues[i]
+		entry := Entry{
+			Key:     issue.Key,
+			Summary: issue.Fields.Summary,
+			Status:  issue.Fields.Status.Name,
+			Commits: commitsByKey[issue.Key],
+		}
+		if crossReference && len(entry.Commits) == 0 {
+			notes.TicketsWithoutCommits = append(notes.TicketsWithoutCommits, entry)
+		}
+
+		issueType := issue.Fields.IssueType.Name
+		if byType[issueType] == nil {
+			byType[issueType] = map[string][]Entry{}
+		}
+		components := byType[issueType]
+		if len(issue.Fields.Components) == 0 {
+			components[NoComponent] = append(components[NoComponent], entry)
+		}
+		for _, component := range issue.Fields.Components {
+			components[component.Name] = append(components[component.Name], entry)
+		}
+	}
+
+	for _, issueType := range sortedTypes(byType) {
+		group := Group{Type: issueType}
+		for _, name := range sortedComponents(byType[issueType]) {
+			entries := byType[issueType][name]
+			sort.SliceStable(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+			group.Components = append(group.Components, ComponentGroup{Name: name, Entries: entries})
+		}
+		notes.Groups = append(notes.Groups, group)
+	}
+	return notes
+}
+
+// sortedTypes orders issue types by typeOrder, then alphabetically
+func sortedTypes(byType map[string]map[string][]Entry) []string {
+	rank := func(issueType string) int {
+		for i, known := range typeOrder {
+			if strings.EqualFold(issueType, known) {
+				return i
+			}
+		}
+		return len(typeOrder)
+	}
+
+	types := make([]string, 0, len(byType))
+	for issueType := range byType {
+		types = append(types, issueType)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if rank(types[i]) != rank(types[j]) {
+			return rank(types[i]) < rank(types[j])
+		}
+		return types[i] < types[j]
+	})
+	return types
+}
+
+// sortedComponents orders components alphabetically, with tickets without a component last
+func sortedComponents(components map[string][]Entry) []string {
+	names := make([]string, 0, len(components))
+	for name := range components {
+		if name != NoComponent {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := components[NoComponent]; ok {
+		names = append(names, NoComponent)
+	}
+	return names
+}
+
+// Summarize sets the summary of each group from summarize, given the group's issue type and
+// the summaries of its tickets. It stops at the first error, keeping the summaries made so far.
+func Summarize(notes *Notes, summarize func(group string, tickets []string) (string, error)) error {
+	for i := range notes.Groups {
+		group := &notes.Groups[i]
+		seen := map[string]bool{}
+		var tickets []string
+		for _, entry := range group.Entries() {
+			if !seen[entry.Key] {
+				seen[entry.Key] = true
+				tickets = append(tickets, entry.Summary)
+			}
+		}
+
+		summary, err := summarize(group.Type, tickets)
+		if err != nil {
+			return fmt.Errorf("failed to summarize %s tickets: %w", group.Type, err)
+		}
+		group.Summary = summary
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package releasenotes
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/beekhof/jira-tool/pkg/git"
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+func testIssue(key, summary, issueType, status string, components ...string) jira.Issue {
+	issue := jira.Issue{Key: key}
+	issue.Fields.Summary = summary
+	issue.Fields.IssueType.Name = issueType
+	issue.Fields.Status.Name = status
+	for _, name := range components {
+		issue.Fields.Components = append(issue.Fields.Components, struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		}{Name: name})
+	}
+	return issue
+}
+
+func testNotes() *Notes {
+	issues := []jira.Issue{
+		testIssue("ENG-3", "Crash on start", "Bug", "Done", "Backend"),
+		testIssue("ENG-1", "Dark mode", "Story", "Done", "UI", "Backend"),
+		testIssue("ENG-2", "Export to CSV", "Story", "In Progress"),
+		testIssue("ENG-4", "Tidy the build", "Chore", "Done"),
+	}
+	commits := []git.Commit{
+		{Hash: "1111111aaaa", Subject: "ENG-1: Add dark mode"},
+		{Hash: "2222222bbbb", Subject: "Fix the crash", Body: "Fixes ENG-3 and ENG-1"},
+		{Hash: "3333333cccc", Subject: "Bump dependencies"},
+		{Hash: "4444444dddd", Subject: "OPS-9: Rotate certificates"},
+	}
+	return Build("1.2", issues, commits, true)
+}
+
+func TestBuild(t *testing.T) {
+	notes := testNotes()
+
+	var types []string
+	for _, group := range notes.Groups {
+		types = append(types, group.Type)
+	}
+	if strings.Join(types, ",") != "Story,Bug,Chore" {
+		t.Fatalf("Expected known types first, got %v", types)
+	}
+
+	story := notes.Groups[0]
+	if len(story.Components) != 3 || story.Components[0].Name != "Backend" || story.Components[2].Name != NoComponent {
+		t.Fatalf("Expected Backend, UI and no component, got %+v", story.Components)
+	}
+	if entry := story.Components[0].Entries[0]; entry.Key != "ENG-1" || len(entry.Commits) != 2 {
+		t.Errorf("Expected ENG-1 with two commits, got %+v", entry)
+	}
+
+	if len(notes.TicketsWithoutCommits) != 2 || notes.TicketsWithoutCommits[0].Key != "ENG-2" {
+		t.Errorf("Expected ENG-2 and ENG-4 without commits, got %+v", notes.TicketsWithoutCommits)
+	}
+	if len(notes.CommitsWithoutTickets) != 1 || notes.CommitsWithoutTickets[0].Subject != "Bump dependencies" {
+		t.Errorf("Expected one commit without a ticket, got %+v", notes.CommitsWithoutTickets)
+	}
+	if len(notes.CommitsForOtherTickets) != 1 || notes.CommitsForOtherTickets[0].Hash != "4444444dddd" {
+		t.Errorf("Expected the OPS-9 commit, got %+v", notes.CommitsForOtherTickets)
+	}
+
+	if plain := Build("1.2", nil, nil, false); len(plain.Groups) != 0 || plain.TicketsWithoutCommits != nil {
+		t.Errorf("Expected empty notes, got %+v", plain)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	notes := testNotes()
+	var storyTickets []string
+	err := Summarize(notes, func(group string, tickets []string) (string, error) {
+		if group == "Story" {
+			storyTickets = tickets
+		}
+		return "Summary of " + group, nil
+	})
+	if err != nil {
+		t.Fatalf("Summarize failed: %v", err)
+	}
+	if strings.Join(storyTickets, ",") != "Dark mode,Export to CSV" {
+		t.Errorf("Expected each story once, got %v", storyTickets)
+	}
+	if notes.Groups[1].Summary != "Summary of Bug" {
+		t.Errorf("Expected the bug summary, got %q", notes.Groups[1].Summary)
+	}
+
+	err = Summarize(notes, func(string, []string) (string, error) { return "", errors.New("quota") })
+	if err == nil || !strings.Contains(err.Error(), "Story") {
+		t.Errorf("Expected an error naming the group, got %v", err)
+	}
+}
+
+func TestRender(t *testing.T) {
+	notes := testNotes()
+	notes.BaseURL = "https://jira.example.com/"
+	notes.Groups[1].Summary = "Fewer crashes & hangs"
+
+	var md strings.Builder
+	if err := Render(&md, notes, FormatMarkdown); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# Release notes: 1.2\n",
+		"## Story\n\n### Backend\n\n- [ENG-1](https://jira.example.com/browse/ENG-1) Dark mode (`1111111`, `2222222`)\n",
+		"## Bug\n\nFewer crashes & hangs\n",
+		"## Tickets without commits\n\n- [ENG-2](https://jira.example.com/browse/ENG-2) Export to CSV (In Progress)\n",
+		"## Commits without tickets\n\n- `3333333` Bump dependencies\n",
+		"## Commits for tickets not in this release\n\n- `4444444` OPS-9: Rotate certificates\n",
+	} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("Expected Markdown to contain %q, got:\n%s", want, md.String())
+		}
+	}
+
+	var page strings.Builder
+	if err := Render(&page, notes, FormatHTML); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"<h1>Release notes: 1.2</h1>",
+		`<li><a href="https://jira.example.com/browse/ENG-1">ENG-1</a> Dark mode (<code>1111111</code>, <code>2222222</code>)</li>`,
+		"<p>Fewer crashes &amp; hangs</p>",
+		"<h2>Commits without tickets</h2>",
+	} {
+		if !strings.Contains(page.String(), want) {
+			t.Errorf("Expected HTML to contain %q, got:\n%s", want, page.String())
+		}
+	}
+
+	if err := Render(&page, notes, "pdf"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,164 @@
+package releasenotes
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/beekhof/jira-tool/pkg/git"
+)
+
+// Formats the notes can be rendered in
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// Render writes the notes in the given format
+func Render(w io.Writer, notes *Notes, format string) error {
+	switch format {
+	case FormatMarkdown:
+		return RenderMarkdown(w, notes)
+	case FormatHTML:
+		return RenderHTML(w, notes)
+	default:
+		return fmt.Errorf("unknown format %q (expected markdown or html)", format)
+	}
+}
+
+// RenderMarkdown writes the notes as Markdown
+func RenderMarkdown(w io.Writer, notes *Notes) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Release notes: %s\n", notes.Version)
+	if len(notes.Groups) == 0 {
+		b.WriteString("\nNo tickets in this release.\n")
+	}
+
+	for _, group := range notes.Groups {
+		fmt.Fprintf(&b, "\n## %s\n", group.Type)
+		if group.Summary != "" {
+			fmt.Fprintf(&b, "\n%s\n", group.Summary)
+		}
+		for _, component := range group.Components {
+			fmt.Fprintf(&b, "\n### %s\n\n", component.Name)
+			for _, entry := range component.Entries {
+				fmt.Fprintf(&b, "- %s %s%s\n", markdownKey(notes, entry.Key), entry.Summary, markdownCommits(entry.Commits))
+			}
+		}
+	}
+
+	if notes.CrossReferenced {
+		if len(notes.TicketsWithoutCommits) > 0 {
+			b.WriteString("\n## Tickets without commits\n\n")
+			for _, entry := range notes.TicketsWithoutCommits {
+				fmt.Fprintf(&b, "- %s %s (%s)\n", markdownKey(notes, entry.Key), entry.Summary, entry.Status)
+			}
+		}
+		writeMarkdownCommits(&b, "Commits without tickets", notes.CommitsWithoutTickets)
+		writeMarkdownCommits(&b, "Commits for tickets not in this release", notes.CommitsForOtherTickets)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func markdownKey(notes *Notes, key string) string {
+	if url := notes.TicketURL(key); url != "" {
+		return fmt.Sprintf("[%s](%s)", key, url)
+	}
+	return key
+}
+
+func markdownCommits(commits []git.Commit) string {
+	if len(commits) == 0 {
+		return ""
+	}
+	hashes := make([]string, len(commits))
+	for i := range commits {
+		hashes[i] = "`" + commits[i].ShortHash() + "`"
+	}
+	return " (" + strings.Join(hashes, ", ") + ")"
+}
+
+func writeMarkdownCommits(b *strings.Builder, title string, commits []git.Commit) {
+	if len(commits) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s\n\n", title)
+	for i := range commits {
+		fmt.Fprintf(b, "- `%s` %s\n", commits[i].ShortHash(), commits[i].Subject)
+	}
+}
+
+// RenderHTML writes the notes as a standalone HTML page
+func RenderHTML(w io.Writer, notes *Notes) error {
+	var b strings.Builder
+	title := "Release notes: " + html.EscapeString(notes.Version)
+	fmt.Fprintf(&b, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n</head>\n<body>\n", title)
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", title)
+	if len(notes.Groups) == 0 {
+		b.WriteString("<p>No tickets in this release.</p>\n")
+	}
+
+	for _, group := range notes.Groups {
+		fmt.Fprintf(&b, "<h2>%s</h2>\n", html.EscapeString(group.Type))
+		if group.Summary != "" {
+			fmt.Fprintf(&b, "<p>%s</p>\n", html.EscapeString(group.Summary))
+		}
+		for _, component := range group.Components {
+			fmt.Fprintf(&b, "<h3>%s</h3>\n<ul>\n", html.EscapeString(component.Name))
+			for _, entry := range component.Entries {
+				fmt.Fprintf(&b, "<li>%s %s%s</li>\n",
+					htmlKey(notes, entry.Key), html.EscapeString(entry.Summary), htmlCommits(entry.Commits))
+			}
+			b.WriteString("</ul>\n")
+		}
+	}
+
+	if notes.CrossReferenced {
+		if len(notes.TicketsWithoutCommits) > 0 {
+			b.WriteString("<h2>Tickets without commits</h2>\n<ul>\n")
+			for _, entry := range notes.TicketsWithoutCommits {
+				fmt.Fprintf(&b, "<li>%s %s (%s)</li>\n",
+					htmlKey(notes, entry.Key), html.EscapeString(entry.Summary), html.EscapeString(entry.Status))
+			}
+			b.WriteString("</ul>\n")
+		}
+		writeHTMLCommits(&b, "Commits without tickets", notes.CommitsWithoutTickets)
+		writeHTMLCommits(&b, "Commits for tickets not in this release", notes.CommitsForOtherTickets)
+	}
+	b.WriteString("</body>\n</html>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func htmlKey(notes *Notes, key string) string {
+	if url := notes.TicketURL(key); url != "" {
+		return fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(url), html.EscapeString(key))
+	}
+	return html.EscapeString(key)
+}
+
+func htmlCommits(commits []git.Commit) string {
+	if len(commits) == 0 {
+		return ""
+	}
+	hashes := make([]string, len(commits))
+	for i := range commits {
+		hashes[i] = "<code>" + commits[i].ShortHash() + "</code>"
+	}
+	return " (" + strings.Join(hashes, ", ") + ")"
+}
+
+func writeHTMLCommits(b *strings.Builder, title string, commits []git.Commit) {
+	if len(commits) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "<h2>%s</h2>\n<ul>\n", title)
+	for i := range commits {
+		fmt.Fprintf(b, "<li><code>%s</code> %s</li>\n", commits[i].ShortHash(), html.EscapeString(commits[i].Subject))
+	}
+	b.WriteString("</ul>\n")
+}
@@ -4,11 +4,12 @@ import (
 	"bufio"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/beekhof/jira-tool/pkg/config"
 	"github.com/beekhof/jira-tool/pkg/gemini"
 	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/prompt"
+	"github.com/beekhof/jira-tool/pkg/tui"
 )
 
 // HandleAssignmentStep handles ticket assignment with auto-actions (transition, sprint, release)
@@ -24,14 +25,11 @@ func HandleAssignmentStep(
 	}
 
 	// Prompt for assignment
-	fmt.Print("Assign this ticket? [y/N] ")
-	response, err := reader.ReadString('\n')
+	assign, err := prompt.Offer(reader, "assign", "Assign this ticket? [y/N] ")
 	if err != nil {
 		return false, err
 	}
-	response = strings.TrimSpace(strings.ToLower(response))
-
-	if response != "y" && response != "yes" {
+	if !assign {
 		return true, nil // Step complete, assignment skipped
 	}
 
@@ -56,7 +54,7 @@ func HandleAssignmentStep(
 	}
 
 	// Auto-actions after assignment
-	transitionToInProgress(client, ticket.Key)
+	TransitionToInProgress(client, ticket.Key)
 	projectKey := cfg.DefaultProject
 	if projectKey != "" {
 		handleSprintAssignment(client, reader, cfg, projectKey, ticket.Key, state, statePath)
@@ -74,10 +72,12 @@ func HandleAssignmentStep(
 	return true, nil
 }
 
-func transitionToInProgress(client jira.JiraClient, ticketKey string) {
+// TransitionToInProgress moves a ticket to In Progress if its workflow allows it,
+// and reports whether it did
+func TransitionToInProgress(client jira.JiraClient, ticketKey string) bool {
 	transitions, err := client.GetTransitions(ticketKey)
 	if err != nil {
-		return
+		return false
 	}
 
 	var inProgressTransitionID string
@@ -88,11 +88,14 @@ func transitionToInProgress(client jira.JiraClient, ticketKey string) {
 		}
 	}
 
-	if inProgressTransitionID != "" {
-		if err := client.TransitionTicket(ticketKey, inProgressTransitionID); err != nil {
-			fmt.Printf("Warning: Could not transition to 'In Progress': %v\n", err)
-		}
+	if inProgressTransitionID == "" {
+		return false
+	}
+	if err := client.TransitionTicket(ticketKey, inProgressTransitionID); err != nil {
+		fmt.Printf("Warning: Could not transition to 'In Progress': %v\n", err)
+		return false
 	}
+	return true
 }
 
 func handleSprintAssignment(
@@ -136,12 +139,34 @@ func handleSprintAssignment(
 func selectSprintForAssignment(
 	reader *bufio.Reader, allSprints []jira.SprintParsed, recent []string,
 ) (sprintID int, sprintName string) {
+	if tui.Pickable("sprint") {
+		return pickSprint(allSprints, recent)
+	}
 	if len(recent) > 0 {
 		return selectSprintWithRecent(reader, allSprints, recent)
 	}
 	return selectSprintFromList(reader, allSprints)
 }
 
+// pickSprint chooses a sprint with the picker, recent sprints first
+func pickSprint(allSprints []jira.SprintParsed, recent []string) (sprintID int, sprintName string) {
+	items := make([]tui.PickItem, 0, len(allSprints))
+	for _, sprint := range allSprints {
+		items = append(items, tui.PickItem{Label: sprint.Name, Detail: sprint.State, Value: strconv.Itoa(sprint.ID)})
+	}
+	chosen, err := tui.Pick(tui.NewPicker("Select sprint", tui.PinRecent(items, recent), false))
+	if err != nil || len(chosen) == 0 {
+		return 0, ""
+	}
+	// Sprints are matched by ID, as different boards can have sprints with the same name
+	for _, sprint := range allSprints {
+		if strconv.Itoa(sprint.ID) == chosen[0].Value {
+			return sprint.ID, sprint.Name
+		}
+	}
+	return 0, ""
+}
+
 func selectSprintWithRecent(
 	reader *bufio.Reader, allSprints []jira.SprintParsed, recent []string,
 ) (sprintID int, sprintName string) {
@@ -150,13 +175,10 @@ func selectSprintWithRecent(
 		fmt.Printf("[%d] %s\n", i+1, sprintName)
 	}
 	fmt.Printf("[%d] Other...\n", len(recent)+1)
-	fmt.Print("> ")
-
-	choice, err := reader.ReadString('\n')
+	choice, err := prompt.Line(reader, "sprint_recent", "> ")
 	if err != nil {
 		return 0, ""
 	}
-	choice = strings.TrimSpace(choice)
 	selected, err := strconv.Atoi(choice)
 	if err != nil {
 		return 0, ""
@@ -184,13 +206,11 @@ func selectSprintFromList(reader *bufio.Reader, allSprints []jira.SprintParsed)
 	for i, sprint := range allSprints {
 		fmt.Printf("[%d] %s\n", i+1, sprint.Name)
 	}
-	fmt.Print("> ")
-
-	choice, err := reader.ReadString('\n')
+	choice, err := prompt.Line(reader, "sprint", "> ")
 	if err != nil {
 		return 0, ""
 	}
-	selected, err := strconv.Atoi(strings.TrimSpace(choice))
+	selected, err := strconv.Atoi(choice)
 	if err != nil || selected < 1 || selected > len(allSprints) {
 		return 0, ""
 	}
@@ -235,12 +255,32 @@ func handleReleaseAssignment(
 func selectReleaseForAssignment(
 	reader *bufio.Reader, unreleased []jira.ReleaseParsed, recent []string,
 ) (releaseID, releaseName string) {
+	if tui.Pickable("release") {
+		return pickRelease(unreleased, recent)
+	}
 	if len(recent) > 0 {
 		return selectReleaseWithRecent(reader, unreleased, recent)
 	}
 	return selectReleaseFromList(reader, unreleased)
 }
 
+// pickRelease chooses a release with the picker, recent releases first
+func pickRelease(unreleased []jira.ReleaseParsed, recent []string) (releaseID, releaseName string) {
+	items := make([]tui.PickItem, 0, len(unreleased))
+	for _, release := range unreleased {
+		item := tui.PickItem{Label: release.Name, Value: release.ID}
+		if !release.ReleaseDate.IsZero() {
+			item.Detail = release.ReleaseDate.Format("2006-01-02")
+		}
+		items = append(items, item)
+	}
+	chosen, err := tui.Pick(tui.NewPicker("Select release", tui.PinRecent(items, recent), false))
+	if err != nil || len(chosen) == 0 {
+		return "", ""
+	}
+	return chosen[0].Value, chosen[0].Label
+}
+
 func selectReleaseWithRecent(
 	reader *bufio.Reader, unreleased []jira.ReleaseParsed, recent []string,
 ) (releaseID, releaseName string) {
@@ -249,13 +289,10 @@ func selectReleaseWithRecent(
 		fmt.Printf("[%d] %s\n", i+1, releaseName)
 	}
 	fmt.Printf("[%d] Other...\n", len(recent)+1)
-	fmt.Print("> ")
-
-	choice, err := reader.ReadString('\n')
+	choice, err := prompt.Line(reader, "release_recent", "> ")
 	if err != nil {
 		return "", ""
 	}
-	choice = strings.TrimSpace(choice)
 	selected, err := strconv.Atoi(choice)
 	if err != nil {
 		return "", ""
@@ -283,13 +320,11 @@ func selectReleaseFromList(reader *bufio.Reader, unreleased []jira.ReleaseParsed
 	for i, release := range unreleased {
 		fmt.Printf("[%d] %s\n", i+1, release.Name)
 	}
-	fmt.Print("> ")
-
-	choice, err := reader.ReadString('\n')
+	choice, err := prompt.Line(reader, "release", "> ")
 	if err != nil {
 		return "", ""
 	}
-	selected, err := strconv.Atoi(strings.TrimSpace(choice))
+	selected, err := strconv.Atoi(choice)
 	if err != nil || selected < 1 || selected > len(unreleased) {
 		return "", ""
 	}
@@ -301,6 +336,9 @@ func selectReleaseFromList(reader *bufio.Reader, unreleased []jira.ReleaseParsed
 func selectUserForAssignment(
 	client jira.JiraClient, reader *bufio.Reader, recent []string,
 ) (user jira.User, userIdentifier string, err error) {
+	if tui.Pickable("user") {
+		return PickUser(client, recent)
+	}
 	if len(recent) > 0 {
 		selectedFromRecent, user, identifier, err := selectUserFromRecent(client, reader, recent)
 		if err != nil {
@@ -322,13 +360,10 @@ func selectUserFromRecent(
 		fmt.Printf("[%d] %s\n", i+1, userID)
 	}
 	fmt.Printf("[%d] Other...\n", len(recent)+1)
-	fmt.Print("> ")
-
-	choice, err := reader.ReadString('\n')
+	choice, err := prompt.Line(reader, "assignee_recent", "> ")
 	if err != nil {
 		return false, jira.User{}, "", err
 	}
-	choice = strings.TrimSpace(choice)
 	selected, err := strconv.Atoi(choice)
 	if err != nil {
 		return false, jira.User{}, "", fmt.Errorf("invalid selection: %s", choice)
@@ -353,15 +388,68 @@ func selectUserFromRecent(
 	return false, jira.User{}, "", nil
 }
 
+// PickUser chooses a user with the picker: recent assignees are listed first,
+// and typing searches Jira for more
+func PickUser(client jira.JiraClient, recent []string) (user jira.User, userIdentifier string, err error) {
+	items := make([]tui.PickItem, 0, len(recent))
+	for _, identifier := range recent {
+		items = append(items, tui.PickItem{Label: identifier})
+	}
+
+	found := map[string]jira.User{}
+	picker := tui.NewPicker("Select user", tui.PinRecent(items, recent), false)
+	picker.Search = func(query string) ([]tui.PickItem, error) {
+		users, err := client.SearchUsers(query)
+		if err != nil {
+			return nil, err
+		}
+		results := make([]tui.PickItem, 0, len(users))
+		for _, u := range users {
+			identifier := userIdentifierOf(u)
+			found[identifier] = u
+			results = append(results, tui.PickItem{Label: identifier, Detail: u.DisplayName})
+		}
+		return results, nil
+	}
+
+	chosen, err := tui.Pick(picker)
+	if err != nil {
+		return jira.User{}, "", err
+	}
+	if len(chosen) == 0 {
+		return jira.User{}, "", fmt.Errorf("no users found matching: %s", picker.Query())
+	}
+
+	identifier := chosen[0].Label
+	if u, ok := found[identifier]; ok {
+		return u, identifier, nil
+	}
+	// A recent assignee that wasn't among the search results
+	users, err := client.SearchUsers(identifier)
+	if err != nil {
+		return jira.User{}, "", err
+	}
+	if len(users) == 0 {
+		return jira.User{}, "", fmt.Errorf("user not found: %s", identifier)
+	}
+	return users[0], identifier, nil
+}
+
+// userIdentifierOf returns the name a user is remembered by in the recent assignees
+func userIdentifierOf(u jira.User) string {
+	if u.Name != "" {
+		return u.Name
+	}
+	return u.AccountID
+}
+
 func selectUserFromSearch(
 	client jira.JiraClient, reader *bufio.Reader,
 ) (user jira.User, userIdentifier string, err error) {
-	fmt.Print("Search for user: ")
-	query, err := reader.ReadString('\n')
+	query, err := prompt.Line(reader, "user_search", "Search for user: ")
 	if err != nil {
 		return jira.User{}, "", err
 	}
-	query = strings.TrimSpace(query)
 
 	users, err := client.SearchUsers(query)
 	if err != nil {
@@ -376,13 +464,10 @@ func selectUserFromSearch(
 	for i, u := range users {
 		fmt.Printf("[%d] %s (%s) [AccountID: %s]\n", i+1, u.DisplayName, u.Name, u.AccountID)
 	}
-	fmt.Print("Select user number: ")
-
-	choice, err := reader.ReadString('\n')
+	choice, err := prompt.Line(reader, "user", "Select user number: ")
 	if err != nil {
 		return jira.User{}, "", err
 	}
-	choice = strings.TrimSpace(choice)
 	selected, err := strconv.Atoi(choice)
 	if err != nil {
 		return jira.User{}, "", fmt.Errorf("invalid selection: %s", choice)
@@ -393,10 +478,5 @@ func selectUserFromSearch(
 	}
 
 	user = users[selected-1]
-	userIdentifier = user.Name
-	if userIdentifier == "" {
-		userIdentifier = user.AccountID
-	}
-
-	return user, userIdentifier, nil
+	return user, userIdentifierOf(user), nil
 }
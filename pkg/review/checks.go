@@ -0,0 +1,108 @@
+package review
+
+import (
+	"fmt"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+// These checks decide whether a review step is already done for a ticket.
+// They are also used by the lint rules, so both agree on what "done" means.
+
+// HasComponent checks if the ticket has at least one component
+func HasComponent(ticket *jira.Issue) bool {
+	return len(ticket.Fields.Components) > 0
+}
+
+// HasPriority checks if the ticket has a priority set
+func HasPriority(ticket *jira.Issue) bool {
+	return ticket.Fields.Priority.Name != ""
+}
+
+// HasStoryPoints checks if the ticket has been estimated
+func HasStoryPoints(ticket *jira.Issue) bool {
+	return ticket.Fields.StoryPoints > 0
+}
+
+// IsAssigned checks if the ticket has an assignee
+func IsAssigned(ticket *jira.Issue) bool {
+	return ticket.Fields.Assignee.DisplayName != "" ||
+		ticket.Fields.Assignee.AccountID != "" ||
+		ticket.Fields.Assignee.Name != ""
+}
+
+// IsOutOfNew checks if the ticket has been transitioned out of the "New" state
+func IsOutOfNew(ticket *jira.Issue) bool {
+	return ticket.Fields.Status.Name != "New"
+}
+
+// IsSeveritySet checks if the severity field has a value
+func IsSeveritySet(client jira.JiraClient, ticketKey, severityFieldID string) bool {
+	rawTicket, err := client.GetTicketRaw(ticketKey)
+	if err != nil {
+		return false
+	}
+
+	fields, ok := rawTicket["fields"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	severityValue, ok := fields[severityFieldID]
+	if !ok || severityValue == nil {
+		return false
+	}
+
+	currentValue := extractSeverityValue(severityValue)
+	return currentValue != ""
+}
+
+// DescriptionLengthIssue returns why a description is too short, or an empty string if it is long enough
+// A minLength of 0 disables the check
+func DescriptionLengthIssue(description string, minLength int) string {
+	if minLength > 0 && len(description) < minLength {
+		return fmt.Sprintf("too short (%d chars, need %d)", len(description), minLength)
+	}
+	return ""
+}
+
+// TicketFields returns the raw fields of a ticket, for checks on fields that aren't in search results
+// Returns an empty map if the ticket can't be read
+func TicketFields(client jira.JiraClient, ticketKey string) map[string]interface{} {
+	raw, err := client.GetTicketRaw(ticketKey)
+	if err != nil {
+		return map[string]interface{}{}
+	}
+	fields, ok := raw["fields"].(map[string]interface{})
+	if !ok {
+		return map[string]interface{}{}
+	}
+	return fields
+}
+
+// HasFieldValue checks if a raw field is set: not null, and not an empty string, list or object
+func HasFieldValue(fields map[string]interface{}, fieldID string) bool {
+	switch v := fields[fieldID].(type) {
+	case nil:
+		return false
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	default:
+		return true
+	}
+}
+
+// HasFieldStepValues checks if every field of a user-defined field step is set
+func HasFieldStepValues(fields map[string]interface{}, step *config.FieldStep) bool {
+	for _, field := range step.Fields {
+		if !HasFieldValue(fields, field.ID) {
+			return false
+		}
+	}
+	return true
+}
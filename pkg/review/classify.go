@@ -0,0 +1,79 @@
+package review
+
+import (
+	"fmt"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/gemini"
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+// suggestTriageValues asks the AI for the priority, severity and component of a ticket
+// and fills in the defaults the triage rules left empty, for the steps still to do
+// Failures only print a warning; the steps then prompt as usual
+func suggestTriageValues(
+	client jira.JiraClient, geminiClient gemini.GeminiClient, cfg *config.Config,
+	ticket *jira.Issue, status *TicketStatus, defaults *StepDefaults,
+) {
+	needPriority := status.Needs(StepPriority) && defaults.Priority.Value == ""
+	needSeverity := cfg.SeverityFieldID != "" && status.Needs(StepSeverity) && defaults.Severity.Value == ""
+	needComponent := status.Needs(StepComponent) && defaults.Component.Value == "" && cfg.DefaultProject != ""
+	if !needPriority && !needSeverity && !needComponent {
+		return
+	}
+
+	input := &gemini.ClassificationInput{
+		Summary:   ticket.Fields.Summary,
+		IssueType: ticket.Fields.IssueType.Name,
+	}
+	if description, err := client.GetTicketDescription(ticket.Key); err == nil {
+		input.Description = description
+	}
+	if needPriority {
+		if priorities, err := client.GetPriorities(); err == nil {
+			for _, p := range priorities {
+				input.Priorities = append(input.Priorities, p.Name)
+			}
+		}
+	}
+	if needSeverity {
+		if values, err := getSeverityValues(client, cfg); err == nil {
+			input.SeverityValues = values
+		}
+	}
+	if needComponent {
+		if components, err := client.GetComponents(cfg.DefaultProject); err == nil {
+			for _, comp := range components {
+				input.Components = append(input.Components,
+					gemini.ComponentOption{Name: comp.Name, Description: comp.Description})
+			}
+		}
+	}
+	if len(input.Priorities) == 0 && len(input.SeverityValues) == 0 && len(input.Components) == 0 {
+		return
+	}
+
+	fmt.Println("Asking AI for priority, severity and component suggestions...")
+	classification, err := geminiClient.ClassifyTicket(input)
+	if err != nil {
+		fmt.Printf("Warning: Could not get AI suggestions: %v\n", err)
+		return
+	}
+
+	ApplyClassification(defaults, classification)
+}
+
+// ApplyClassification fills the empty defaults with the AI's recommendations
+// Suggestions that are already set (e.g. by triage rules) take precedence
+func ApplyClassification(defaults *StepDefaults, classification *gemini.Classification) {
+	fill := func(suggestion *Suggestion, rec gemini.Recommendation) {
+		if suggestion.Value != "" || rec.Value == "" {
+			return
+		}
+		*suggestion = Suggestion{Value: rec.Value, Source: "AI", Rationale: rec.Rationale}
+	}
+
+	fill(&defaults.Priority, classification.Priority)
+	fill(&defaults.Severity, classification.Severity)
+	fill(&defaults.Component, classification.Component)
+}
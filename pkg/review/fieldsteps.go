@@ -0,0 +1,279 @@
+package review
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/prompt"
+)
+
+// Field types for user-defined field steps
+const (
+	fieldTypeText   = "text"
+	fieldTypeNumber = "number"
+	fieldTypeDate   = "date"
+	fieldTypeOption = "option"
+)
+
+// dueDateLayout is the date format Jira uses for date fields
+const dueDateLayout = "2006-01-02"
+
+// HandleLabelsStep asks for labels to add to a ticket without any
+func HandleLabelsStep(client jira.JiraClient, reader *bufio.Reader, ticket *jira.Issue) (bool, error) {
+	input, err := prompt.Line(reader, "labels", "Labels to add (comma-separated, Enter to skip): ")
+	if err != nil {
+		return false, err
+	}
+
+	labels := []string{}
+	for _, label := range strings.Split(input, ",") {
+		// Jira labels can't contain spaces
+		label = strings.Join(strings.Fields(label), "-")
+		if label != "" {
+			labels = append(labels, label)
+		}
+	}
+	if len(labels) == 0 {
+		return false, nil
+	}
+
+	if err := client.AddLabels(ticket.Key, labels); err != nil {
+		return false, fmt.Errorf("failed to add labels: %w", err)
+	}
+	return true, nil
+}
+
+// HandleFixVersionStep offers the project's unreleased versions as the ticket's fix version
+func HandleFixVersionStep(client jira.JiraClient, reader *bufio.Reader, ticket *jira.Issue) (bool, error) {
+	releases, err := client.GetReleases(jira.ProjectKey(ticket.Key))
+	if err != nil {
+		return false, fmt.Errorf("failed to get versions: %w", err)
+	}
+
+	unreleased := []jira.ReleaseParsed{}
+	for _, release := range releases {
+		if !release.Released {
+			unreleased = append(unreleased, release)
+		}
+	}
+	if len(unreleased) == 0 {
+		fmt.Println("No unreleased versions found - skipping fix version")
+		return false, nil
+	}
+
+	names := make([]string, len(unreleased))
+	for i, release := range unreleased {
+		names[i] = release.Name
+	}
+
+	fmt.Println("Select fix version:")
+	printChoices(names, 0)
+	fmt.Printf("[%d] Skip\n", len(names)+1)
+	selected, err := readChoice(reader, "fix_version", 0)
+	if err != nil {
+		return false, err
+	}
+	if selected == len(names)+1 {
+		return false, nil
+	}
+	if selected < 1 || selected > len(names) {
+		return false, fmt.Errorf("invalid selection: %d", selected)
+	}
+
+	version := []map[string]string{{"id": unreleased[selected-1].ID}}
+	if err := client.UpdateTicketFields(ticket.Key, map[string]interface{}{"fixVersions": version}); err != nil {
+		return false, fmt.Errorf("failed to set fix version: %w", err)
+	}
+	return true, nil
+}
+
+// HandleDueDateStep asks for a due date
+func HandleDueDateStep(client jira.JiraClient, reader *bufio.Reader, ticket *jira.Issue) (bool, error) {
+	input, err := prompt.Line(reader, "due_date",
+		"Due date (YYYY-MM-DD, or +N for N days from today; Enter to skip): ")
+	if err != nil {
+		return false, err
+	}
+	if input == "" {
+		return false, nil
+	}
+
+	date, err := parseDueDate(input, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	if err := client.UpdateTicketFields(ticket.Key, map[string]interface{}{"duedate": date}); err != nil {
+		return false, fmt.Errorf("failed to set due date: %w", err)
+	}
+	return true, nil
+}
+
+// parseDueDate parses a date as YYYY-MM-DD or +N days from now, returning it as YYYY-MM-DD
+func parseDueDate(input string, now time.Time) (string, error) {
+	if strings.HasPrefix(input, "+") {
+		days, err := strconv.Atoi(strings.TrimSuffix(input[1:], "d"))
+		if err != nil || days < 0 {
+			return "", fmt.Errorf("invalid number of days: %s", input)
+		}
+		return now.AddDate(0, 0, days).Format(dueDateLayout), nil
+	}
+
+	date, err := time.Parse(dueDateLayout, input)
+	if err != nil {
+		return "", fmt.Errorf("invalid date %q, expected YYYY-MM-DD", input)
+	}
+	return date.Format(dueDateLayout), nil
+}
+
+// linkDirection is one way of reading a link type, e.g. "blocks" or "is blocked by"
+type linkDirection struct {
+	linkType    string
+	description string
+	outward     bool
+}
+
+// HandleLinksStep links the ticket to another ticket
+func HandleLinksStep(client jira.JiraClient, reader *bufio.Reader, ticket *jira.Issue) (bool, error) {
+	linkTypes, err := client.GetIssueLinkTypes()
+	if err != nil {
+		return false, fmt.Errorf("failed to get link types: %w", err)
+	}
+
+	directions := []linkDirection{}
+	for _, lt := range linkTypes {
+		directions = append(directions, linkDirection{linkType: lt.Name, description: lt.Outward, outward: true})
+		if !strings.EqualFold(lt.Inward, lt.Outward) {
+			directions = append(directions, linkDirection{linkType: lt.Name, description: lt.Inward})
+		}
+	}
+	if len(directions) == 0 {
+		fmt.Println("No link types found - skipping links")
+		return false, nil
+	}
+
+	names := make([]string, len(directions))
+	for i, d := range directions {
+		names[i] = fmt.Sprintf("%s %s ...", ticket.Key, d.description)
+	}
+
+	fmt.Println("Select link type:")
+	printChoices(names, 0)
+	fmt.Printf("[%d] Skip\n", len(names)+1)
+	selected, err := readChoice(reader, "link_type", 0)
+	if err != nil {
+		return false, err
+	}
+	if selected == len(names)+1 {
+		return false, nil
+	}
+	if selected < 1 || selected > len(names) {
+		return false, fmt.Errorf("invalid selection: %d", selected)
+	}
+	direction := directions[selected-1]
+
+	other, err := prompt.Line(reader, "link_target", fmt.Sprintf("%s %s: ", ticket.Key, direction.description))
+	if err != nil {
+		return false, err
+	}
+	other = strings.ToUpper(other)
+	if other == "" {
+		return false, nil
+	}
+
+	from, to := ticket.Key, other
+	if !direction.outward {
+		from, to = other, ticket.Key
+	}
+	if err := client.LinkIssues(direction.linkType, from, to); err != nil {
+		return false, fmt.Errorf("failed to link %s to %s: %w", ticket.Key, other, err)
+	}
+	return true, nil
+}
+
+// HandleFieldStep prompts for the unset fields of a user-defined field step
+// The step is complete once all its fields are set
+func HandleFieldStep(
+	client jira.JiraClient, reader *bufio.Reader, ticket *jira.Issue, step *config.FieldStep,
+) (bool, error) {
+	current := TicketFields(client, ticket.Key)
+
+	updates := map[string]interface{}{}
+	for _, field := range step.Fields {
+		if HasFieldValue(current, field.ID) {
+			continue
+		}
+		value, err := promptFieldValue(reader, field)
+		if err != nil {
+			return false, err
+		}
+		if value != nil {
+			updates[field.ID] = value
+		}
+	}
+
+	if len(updates) > 0 {
+		if err := client.UpdateTicketFields(ticket.Key, updates); err != nil {
+			return false, fmt.Errorf("failed to update %s: %w", step.Name, err)
+		}
+	}
+
+	for _, field := range step.Fields {
+		if _, ok := updates[field.ID]; !ok && !HasFieldValue(current, field.ID) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// promptFieldValue asks for a field's value and returns it in the shape Jira expects,
+// or nil if the user skipped it
+func promptFieldValue(reader *bufio.Reader, field config.FieldPrompt) (interface{}, error) {
+	label := field.Label
+	if label == "" {
+		label = field.ID
+	}
+
+	if field.Type == fieldTypeOption {
+		fmt.Printf("Select %s:\n", label)
+		printChoices(field.Options, 0)
+		fmt.Printf("[%d] Skip\n", len(field.Options)+1)
+		selected, err := readChoice(reader, field.ID, 0)
+		if err != nil {
+			return nil, err
+		}
+		if selected == len(field.Options)+1 {
+			return nil, nil
+		}
+		if selected < 1 || selected > len(field.Options) {
+			return nil, fmt.Errorf("invalid selection: %d", selected)
+		}
+		return map[string]string{"value": field.Options[selected-1]}, nil
+	}
+
+	input, err := prompt.Line(reader, field.ID, fmt.Sprintf("%s (Enter to skip): ", label))
+	if err != nil {
+		return nil, err
+	}
+	if input == "" {
+		return nil, nil
+	}
+
+	switch field.Type {
+	case fieldTypeNumber:
+		number, err := strconv.ParseFloat(input, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number for %s: %s", label, input)
+		}
+		return number, nil
+	case fieldTypeDate:
+		return parseDueDate(input, time.Now())
+	default:
+		return input, nil
+	}
+}
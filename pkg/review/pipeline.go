@@ -0,0 +1,164 @@
+package review
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+// stepIDs are the names of the built-in steps in the review_workflow config
+var stepIDs = map[WorkflowStep]string{
+	StepDescription: "description",
+	StepComponent:   "component",
+	StepPriority:    "priority",
+	StepSeverity:    "severity",
+	StepStoryPoints: "story_points",
+	StepBacklog:     "backlog",
+	StepAssignment:  "assignment",
+	StepLabels:      "labels",
+	StepFixVersion:  "fix_version",
+	StepDueDate:     "due_date",
+	StepLinks:       "links",
+}
+
+// defaultSteps are the steps run when review_workflow doesn't configure any
+var defaultSteps = []WorkflowStep{
+	StepDescription, StepComponent, StepPriority, StepSeverity, StepStoryPoints, StepBacklog, StepAssignment,
+}
+
+// Step is one step of a ticket's review pipeline
+type Step struct {
+	Kind WorkflowStep
+	// Field is the user-defined step, for StepCustomField
+	Field *config.FieldStep
+}
+
+// ID returns the name of the step in the review_workflow config
+func (s Step) ID() string {
+	if s.Field != nil {
+		return s.Field.Name
+	}
+	return stepIDs[s.Kind]
+}
+
+// Label returns the name of the step shown to the user
+func (s Step) Label() string {
+	if s.Field != nil {
+		if s.Field.Label != "" {
+			return s.Field.Label
+		}
+		return s.Field.Name
+	}
+	return s.Kind.String()
+}
+
+// DefaultPipeline returns the built-in steps in their default order
+func DefaultPipeline() []Step {
+	steps := make([]Step, len(defaultSteps))
+	for i, kind := range defaultSteps {
+		steps[i] = Step{Kind: kind}
+	}
+	return steps
+}
+
+// ResolvePipeline returns the review steps for a ticket from the review_workflow config
+func ResolvePipeline(cfg *config.Config, ticket *jira.Issue) ([]Step, error) {
+	defaults := make([]string, len(defaultSteps))
+	for i, kind := range defaultSteps {
+		defaults[i] = stepIDs[kind]
+	}
+
+	workflow := &cfg.ReviewWorkflow
+	ids := workflow.StepsFor(jira.ProjectKey(ticket.Key), ticket.Fields.IssueType.Name, defaults)
+
+	steps := make([]Step, 0, len(ids))
+	for _, id := range ids {
+		step, err := parseStep(workflow, id)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// ValidateWorkflow checks that every step named in the review_workflow config exists
+func ValidateWorkflow(workflow *config.ReviewWorkflow) error {
+	lists := [][]string{workflow.Steps, workflow.Disabled}
+	for _, steps := range workflow.IssueTypes {
+		lists = append(lists, steps)
+	}
+	for _, project := range workflow.Projects {
+		lists = append(lists, project.Steps, project.Disabled)
+		for _, steps := range project.IssueTypes {
+			lists = append(lists, steps)
+		}
+	}
+
+	for _, ids := range lists {
+		for _, id := range ids {
+			if _, err := parseStep(workflow, id); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := range workflow.FieldSteps {
+		if err := validateFieldStep(i, &workflow.FieldSteps[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateFieldStep checks a user-defined field step; index is its position in the config
+func validateFieldStep(index int, fs *config.FieldStep) error {
+	if fs.Name == "" {
+		return fmt.Errorf("review_workflow field step %d has no name", index+1)
+	}
+	if len(fs.Fields) == 0 {
+		return fmt.Errorf("review_workflow field step %q has no fields", fs.Name)
+	}
+	for _, field := range fs.Fields {
+		if field.ID == "" {
+			return fmt.Errorf("review_workflow field step %q has a field without an id", fs.Name)
+		}
+		switch field.Type {
+		case "", fieldTypeText, fieldTypeNumber, fieldTypeDate:
+		case fieldTypeOption:
+			if len(field.Options) == 0 {
+				return fmt.Errorf("review_workflow field %s in step %q needs options", field.ID, fs.Name)
+			}
+		default:
+			return fmt.Errorf("review_workflow field %s in step %q has unknown type %q", field.ID, fs.Name, field.Type)
+		}
+	}
+	return nil
+}
+
+// parseStep looks up a step by its config ID; built-in steps take precedence over field steps
+func parseStep(workflow *config.ReviewWorkflow, id string) (Step, error) {
+	for kind, name := range stepIDs {
+		if strings.EqualFold(name, id) {
+			return Step{Kind: kind}, nil
+		}
+	}
+	if fs := workflow.FieldStep(id); fs != nil {
+		return Step{Kind: StepCustomField, Field: fs}, nil
+	}
+	return Step{}, fmt.Errorf("unknown review_workflow step %q", id)
+}
+
+// OnlyStep returns a copy of cfg whose review workflow runs just the given step,
+// for running a single step on a ticket; field steps stay defined so they can be named
+func OnlyStep(cfg *config.Config, stepID string) *config.Config {
+	only := *cfg
+	only.ReviewWorkflow = config.ReviewWorkflow{
+		Steps:      []string{stepID},
+		FieldSteps: cfg.ReviewWorkflow.FieldSteps,
+	}
+	return &only
+}
@@ -0,0 +1,235 @@
+package review
+
+import (
+	"testing"
+	"time"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+func testWorkflowConfig() *config.Config {
+	return &config.Config{
+		ReviewWorkflow: config.ReviewWorkflow{
+			Disabled: []string{"assignment"},
+			IssueTypes: map[string][]string{
+				"Bug":   {"description", "component", "priority", "severity", "links", "assignment"},
+				"Story": {"description", "component", "story_points", "fix_version", "customer_impact"},
+			},
+			Projects: map[string]config.ProjectWorkflow{
+				"OPS": {Steps: []string{"priority", "due_date", "labels"}},
+			},
+			FieldSteps: []config.FieldStep{{
+				Name:   "customer_impact",
+				Label:  "Customer Impact",
+				Fields: []config.FieldPrompt{{ID: "customfield_100", Type: "option", Options: []string{"Low", "High"}}},
+			}},
+		},
+	}
+}
+
+func testTicket(key, issueType string) *jira.Issue {
+	ticket := &jira.Issue{Key: key}
+	ticket.Fields.IssueType.Name = issueType
+	return ticket
+}
+
+func stepIDList(steps []Step) []string {
+	ids := make([]string, len(steps))
+	for i, step := range steps {
+		ids[i] = step.ID()
+	}
+	return ids
+}
+
+func TestResolvePipeline(t *testing.T) {
+	cfg := testWorkflowConfig()
+
+	tests := []struct {
+		name     string
+		ticket   *jira.Issue
+		expected []string
+	}{
+		{"bug", testTicket("ENG-1", "bug"), []string{"description", "component", "priority", "severity", "links"}},
+		{"story", testTicket("ENG-2", "Story"),
+			[]string{"description", "component", "story_points", "fix_version", "customer_impact"}},
+		{"default steps", testTicket("ENG-3", "Task"),
+			[]string{"description", "component", "priority", "severity", "story_points", "backlog"}},
+		{"project override", testTicket("OPS-4", "Bug"), []string{"priority", "due_date", "labels"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps, err := ResolvePipeline(cfg, tt.ticket)
+			if err != nil {
+				t.Fatalf("ResolvePipeline failed: %v", err)
+			}
+			ids := stepIDList(steps)
+			if len(ids) != len(tt.expected) {
+				t.Fatalf("Expected steps %v, got %v", tt.expected, ids)
+			}
+			for i := range ids {
+				if ids[i] != tt.expected[i] {
+					t.Fatalf("Expected steps %v, got %v", tt.expected, ids)
+				}
+			}
+		})
+	}
+
+	steps, _ := ResolvePipeline(cfg, testTicket("ENG-2", "Story"))
+	last := steps[len(steps)-1]
+	if last.Kind != StepCustomField || last.Label() != "Customer Impact" {
+		t.Errorf("Expected the customer impact field step last, got %s (%s)", last.Label(), last.Kind)
+	}
+}
+
+func TestOnlyStep(t *testing.T) {
+	cfg := testWorkflowConfig()
+
+	for _, key := range []string{"ENG-1", "OPS-2"} {
+		steps, err := ResolvePipeline(OnlyStep(cfg, "customer_impact"), testTicket(key, "Bug"))
+		if err != nil {
+			t.Fatalf("ResolvePipeline failed: %v", err)
+		}
+		if ids := stepIDList(steps); len(ids) != 1 || ids[0] != "customer_impact" {
+			t.Errorf("Expected only customer_impact for %s, got %v", key, ids)
+		}
+	}
+
+	if len(cfg.ReviewWorkflow.Steps) != 0 || len(cfg.ReviewWorkflow.Projects) != 1 {
+		t.Error("Expected the original config to be unchanged")
+	}
+}
+
+func TestValidateWorkflow(t *testing.T) {
+	cfg := testWorkflowConfig()
+	if err := ValidateWorkflow(&cfg.ReviewWorkflow); err != nil {
+		t.Fatalf("Expected valid workflow, got %v", err)
+	}
+
+	cfg.ReviewWorkflow.IssueTypes["Epic"] = []string{"description", "milestone"}
+	if err := ValidateWorkflow(&cfg.ReviewWorkflow); err == nil {
+		t.Error("Expected an error for an unknown step")
+	}
+	delete(cfg.ReviewWorkflow.IssueTypes, "Epic")
+
+	cfg.ReviewWorkflow.FieldSteps[0].Fields[0].Options = nil
+	if err := ValidateWorkflow(&cfg.ReviewWorkflow); err == nil {
+		t.Error("Expected an error for an option field without options")
+	}
+}
+
+func TestEmptyPipeline(t *testing.T) {
+	cfg := testWorkflowConfig()
+	cfg.ReviewWorkflow.IssueTypes["Epic"] = []string{}
+	cfg.ReviewWorkflow.Projects["QA"] = config.ProjectWorkflow{
+		Disabled: []string{"description", "component", "priority", "severity", "story_points", "backlog"},
+	}
+
+	for _, ticket := range []*jira.Issue{testTicket("ENG-5", "Epic"), testTicket("QA-6", "Task")} {
+		steps, err := ResolvePipeline(cfg, ticket)
+		if err != nil {
+			t.Fatalf("ResolvePipeline failed: %v", err)
+		}
+		if steps == nil || len(steps) != 0 {
+			t.Fatalf("Expected no steps for %s, got %v", ticket.Key, stepIDList(steps))
+		}
+
+		// An empty pipeline has no steps, rather than falling back to the default ones
+		status := &TicketStatus{Pipeline: steps}
+		if len(status.Steps()) != 0 || status.HasStep(StepDescription) || !status.IsComplete() {
+			t.Errorf("Expected %s to have no steps, got %v", ticket.Key, stepIDList(status.Steps()))
+		}
+		if next := status.GetNextStep(); next != StepDescription {
+			t.Errorf("Expected StepDescription for an empty pipeline, got %s", next)
+		}
+	}
+
+	// A status without a resolved pipeline still uses the default steps
+	status := &TicketStatus{}
+	if len(status.Steps()) != len(DefaultPipeline()) {
+		t.Errorf("Expected the default steps, got %v", stepIDList(status.Steps()))
+	}
+}
+
+func TestTicketStatusWithPipeline(t *testing.T) {
+	cfg := testWorkflowConfig()
+	steps, err := ResolvePipeline(cfg, testTicket("ENG-2", "Story"))
+	if err != nil {
+		t.Fatalf("ResolvePipeline failed: %v", err)
+	}
+	status := &TicketStatus{Pipeline: steps}
+
+	// Steps outside the pipeline don't count
+	status.MarkComplete(StepDescription)
+	status.MarkComplete(StepComponent)
+	status.MarkComplete(StepStoryPoints)
+	status.MarkComplete(StepFixVersion)
+	if status.IsComplete() {
+		t.Error("Expected status to be incomplete until the field step is done")
+	}
+	if next := status.GetNextStep(); next != StepCustomField {
+		t.Errorf("Expected next step to be the field step, got %s", next)
+	}
+	if status.Needs(StepPriority) {
+		t.Error("Expected priority not to be needed for stories")
+	}
+
+	status.markDone(steps[len(steps)-1])
+	if !status.IsComplete() {
+		t.Error("Expected status to be complete")
+	}
+}
+
+func TestHasFieldValue(t *testing.T) {
+	fields := map[string]interface{}{
+		"duedate":         nil,
+		"labels":          []interface{}{},
+		"fixVersions":     []interface{}{map[string]interface{}{"id": "1"}},
+		"customfield_100": map[string]interface{}{"value": "High"},
+		"customfield_200": "",
+		"customfield_300": 3.0,
+	}
+
+	expected := map[string]bool{
+		"duedate":         false,
+		"labels":          false,
+		"fixVersions":     true,
+		"customfield_100": true,
+		"customfield_200": false,
+		"customfield_300": true,
+		"issuelinks":      false,
+	}
+	for field, want := range expected {
+		if got := HasFieldValue(fields, field); got != want {
+			t.Errorf("HasFieldValue(%s) = %v, expected %v", field, got, want)
+		}
+	}
+}
+
+func TestParseDueDate(t *testing.T) {
+	now := time.Date(2024, 1, 30, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"2024-03-01", "2024-03-01", false},
+		{"+3", "2024-02-02", false},
+		{"+14d", "2024-02-13", false},
+		{"next week", "", true},
+		{"+x", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseDueDate(tt.input, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseDueDate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("parseDueDate(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package review
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Session is a multi-ticket review saved after every change, so triage can be
+// spread across several sittings or handed to someone else as an exported file
+type Session struct {
+	Name string `json:"name"`
+	// JQL is the query that built the queue; the queue itself is fixed when the session starts
+	JQL      string   `json:"jql"`
+	Keys     []string `json:"keys"`
+	Selected []string `json:"selected,omitempty"`
+	ActedOn  []string `json:"acted_on,omitempty"`
+	Page     int      `json:"page"`
+	// Step progress by ticket key
+	Tickets   map[string]*TicketProgress `json:"tickets,omitempty"`
+	CreatedAt time.Time                  `json:"created_at"`
+	UpdatedAt time.Time                  `json:"updated_at"`
+	// UpdatedBy is the user who last saved the session, to show who it was handed off from
+	UpdatedBy string `json:"updated_by,omitempty"`
+}
+
+// TicketProgress records the steps done or skipped for a ticket, by step ID
+type TicketProgress struct {
+	Completed []string `json:"completed,omitempty"`
+	Skipped   []string `json:"skipped,omitempty"`
+	// OnUpdate, if set, is called after each step is recorded
+	OnUpdate func() `json:"-"`
+}
+
+// IsCompleted checks if a step was completed in an earlier sitting
+func (p *TicketProgress) IsCompleted(stepID string) bool {
+	return p != nil && containsString(p.Completed, stepID)
+}
+
+// IsSkipped checks if a step was skipped in an earlier sitting
+func (p *TicketProgress) IsSkipped(stepID string) bool {
+	return p != nil && containsString(p.Skipped, stepID)
+}
+
+// Record notes the outcome of a step
+func (p *TicketProgress) Record(stepID string, completed bool) {
+	if p == nil {
+		return
+	}
+	if completed {
+		p.Skipped = removeString(p.Skipped, stepID)
+		if !containsString(p.Completed, stepID) {
+			p.Completed = append(p.Completed, stepID)
+		}
+	} else if !containsString(p.Skipped, stepID) && !containsString(p.Completed, stepID) {
+		p.Skipped = append(p.Skipped, stepID)
+	}
+	if p.OnUpdate != nil {
+		p.OnUpdate()
+	}
+}
+
+// NewSession starts a review session over a queue of tickets
+func NewSession(name, jql string, keys []string) *Session {
+	now := time.Now()
+	return &Session{
+		Name:      name,
+		JQL:       jql,
+		Keys:      keys,
+		Tickets:   map[string]*TicketProgress{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// DefaultSessionName returns a name for a new session started at t
+func DefaultSessionName(t time.Time) string {
+	return "review-" + t.Format("20060102-150405")
+}
+
+// Progress returns the step progress for a ticket, creating it if needed
+func (s *Session) Progress(key string) *TicketProgress {
+	if s.Tickets == nil {
+		s.Tickets = map[string]*TicketProgress{}
+	}
+	progress, ok := s.Tickets[key]
+	if !ok {
+		progress = &TicketProgress{}
+		s.Tickets[key] = progress
+	}
+	return progress
+}
+
+// Remaining returns the number of tickets in the queue not yet reviewed
+func (s *Session) Remaining() int {
+	remaining := 0
+	for _, key := range s.Keys {
+		if !containsString(s.ActedOn, key) {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+// SessionStore saves review sessions as one JSON file per session
+type SessionStore struct {
+	dir string
+}
+
+// GetSessionsDir returns the directory used for saved review sessions
+// If configDir is empty, uses the default ~/.jira-tool
+func GetSessionsDir(configDir string) string {
+	if configDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "./.jira-tool/review-sessions"
+		}
+		configDir = filepath.Join(homeDir, ".jira-tool")
+	}
+	return filepath.Join(configDir, "review-sessions")
+}
+
+// NewSessionStore creates a review session store in dir
+func NewSessionStore(dir string) *SessionStore {
+	return &SessionStore{dir: dir}
+}
+
+func (s *SessionStore) sessionPath(name string) string {
+	return filepath.Join(s.dir, strings.ReplaceAll(name, string(filepath.Separator), "_")+".json")
+}
+
+// Save writes a session, replacing any previous copy
+func (s *SessionStore) Save(session *Session) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create review sessions directory: %w", err)
+	}
+
+	session.UpdatedAt = time.Now()
+	session.UpdatedBy = os.Getenv("USER")
+	return WriteSession(session, s.sessionPath(session.Name))
+}
+
+// Load reads a session by name
+// Returns nil (not an error) if no such session exists
+func (s *SessionStore) Load(name string) (*Session, error) {
+	session, err := ReadSession(s.sessionPath(name))
+	if err != nil && os.IsNotExist(err) {
+		return nil, nil
+	}
+	return session, err
+}
+
+// Delete removes a session; deleting a missing session is not an error
+func (s *SessionStore) Delete(name string) error {
+	if err := os.Remove(s.sessionPath(name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete review session: %w", err)
+	}
+	return nil
+}
+
+// List returns all saved sessions, most recently updated first
+func (s *SessionStore) List() ([]*Session, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Session{}, nil
+		}
+		return nil, fmt.Errorf("failed to read review sessions directory: %w", err)
+	}
+
+	sessions := []*Session{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		session, err := s.Load(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil || session == nil {
+			// Skip unreadable sessions rather than failing the whole listing
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].UpdatedAt.After(sessions[j].UpdatedAt)
+	})
+
+	return sessions, nil
+}
+
+// Latest returns the most recently updated session, or nil if there is none
+func (s *SessionStore) Latest() (*Session, error) {
+	sessions, err := s.List()
+	if err != nil || len(sessions) == 0 {
+		return nil, err
+	}
+	return sessions[0], nil
+}
+
+// WriteSession writes a session to a file, e.g. to hand it to someone else
+func WriteSession(session *Session, path string) error {
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal review session: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write review session: %w", err)
+	}
+	return nil
+}
+
+// ReadSession reads a session from a file
+// If the file can't be read the os error is returned as-is, so os.IsNotExist works on it
+func ReadSession(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to parse review session %s: %w", path, err)
+	}
+	if session.Name == "" {
+		return nil, fmt.Errorf("review session %s has no name", path)
+	}
+
+	return &session, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(values []string, value string) []string {
+	result := []string{}
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
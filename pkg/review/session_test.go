@@ -0,0 +1,102 @@
+package review
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSessionStore(t *testing.T) {
+	store := NewSessionStore(t.TempDir())
+
+	session, err := store.Load("missing")
+	if err != nil || session != nil {
+		t.Fatalf("Expected nil session when none is saved, got %+v (err %v)", session, err)
+	}
+
+	first := NewSession("triage-week-1", "project = ENG", []string{"ENG-1", "ENG-2", "ENG-3"})
+	first.ActedOn = []string{"ENG-1"}
+	first.Progress("ENG-2").Record("component", true)
+	first.Progress("ENG-2").Record("priority", false)
+	if err := store.Save(first); err != nil {
+		t.Fatalf("Failed to save session: %v", err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	second := NewSession("triage-week-2", "project = OPS", []string{"OPS-1"})
+	if err := store.Save(second); err != nil {
+		t.Fatalf("Failed to save session: %v", err)
+	}
+
+	loaded, err := store.Load("triage-week-1")
+	if err != nil || loaded == nil {
+		t.Fatalf("Failed to load saved session: %v", err)
+	}
+	if loaded.Remaining() != 2 {
+		t.Errorf("Expected 2 tickets left, got %d", loaded.Remaining())
+	}
+	progress := loaded.Tickets["ENG-2"]
+	if !progress.IsCompleted("component") || !progress.IsSkipped("priority") {
+		t.Errorf("Expected component completed and priority skipped, got %+v", progress)
+	}
+
+	latest, err := store.Latest()
+	if err != nil || latest == nil || latest.Name != "triage-week-2" {
+		t.Errorf("Expected latest session triage-week-2, got %+v (err %v)", latest, err)
+	}
+
+	// Hand off through an exported file
+	exported := filepath.Join(t.TempDir(), "handoff.json")
+	if err := WriteSession(loaded, exported); err != nil {
+		t.Fatalf("Failed to export session: %v", err)
+	}
+	imported, err := ReadSession(exported)
+	if err != nil {
+		t.Fatalf("Failed to import session: %v", err)
+	}
+	if imported.JQL != "project = ENG" || len(imported.Keys) != 3 {
+		t.Errorf("Unexpected imported session: %+v", imported)
+	}
+
+	if err := store.Delete("triage-week-1"); err != nil {
+		t.Fatalf("Failed to delete session: %v", err)
+	}
+	sessions, err := store.List()
+	if err != nil || len(sessions) != 1 {
+		t.Errorf("Expected 1 session after delete, got %d (err %v)", len(sessions), err)
+	}
+}
+
+func TestTicketProgressRecord(t *testing.T) {
+	updates := 0
+	progress := &TicketProgress{OnUpdate: func() { updates++ }}
+
+	progress.Record("priority", false)
+	progress.Record("priority", true) // Done on retry in a later sitting
+	progress.Record("priority", false)
+
+	if !progress.IsCompleted("priority") || progress.IsSkipped("priority") {
+		t.Errorf("Expected a completed step to stay completed, got %+v", progress)
+	}
+	if updates != 3 {
+		t.Errorf("Expected OnUpdate after every step, got %d calls", updates)
+	}
+
+	var none *TicketProgress
+	none.Record("priority", true) // Outside a session there is nothing to record
+	if none.IsCompleted("priority") {
+		t.Error("Expected nil progress to report nothing completed")
+	}
+}
+
+func TestRestoreProgress(t *testing.T) {
+	status := &TicketStatus{}
+	restoreProgress(status, &TicketProgress{Completed: []string{"description"}, Skipped: []string{"severity"}})
+
+	if !status.DescriptionComplete {
+		t.Error("Expected description to be restored as complete")
+	}
+	if !status.SkippedSteps["severity"] || status.SeverityComplete {
+		t.Error("Expected severity to be restored as skipped, not complete")
+	}
+}
@@ -2,19 +2,55 @@ package review
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/estimate"
 	"github.com/beekhof/jira-tool/pkg/gemini"
 	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/prompt"
+	"github.com/beekhof/jira-tool/pkg/tui"
 )
 
+// DescriptionQuality is the result of checking a ticket's description
+type DescriptionQuality struct {
+	Valid  bool
+	Reason string // Why the description isn't valid; empty if it is
+	// Assessment holds the AI rubric scores; nil if AI analysis is disabled or unavailable
+	Assessment *gemini.DescriptionAssessment
+}
+
+// SeedContext returns the rubric findings as context for the description Q&A,
+// so questions focus on what the description is missing
+func (q *DescriptionQuality) SeedContext() string {
+	if q.Assessment == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("A review of the current description found:\n")
+	for _, s := range q.Assessment.Scores {
+		b.WriteString(fmt.Sprintf("- %s: %d/%d\n", gemini.CriterionLabel(s.Criterion), s.Score, gemini.MaxRubricScore))
+	}
+	if hints := q.Assessment.Hints(); len(hints) > 0 {
+		b.WriteString("Ask questions that address these gaps:\n")
+		for _, hint := range hints {
+			b.WriteString("- " + hint + "\n")
+		}
+	}
+	return b.String()
+}
+
 // CheckDescriptionQuality checks if a ticket's description meets quality criteria
+// If cfg.DescriptionQualityAI is set and geminiClient is not nil, the description is also
+// scored against a rubric (problem, motivation, acceptance criteria, scope, test notes),
+// and is invalid if any criterion is missing entirely
 func CheckDescriptionQuality(
-	client jira.JiraClient, ticket *jira.Issue, cfg *config.Config,
-) (isValid bool, reason string, err error) {
+	client jira.JiraClient, geminiClient gemini.GeminiClient, ticket *jira.Issue, cfg *config.Config,
+) (*DescriptionQuality, error) {
 	// Fetch description
 	description, err := client.GetTicketDescription(ticket.Key)
 	if err != nil {
@@ -22,28 +58,41 @@ func CheckDescriptionQuality(
 		description = ""
 	}
 
+	quality := &DescriptionQuality{Valid: true}
+
 	// Check minimum length
-	if cfg.DescriptionMinLength > 0 {
-		if len(description) < cfg.DescriptionMinLength {
-			return false, fmt.Sprintf("too short (%d chars, need %d)", len(description), cfg.DescriptionMinLength), nil
-		}
+	if reason := DescriptionLengthIssue(description, cfg.DescriptionMinLength); reason != "" {
+		quality.Valid = false
+		quality.Reason = reason
 	}
 
-	// Optional Gemini AI analysis (not implemented yet - would require new method)
-	// For now, just check length
-	if cfg.DescriptionQualityAI {
-		// Placeholder for future AI analysis
-		// Would use Gemini to check if description answers "what", "why", "how"
-		_ = description // Use description variable
+	// Optional Gemini rubric analysis; an empty description has nothing to score
+	if cfg.DescriptionQualityAI && geminiClient != nil && strings.TrimSpace(description) != "" {
+		assessment, err := geminiClient.AssessDescription(ticket.Fields.Summary, description, ticket.Fields.IssueType.Name)
+		if err != nil {
+			_ = err // Ignore - AI analysis is optional, fall back to the length check
+			return quality, nil
+		}
+		quality.Assessment = assessment
+
+		if missing := assessment.Missing(); len(missing) > 0 && quality.Valid {
+			labels := make([]string, len(missing))
+			for i, criterion := range missing {
+				labels[i] = strings.ToLower(gemini.CriterionLabel(criterion))
+			}
+			quality.Valid = false
+			quality.Reason = "missing " + strings.Join(labels, ", ")
+		}
 	}
 
-	return true, "", nil
+	return quality, nil
 }
 
 // HandleComponentStep checks and assigns component if missing
+// suggested, if it is one of the project's components, is offered first
 func HandleComponentStep(
 	client jira.JiraClient, reader *bufio.Reader, cfg *config.Config,
-	ticket *jira.Issue, configDir string,
+	ticket *jira.Issue, configDir string, suggested Suggestion,
 ) (bool, error) {
 	if len(ticket.Fields.Components) > 0 {
 		return true, nil
@@ -69,6 +118,14 @@ func HandleComponentStep(
 		return true, nil
 	}
 
+	if comp, ok := confirmSuggestedComponent(reader, components, suggested); ok {
+		return updateComponentAndSave(client, ticket.Key, comp, state, statePath)
+	}
+
+	if tui.Pickable("component") {
+		return pickComponents(client, reader, ticket, projectKey, components, state, statePath)
+	}
+
 	selectedFromRecent, comp, err := selectFromRecentComponents(reader, components, state.RecentComponents)
 	if err != nil {
 		return false, err
@@ -82,19 +139,76 @@ func HandleComponentStep(
 		return false, err
 	}
 
-	if selected == len(components)+2 {
+	if len(selected) == 1 && selected[0] == len(components)+2 {
 		return false, nil
 	}
 
-	if selected == len(components)+1 {
+	if len(selected) == 1 && selected[0] == len(components)+1 {
 		return handleComponentSearch(client, reader, ticket, projectKey, components, state, statePath)
 	}
 
-	if selected < 1 || selected > len(components) {
-		return false, fmt.Errorf("invalid selection: %d", selected)
+	chosen := make([]jira.Component, 0, len(selected))
+	for _, number := range selected {
+		if number < 1 || number > len(components) {
+			return false, fmt.Errorf("invalid selection: %d", number)
+		}
+		chosen = append(chosen, components[number-1])
+	}
+
+	return updateComponentsAndSave(client, ticket.Key, chosen, state, statePath)
+}
+
+// pickComponents chooses one or more components with the picker, recent ones first
+// A name that matches none of them is looked up as in the component search; leaving
+// the picker skips the step
+func pickComponents(
+	client jira.JiraClient, reader *bufio.Reader, ticket *jira.Issue,
+	projectKey string, components []jira.Component, state *config.State, statePath string,
+) (bool, error) {
+	items := make([]tui.PickItem, 0, len(components))
+	for _, comp := range components {
+		items = append(items, tui.PickItem{Label: comp.Name, Detail: comp.Description, Value: comp.ID})
+	}
+
+	picker := tui.NewPicker("Select components", tui.PinRecent(items, state.RecentComponents), true)
+	picked, err := tui.Pick(picker)
+	if errors.Is(err, tui.ErrCancelled) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if len(picked) == 0 {
+		return handleComponentNotFound(client, reader, ticket, projectKey, picker.Query(), state, statePath)
 	}
 
-	return updateComponentAndSave(client, ticket.Key, components[selected-1], state, statePath)
+	chosen := make([]jira.Component, 0, len(picked))
+	for _, item := range picked {
+		chosen = append(chosen, jira.Component{ID: item.Value, Name: item.Label})
+	}
+	return updateComponentsAndSave(client, ticket.Key, chosen, state, statePath)
+}
+
+// confirmSuggestedComponent offers the suggested component, if it exists, and
+// reports whether the user accepted it
+func confirmSuggestedComponent(
+	reader *bufio.Reader, components []jira.Component, suggested Suggestion,
+) (jira.Component, bool) {
+	if suggested.Value == "" {
+		return jira.Component{}, false
+	}
+	for _, comp := range components {
+		if !strings.EqualFold(comp.Name, suggested.Value) {
+			continue
+		}
+		printSuggestion(suggested)
+		use, err := prompt.Confirm(reader, "use_component", fmt.Sprintf("Use component %s? [Y/n] ", comp.Name), true)
+		if err != nil {
+			return jira.Component{}, false
+		}
+		return comp, use
+	}
+	return jira.Component{}, false
 }
 
 func fetchComponentsWithRetry(
@@ -106,13 +220,11 @@ func fetchComponentsWithRetry(
 	}
 
 	fmt.Printf("Failed to fetch components: %v\n", err)
-	fmt.Print("Retry without cache? [y/N]: ")
-	retryInput, err := reader.ReadString('\n')
-	if err != nil {
-		return nil, err
+	retry, retryErr := prompt.Offer(reader, "retry_components", "Retry without cache? [y/N]: ")
+	if retryErr != nil {
+		return nil, retryErr
 	}
-	retryInput = strings.TrimSpace(strings.ToLower(retryInput))
-	if retryInput != "y" && retryInput != "yes" {
+	if !retry {
 		return nil, err
 	}
 
@@ -135,13 +247,10 @@ func selectFromRecentComponents(
 		fmt.Printf("[%d] %s\n", i+1, compName)
 	}
 	fmt.Printf("[%d] Other...\n", len(recent)+1)
-	fmt.Print("> ")
-
-	choice, err := reader.ReadString('\n')
+	choice, err := prompt.Line(reader, "component_recent", "> ")
 	if err != nil {
 		return false, jira.Component{}, err
 	}
-	choice = strings.TrimSpace(choice)
 	selected, err := strconv.Atoi(choice)
 	if err != nil {
 		return false, jira.Component{}, fmt.Errorf("invalid selection: %s", choice)
@@ -163,23 +272,26 @@ func selectFromRecentComponents(
 	return false, jira.Component{}, nil
 }
 
-func selectFromComponentList(reader *bufio.Reader, components []jira.Component) (int, error) {
-	fmt.Println("Select component:")
+// selectFromComponentList asks for one or more components by number, separated by commas
+func selectFromComponentList(reader *bufio.Reader, components []jira.Component) ([]int, error) {
+	fmt.Println("Select component(s), e.g. 1 or 1,3:")
 	for i, comp := range components {
 		fmt.Printf("[%d] %s\n", i+1, comp.Name)
 	}
 	fmt.Printf("[%d] Search/Enter component name\n", len(components)+1)
 	fmt.Printf("[%d] Skip\n", len(components)+2)
-	fmt.Print("> ")
-
-	choice, err := reader.ReadString('\n')
+	choice, err := prompt.Line(reader, "component", "> ")
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	choice = strings.TrimSpace(choice)
-	selected, err := strconv.Atoi(choice)
-	if err != nil {
-		return 0, fmt.Errorf("invalid selection: %s", choice)
+
+	selected := []int{}
+	for _, part := range strings.Split(choice, ",") {
+		number, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection: %s", choice)
+		}
+		selected = append(selected, number)
 	}
 	return selected, nil
 }
@@ -188,12 +300,11 @@ func handleComponentSearch(
 	client jira.JiraClient, reader *bufio.Reader, ticket *jira.Issue,
 	projectKey string, components []jira.Component, state *config.State, statePath string,
 ) (bool, error) {
-	fmt.Print("Enter component name to search for (or exact name to create): ")
-	searchInput, err := reader.ReadString('\n')
+	searchInput, err := prompt.Line(reader, "component_search",
+		"Enter component name to search for (or exact name to create): ")
 	if err != nil {
 		return false, err
 	}
-	searchInput = strings.TrimSpace(searchInput)
 	if searchInput == "" {
 		return false, fmt.Errorf("component name cannot be empty")
 	}
@@ -292,13 +403,10 @@ func selectFromMatchingComponents(
 		fmt.Printf("[%d] %s\n", i+1, comp.Name)
 	}
 	fmt.Printf("[%d] Cancel\n", len(matchingComponents)+1)
-	fmt.Print("> ")
-
-	matchChoice, err := reader.ReadString('\n')
+	matchChoice, err := prompt.Line(reader, "component_match", "> ")
 	if err != nil {
 		return false, err
 	}
-	matchChoice = strings.TrimSpace(matchChoice)
 	matchSelected, err := strconv.Atoi(matchChoice)
 	if err != nil {
 		return false, fmt.Errorf("invalid selection: %s", matchChoice)
@@ -319,10 +427,23 @@ func updateComponentAndSave(
 	client jira.JiraClient, ticketKey string, comp jira.Component,
 	state *config.State, statePath string,
 ) (bool, error) {
-	if err := client.UpdateTicketComponents(ticketKey, []string{comp.ID}); err != nil {
+	return updateComponentsAndSave(client, ticketKey, []jira.Component{comp}, state, statePath)
+}
+
+func updateComponentsAndSave(
+	client jira.JiraClient, ticketKey string, components []jira.Component,
+	state *config.State, statePath string,
+) (bool, error) {
+	ids := make([]string, len(components))
+	for i, comp := range components {
+		ids[i] = comp.ID
+	}
+	if err := client.UpdateTicketComponents(ticketKey, ids); err != nil {
 		return false, err
 	}
-	state.AddRecentComponent(comp.Name)
+	for _, comp := range components {
+		state.AddRecentComponent(comp.Name)
+	}
 	if err := config.SaveState(state, statePath); err != nil {
 		_ = err // Ignore - state saving is optional
 	}
@@ -330,7 +451,10 @@ func updateComponentAndSave(
 }
 
 // HandlePriorityStep checks and assigns priority if missing
-func HandlePriorityStep(client jira.JiraClient, reader *bufio.Reader, ticket *jira.Issue) (bool, error) {
+// suggested, if it is one of the priorities, is offered as the default
+func HandlePriorityStep(
+	client jira.JiraClient, reader *bufio.Reader, ticket *jira.Issue, suggested Suggestion,
+) (bool, error) {
 	// Check if priority is set
 	if ticket.Fields.Priority.Name != "" {
 		return true, nil // Already set
@@ -342,22 +466,22 @@ func HandlePriorityStep(client jira.JiraClient, reader *bufio.Reader, ticket *ji
 		return false, fmt.Errorf("failed to fetch priorities: %w", err)
 	}
 
-	fmt.Println("Select priority:")
+	names := make([]string, len(priorities))
 	for i, p := range priorities {
-		fmt.Printf("[%d] %s\n", i+1, p.Name)
+		names[i] = p.Name
+	}
+	defaultChoice := suggestedChoice(names, suggested.Value)
+	if defaultChoice > 0 {
+		printSuggestion(suggested)
 	}
-	fmt.Printf("[%d] Skip\n", len(priorities)+1)
-	fmt.Print("> ")
 
-	choice, err := reader.ReadString('\n')
+	fmt.Println("Select priority:")
+	printChoices(names, defaultChoice)
+	fmt.Printf("[%d] Skip\n", len(priorities)+1)
+	selected, err := readChoice(reader, "priority", defaultChoice)
 	if err != nil {
 		return false, err
 	}
-	choice = strings.TrimSpace(choice)
-	selected, err := strconv.Atoi(choice)
-	if err != nil {
-		return false, fmt.Errorf("invalid selection: %s", choice)
-	}
 
 	if selected == len(priorities)+1 {
 		// User skipped
@@ -376,15 +500,69 @@ func HandlePriorityStep(client jira.JiraClient, reader *bufio.Reader, ticket *ji
 	return true, nil
 }
 
+// suggestedChoice returns the 1-based position of suggested in names, or 0 if it isn't there
+func suggestedChoice(names []string, suggested string) int {
+	if suggested == "" {
+		return 0
+	}
+	for i, name := range names {
+		if strings.EqualFold(name, suggested) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// printSuggestion shows a suggested value, where it came from and why
+func printSuggestion(suggested Suggestion) {
+	source := suggested.Source
+	if source == "" {
+		source = "suggested"
+	}
+	if suggested.Rationale != "" {
+		fmt.Printf("💡 %s (%s): %s\n", suggested.Value, source, suggested.Rationale)
+	} else {
+		fmt.Printf("💡 %s (%s)\n", suggested.Value, source)
+	}
+}
+
+// printChoices prints a numbered list, marking the default choice (if any)
+func printChoices(names []string, defaultChoice int) {
+	for i, name := range names {
+		if i+1 == defaultChoice {
+			fmt.Printf("[%d] %s (suggested, press Enter)\n", i+1, name)
+		} else {
+			fmt.Printf("[%d] %s\n", i+1, name)
+		}
+	}
+}
+
+// readChoice reads a numbered choice; an empty answer picks defaultChoice, if there is one
+func readChoice(reader *bufio.Reader, name string, defaultChoice int) (int, error) {
+	choice, err := prompt.Line(reader, name, "> ")
+	if err != nil {
+		return 0, err
+	}
+	if choice == "" && defaultChoice > 0 {
+		return defaultChoice, nil
+	}
+	selected, err := strconv.Atoi(choice)
+	if err != nil {
+		return 0, fmt.Errorf("invalid selection: %s", choice)
+	}
+	return selected, nil
+}
+
 // HandleSeverityStep checks and assigns severity if configured and missing
+// suggested, if it is one of the allowed values, is offered as the default
 func HandleSeverityStep(
-	client jira.JiraClient, reader *bufio.Reader, cfg *config.Config, ticket *jira.Issue,
+	client jira.JiraClient, reader *bufio.Reader, cfg *config.Config, ticket *jira.Issue, suggested Suggestion,
 ) (bool, error) {
 	if cfg.SeverityFieldID == "" {
 		return true, nil
 	}
 
-	if isSeverityAlreadySet(client, ticket.Key, cfg.SeverityFieldID) {
+	if IsSeveritySet(client, ticket.Key, cfg.SeverityFieldID) {
 		return true, nil
 	}
 
@@ -397,27 +575,7 @@ func HandleSeverityStep(
 		return handleSeverityWithoutValues(reader)
 	}
 
-	return selectAndSetSeverity(client, reader, ticket.Key, cfg.SeverityFieldID, values)
-}
-
-func isSeverityAlreadySet(client jira.JiraClient, ticketKey, severityFieldID string) bool {
-	rawTicket, err := client.GetTicketRaw(ticketKey)
-	if err != nil {
-		return false
-	}
-
-	fields, ok := rawTicket["fields"].(map[string]interface{})
-	if !ok {
-		return false
-	}
-
-	severityValue, ok := fields[severityFieldID]
-	if !ok || severityValue == nil {
-		return false
-	}
-
-	currentValue := extractSeverityValue(severityValue)
-	return currentValue != ""
+	return selectAndSetSeverity(client, reader, ticket.Key, cfg.SeverityFieldID, values, suggested)
 }
 
 func extractSeverityValue(severityValue interface{}) string {
@@ -450,15 +608,10 @@ func getSeverityValues(client jira.JiraClient, cfg *config.Config) ([]string, er
 
 func handleSeverityWithoutValues(reader *bufio.Reader) (bool, error) {
 	fmt.Println("Severity field is configured but has no predefined values.")
-	fmt.Print("Set severity? [y/N] ")
-	response, err := reader.ReadString('\n')
-	if err != nil {
+	set, err := prompt.Offer(reader, "set_severity", "Set severity? [y/N] ")
+	if err != nil || !set {
 		return false, err
 	}
-	response = strings.TrimSpace(strings.ToLower(response))
-	if response != "y" && response != "yes" {
-		return false, nil
-	}
 
 	fmt.Println("Note: Setting custom severity values is not yet implemented.")
 	fmt.Println("You may need to set the severity manually in Jira.")
@@ -467,24 +620,20 @@ func handleSeverityWithoutValues(reader *bufio.Reader) (bool, error) {
 
 func selectAndSetSeverity(
 	client jira.JiraClient, reader *bufio.Reader,
-	ticketKey, severityFieldID string, values []string,
+	ticketKey, severityFieldID string, values []string, suggested Suggestion,
 ) (bool, error) {
-	fmt.Println("Select severity:")
-	for i, v := range values {
-		fmt.Printf("[%d] %s\n", i+1, v)
+	defaultChoice := suggestedChoice(values, suggested.Value)
+	if defaultChoice > 0 {
+		printSuggestion(suggested)
 	}
-	fmt.Printf("[%d] Skip\n", len(values)+1)
-	fmt.Print("> ")
 
-	choice, err := reader.ReadString('\n')
+	fmt.Println("Select severity:")
+	printChoices(values, defaultChoice)
+	fmt.Printf("[%d] Skip\n", len(values)+1)
+	selected, err := readChoice(reader, "severity", defaultChoice)
 	if err != nil {
 		return false, err
 	}
-	choice = strings.TrimSpace(choice)
-	selected, err := strconv.Atoi(choice)
-	if err != nil {
-		return false, fmt.Errorf("invalid selection: %s", choice)
-	}
 
 	if selected == len(values)+1 {
 		return false, nil
@@ -508,8 +657,9 @@ func HandleStoryPointsStep(
 	client jira.JiraClient,
 	geminiClient gemini.GeminiClient,
 	reader *bufio.Reader,
-	_ *config.Config,
+	cfg *config.Config,
 	ticket *jira.Issue,
+	configDir string,
 ) (bool, error) {
 	// Check if story points are set
 	if ticket.Fields.StoryPoints > 0 {
@@ -525,14 +675,24 @@ func HandleStoryPointsStep(
 	// Get AI suggestion
 	options := []int{1, 2, 3, 5, 8, 13}
 	var aiReasoning string
-	estimate, reasoning, err := geminiClient.EstimateStoryPoints(ticket.Fields.Summary, description, options)
+	var suggestedPoints int
+	referenceCount := estimate.DefaultReferenceCount
+	if cfg != nil {
+		referenceCount = estimate.ReferenceCount(cfg.EstimateReferenceCount)
+	}
+	aiPoints, reasoning, references, err := estimate.EstimateWithReferences(
+		geminiClient, client, ticket, description, options, referenceCount)
 	if err != nil {
 		// If AI fails, continue with manual selection
 		fmt.Println("Could not get AI estimate, proceeding with manual selection")
 	} else {
-		fmt.Printf("🤖 AI Estimate: %d story points\n", estimate)
+		fmt.Printf("🤖 AI Estimate: %d story points\n", aiPoints)
 		fmt.Printf("   Reasoning: %s\n", reasoning)
+		if len(references) > 0 {
+			fmt.Printf("   Compared with: %s\n", estimate.FormatReferences(references))
+		}
 		aiReasoning = reasoning // Store for later use
+		suggestedPoints = aiPoints
 	}
 
 	fmt.Println("\nSelect story points:")
@@ -543,13 +703,11 @@ func HandleStoryPointsStep(
 		}
 	}
 	fmt.Println()
-	fmt.Print("Enter letter, number, or 'skip': > ")
-
-	input, err := reader.ReadString('\n')
+	input, err := prompt.Line(reader, "points", "Enter letter, number, or 'skip': > ")
 	if err != nil {
 		return false, err
 	}
-	input = strings.TrimSpace(strings.ToLower(input))
+	input = strings.ToLower(input)
 
 	if input == "skip" {
 		return false, nil
@@ -559,19 +717,7 @@ func HandleStoryPointsStep(
 	if len(input) == 1 {
 		for i, letter := range letters {
 			if input == letter && i < len(options) {
-				points := options[i]
-				if err := client.UpdateTicketPoints(ticket.Key, points); err != nil {
-					return false, err
-				}
-				// Add AI reasoning as comment if available
-				if aiReasoning != "" {
-					comment := fmt.Sprintf("🤖 *AI Story Point Estimate: %d points*\n\n%s", points, aiReasoning)
-					if err := client.AddComment(ticket.Key, comment); err != nil {
-						// Log but don't fail - comment is optional
-						fmt.Printf("Warning: Could not add reasoning comment: %v\n", err)
-					}
-				}
-				return true, nil
+				return applyStoryPoints(client, ticket, options[i], suggestedPoints, aiReasoning, configDir)
 			}
 		}
 	}
@@ -579,23 +725,35 @@ func HandleStoryPointsStep(
 	// Try to parse as number
 	points, err := strconv.Atoi(input)
 	if err == nil {
-		if err := client.UpdateTicketPoints(ticket.Key, points); err != nil {
-			return false, err
-		}
-		// Add AI reasoning as comment if available
-		if aiReasoning != "" {
-			comment := fmt.Sprintf("🤖 *AI Story Point Estimate: %d points*\n\n%s", points, aiReasoning)
-			if err := client.AddComment(ticket.Key, comment); err != nil {
-				// Log but don't fail - comment is optional
-				fmt.Printf("Warning: Could not add reasoning comment: %v\n", err)
-			}
-		}
-		return true, nil
+		return applyStoryPoints(client, ticket, points, suggestedPoints, aiReasoning, configDir)
 	}
 
 	return false, fmt.Errorf("invalid input: %s", input)
 }
 
+// applyStoryPoints sets the chosen story points, comments with the AI reasoning (if any)
+// and records the AI suggestion alongside the chosen points for accuracy tracking
+func applyStoryPoints(
+	client jira.JiraClient, ticket *jira.Issue, points, suggestedPoints int, aiReasoning, configDir string,
+) (bool, error) {
+	if err := client.UpdateTicketPoints(ticket.Key, points); err != nil {
+		return false, err
+	}
+
+	// Add AI reasoning as comment if available
+	if aiReasoning != "" {
+		comment := fmt.Sprintf("🤖 *AI Story Point Estimate: %d points* (chosen: %d)\n\n%s",
+			suggestedPoints, points, aiReasoning)
+		if err := client.AddComment(ticket.Key, comment); err != nil {
+			// Log but don't fail - comment is optional
+			fmt.Printf("Warning: Could not add reasoning comment: %v\n", err)
+		}
+	}
+
+	estimate.RecordEstimate(configDir, ticket, suggestedPoints, points)
+	return true, nil
+}
+
 // HandleBacklogTransitionStep transitions ticket to Backlog if in "New" state
 func HandleBacklogTransitionStep(client jira.JiraClient, ticket *jira.Issue) (bool, error) {
 	// Check if ticket is in "New" state
@@ -656,13 +814,10 @@ func SelectBoard(client jira.JiraClient, reader *bufio.Reader, cfg *config.Confi
 	for i, board := range boards {
 		fmt.Printf("[%d] %s (%s)\n", i+1, board.Name, board.Type)
 	}
-	fmt.Print("> ")
-
-	choice, err := reader.ReadString('\n')
+	choice, err := prompt.Line(reader, "board", "> ")
 	if err != nil {
 		return 0, err
 	}
-	choice = strings.TrimSpace(choice)
 	selected, err := strconv.Atoi(choice)
 	if err != nil {
 		return 0, fmt.Errorf("invalid selection: %s", choice)
@@ -0,0 +1,136 @@
+package review
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/gemini"
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+// descriptionClient returns a fixed description; other methods are not used
+type descriptionClient struct {
+	jira.JiraClient
+	description string
+}
+
+func (c *descriptionClient) GetTicketDescription(_ string) (string, error) {
+	return c.description, nil
+}
+
+// rubricClient returns a fixed assessment; other methods are not used
+type rubricClient struct {
+	gemini.GeminiClient
+	assessment *gemini.DescriptionAssessment
+	err        error
+	calls      int
+}
+
+func (c *rubricClient) AssessDescription(_, _, _ string) (*gemini.DescriptionAssessment, error) {
+	c.calls++
+	return c.assessment, c.err
+}
+
+func TestCheckDescriptionQuality(t *testing.T) {
+	ticket := &jira.Issue{Key: "ENG-1"}
+	client := &descriptionClient{description: "Users can't reset their password from the login page."}
+	assessment := &gemini.DescriptionAssessment{Scores: []gemini.RubricScore{
+		{Criterion: gemini.CriterionProblem, Score: 2},
+		{Criterion: gemini.CriterionAcceptanceCriteria, Score: 0, Hint: "Describe what a successful reset looks like"},
+		{Criterion: gemini.CriterionTestNotes, Score: 1, Hint: "Mention the email flow"},
+	}}
+
+	t.Run("length only", func(t *testing.T) {
+		cfg := &config.Config{DescriptionMinLength: 200}
+		quality, err := CheckDescriptionQuality(client, nil, ticket, cfg)
+		if err != nil {
+			t.Fatalf("CheckDescriptionQuality failed: %v", err)
+		}
+		if quality.Valid || !strings.HasPrefix(quality.Reason, "too short") {
+			t.Errorf("Expected too short, got %+v", quality)
+		}
+	})
+
+	t.Run("AI disabled", func(t *testing.T) {
+		rubric := &rubricClient{assessment: assessment}
+		quality, err := CheckDescriptionQuality(client, rubric, ticket, &config.Config{})
+		if err != nil {
+			t.Fatalf("CheckDescriptionQuality failed: %v", err)
+		}
+		if !quality.Valid || rubric.calls != 0 {
+			t.Errorf("Expected valid without AI call, got %+v after %d calls", quality, rubric.calls)
+		}
+	})
+
+	t.Run("rubric", func(t *testing.T) {
+		rubric := &rubricClient{assessment: assessment}
+		quality, err := CheckDescriptionQuality(client, rubric, ticket, &config.Config{DescriptionQualityAI: true})
+		if err != nil {
+			t.Fatalf("CheckDescriptionQuality failed: %v", err)
+		}
+		if quality.Valid || quality.Reason != "missing acceptance criteria" {
+			t.Errorf("Expected missing acceptance criteria, got %+v", quality)
+		}
+
+		seed := quality.SeedContext()
+		if !strings.Contains(seed, "Acceptance criteria: Describe what a successful reset looks like") ||
+			!strings.Contains(seed, "Test notes: Mention the email flow") {
+			t.Errorf("Expected hints in seed context, got:\n%s", seed)
+		}
+	})
+
+	t.Run("AI unavailable", func(t *testing.T) {
+		rubric := &rubricClient{err: errors.New("quota exceeded")}
+		quality, err := CheckDescriptionQuality(client, rubric, ticket, &config.Config{DescriptionQualityAI: true})
+		if err != nil {
+			t.Fatalf("CheckDescriptionQuality failed: %v", err)
+		}
+		if !quality.Valid || quality.Assessment != nil || quality.SeedContext() != "" {
+			t.Errorf("Expected fallback to the length check, got %+v", quality)
+		}
+	})
+}
+
+// componentClient lists fixed components and records the ones set on a ticket
+type componentClient struct {
+	jira.JiraClient
+	components []jira.Component
+	updated    []string
+}
+
+func (c *componentClient) GetComponents(_ string) ([]jira.Component, error) {
+	return c.components, nil
+}
+
+func (c *componentClient) UpdateTicketComponents(_ string, componentIDs []string) error {
+	c.updated = componentIDs
+	return nil
+}
+
+func TestHandleComponentStepSeveral(t *testing.T) {
+	client := &componentClient{components: []jira.Component{
+		{ID: "10", Name: "Backend"}, {ID: "11", Name: "Frontend"}, {ID: "12", Name: "Docs"},
+	}}
+	configDir := t.TempDir()
+	reader := bufio.NewReader(strings.NewReader("1, 3\n"))
+
+	done, err := HandleComponentStep(client, reader, &config.Config{DefaultProject: "ENG"},
+		&jira.Issue{Key: "ENG-1"}, configDir, Suggestion{})
+	if err != nil || !done {
+		t.Fatalf("HandleComponentStep = %v, %v", done, err)
+	}
+	if strings.Join(client.updated, ",") != "10,12" {
+		t.Errorf("Expected components 10 and 12, got %v", client.updated)
+	}
+
+	state, err := config.LoadState(config.GetStatePath(configDir))
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if strings.Join(state.RecentComponents, ",") != "Backend,Docs" {
+		t.Errorf("Expected both components remembered, got %v", state.RecentComponents)
+	}
+}
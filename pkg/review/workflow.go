@@ -9,6 +9,7 @@ import (
 	"github.com/beekhof/jira-tool/pkg/editor"
 	"github.com/beekhof/jira-tool/pkg/gemini"
 	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/prompt"
 	"github.com/beekhof/jira-tool/pkg/qa"
 )
 
@@ -23,6 +24,11 @@ const (
 	StepStoryPoints
 	StepBacklog
 	StepAssignment
+	StepLabels
+	StepFixVersion
+	StepDueDate
+	StepLinks
+	StepCustomField
 )
 
 // String returns the string representation of a workflow step
@@ -42,6 +48,16 @@ func (ws WorkflowStep) String() string {
 		return "Backlog State"
 	case StepAssignment:
 		return "Assignment"
+	case StepLabels:
+		return "Labels"
+	case StepFixVersion:
+		return "Fix Version"
+	case StepDueDate:
+		return "Due Date"
+	case StepLinks:
+		return "Links"
+	case StepCustomField:
+		return "Custom Field"
 	default:
 		return "Unknown"
 	}
@@ -56,44 +72,66 @@ type TicketStatus struct {
 	StoryPointsComplete bool
 	BacklogComplete     bool
 	AssignmentComplete  bool
+	LabelsComplete      bool
+	FixVersionComplete  bool
+	DueDateComplete     bool
+	LinksComplete       bool
+	// Completion of user-defined field steps, by step name
+	FieldStepsComplete map[string]bool
+	// Steps skipped in an earlier sitting of a review session, by step ID
+	SkippedSteps map[string]bool
+	// Pipeline is the steps the ticket goes through; nil means DefaultPipeline,
+	// while an empty pipeline (e.g. every step disabled in review_workflow) has no steps
+	Pipeline []Step
 }
 
-// IsComplete returns true if all required steps are complete
-func (ts *TicketStatus) IsComplete() bool {
-	return ts.DescriptionComplete &&
-		ts.ComponentComplete &&
-		ts.PriorityComplete &&
-		ts.SeverityComplete &&
-		ts.StoryPointsComplete &&
-		ts.BacklogComplete &&
-		ts.AssignmentComplete
+// Steps returns the steps the ticket goes through
+func (ts *TicketStatus) Steps() []Step {
+	if ts.Pipeline == nil {
+		return DefaultPipeline()
+	}
+	return ts.Pipeline
 }
 
-// GetNextStep returns the first incomplete step, or nil if all complete
-func (ts *TicketStatus) GetNextStep() WorkflowStep {
-	if !ts.DescriptionComplete {
-		return StepDescription
-	}
-	if !ts.ComponentComplete {
-		return StepComponent
-	}
-	if !ts.PriorityComplete {
-		return StepPriority
-	}
-	if !ts.SeverityComplete {
-		return StepSeverity
+// HasStep checks if a kind of step is part of the ticket's pipeline
+func (ts *TicketStatus) HasStep(kind WorkflowStep) bool {
+	for _, step := range ts.Steps() {
+		if step.Kind == kind {
+			return true
+		}
 	}
-	if !ts.StoryPointsComplete {
-		return StepStoryPoints
+	return false
+}
+
+// Needs checks if a kind of step is part of the ticket's pipeline and not yet complete
+func (ts *TicketStatus) Needs(kind WorkflowStep) bool {
+	return ts.HasStep(kind) && !ts.IsStepComplete(kind)
+}
+
+// IsComplete returns true if all steps in the pipeline are complete
+func (ts *TicketStatus) IsComplete() bool {
+	for _, step := range ts.Steps() {
+		if !ts.isDone(step) {
+			return false
+		}
 	}
-	if !ts.BacklogComplete {
-		return StepBacklog
+	return true
+}
+
+// GetNextStep returns the first incomplete step, or the last step if all are complete
+// A pipeline without steps is always complete, and returns StepDescription
+func (ts *TicketStatus) GetNextStep() WorkflowStep {
+	steps := ts.Steps()
+	for _, step := range steps {
+		if !ts.isDone(step) {
+			return step.Kind
+		}
 	}
-	if !ts.AssignmentComplete {
-		return StepAssignment
+	if len(steps) == 0 {
+		return StepDescription
 	}
 	// All complete - return last step as sentinel
-	return StepAssignment
+	return steps[len(steps)-1].Kind
 }
 
 // MarkComplete marks a step as complete
@@ -113,71 +151,88 @@ func (ts *TicketStatus) MarkComplete(step WorkflowStep) {
 		ts.BacklogComplete = true
 	case StepAssignment:
 		ts.AssignmentComplete = true
+	case StepLabels:
+		ts.LabelsComplete = true
+	case StepFixVersion:
+		ts.FixVersionComplete = true
+	case StepDueDate:
+		ts.DueDateComplete = true
+	case StepLinks:
+		ts.LinksComplete = true
 	}
 }
 
-// InitializeStatusFromTicket creates a TicketStatus based on the current ticket state
-func InitializeStatusFromTicket(client jira.JiraClient, ticket *jira.Issue, cfg *config.Config) TicketStatus {
-	status := TicketStatus{}
-
-	// Check Description
-	isValid, _, err := CheckDescriptionQuality(client, ticket, cfg)
-	if err == nil && isValid {
-		status.DescriptionComplete = true
-	}
-
-	// Check Component
-	if len(ticket.Fields.Components) > 0 {
-		status.ComponentComplete = true
-	}
-
-	// Check Priority
-	if ticket.Fields.Priority.Name != "" {
-		status.PriorityComplete = true
-	}
-
-	// Check Severity (only if configured)
-	if cfg.SeverityFieldID != "" {
-		rawTicket, err := client.GetTicketRaw(ticket.Key)
-		if err == nil {
-			if fields, ok := rawTicket["fields"].(map[string]interface{}); ok {
-				if severityValue, ok := fields[cfg.SeverityFieldID]; ok && severityValue != nil {
-					// Check if it's a value object (map) or direct string
-					var currentValue string
-					switch v := severityValue.(type) {
-					case map[string]interface{}:
-						if val, ok := v["value"].(string); ok {
-							currentValue = val
-						} else if val, ok := v["name"].(string); ok {
-							currentValue = val
-						}
-					case string:
-						currentValue = v
-					}
-					if currentValue != "" {
-						// Severity is already set
-						status.SeverityComplete = true
-					}
-				}
-			}
-		}
+// isDone checks if a pipeline step is complete, including user-defined field steps
+func (ts *TicketStatus) isDone(step Step) bool {
+	if step.Field != nil {
+		return ts.FieldStepsComplete[step.Field.Name]
 	}
+	return ts.IsStepComplete(step.Kind)
+}
 
-	// Check Story Points
-	if ticket.Fields.StoryPoints > 0 {
-		status.StoryPointsComplete = true
+// markDone marks a pipeline step as complete, including user-defined field steps
+func (ts *TicketStatus) markDone(step Step) {
+	if step.Field == nil {
+		ts.MarkComplete(step.Kind)
+		return
 	}
-
-	// Check Backlog State (not in "New" state means already transitioned)
-	if ticket.Fields.Status.Name != "New" {
-		status.BacklogComplete = true
+	if ts.FieldStepsComplete == nil {
+		ts.FieldStepsComplete = map[string]bool{}
 	}
+	ts.FieldStepsComplete[step.Field.Name] = true
+}
 
-	// Check Assignment
-	if ticket.Fields.Assignee.DisplayName != "" ||
-		ticket.Fields.Assignee.AccountID != "" ||
-		ticket.Fields.Assignee.Name != "" {
-		status.AssignmentComplete = true
+// InitializeStatusFromTicket creates a TicketStatus for a pipeline based on the current ticket state
+// Only the steps in the pipeline are checked. The description is only checked for length here;
+// AI rubric scoring happens in ProcessTicketWorkflow
+func InitializeStatusFromTicket(
+	client jira.JiraClient, ticket *jira.Issue, cfg *config.Config, pipeline []Step,
+) TicketStatus {
+	status := TicketStatus{Pipeline: pipeline}
+
+	// Labels, fix versions, due dates, links and custom fields aren't in search results
+	var fields map[string]interface{}
+	rawFields := func() map[string]interface{} {
+		if fields == nil {
+			fields = TicketFields(client, ticket.Key)
+		}
+		return fields
+	}
+
+	for _, step := range status.Steps() {
+		switch step.Kind {
+		case StepDescription:
+			quality, err := CheckDescriptionQuality(client, nil, ticket, cfg)
+			status.DescriptionComplete = err == nil && quality.Valid
+		case StepComponent:
+			status.ComponentComplete = HasComponent(ticket)
+		case StepPriority:
+			status.PriorityComplete = HasPriority(ticket)
+		case StepSeverity:
+			// Only if configured
+			if cfg.SeverityFieldID != "" {
+				status.SeverityComplete = IsSeveritySet(client, ticket.Key, cfg.SeverityFieldID)
+			}
+		case StepStoryPoints:
+			status.StoryPointsComplete = HasStoryPoints(ticket)
+		case StepBacklog:
+			// Not in "New" state means already transitioned
+			status.BacklogComplete = IsOutOfNew(ticket)
+		case StepAssignment:
+			status.AssignmentComplete = IsAssigned(ticket)
+		case StepLabels:
+			status.LabelsComplete = HasFieldValue(rawFields(), "labels")
+		case StepFixVersion:
+			status.FixVersionComplete = HasFieldValue(rawFields(), "fixVersions")
+		case StepDueDate:
+			status.DueDateComplete = HasFieldValue(rawFields(), "duedate")
+		case StepLinks:
+			status.LinksComplete = HasFieldValue(rawFields(), "issuelinks")
+		case StepCustomField:
+			if HasFieldStepValues(rawFields(), step.Field) {
+				status.markDone(step)
+			}
+		}
 	}
 
 	return status
@@ -189,47 +244,15 @@ func DisplayProgress(ticket *jira.Issue, status TicketStatus) {
 	fmt.Println("Progress:")
 
 	// Display each step with completion indicator
-	marker := " "
-	if status.DescriptionComplete {
-		marker = "✓"
-	}
-	fmt.Printf("  [%s] Description\n", marker)
-
-	marker = " "
-	if status.ComponentComplete {
-		marker = "✓"
-	}
-	fmt.Printf("  [%s] Component\n", marker)
-
-	marker = " "
-	if status.PriorityComplete {
-		marker = "✓"
-	}
-	fmt.Printf("  [%s] Priority\n", marker)
-
-	marker = " "
-	if status.SeverityComplete {
-		marker = "✓"
-	}
-	fmt.Printf("  [%s] Severity\n", marker)
-
-	marker = " "
-	if status.StoryPointsComplete {
-		marker = "✓"
-	}
-	fmt.Printf("  [%s] Story Points\n", marker)
-
-	marker = " "
-	if status.BacklogComplete {
-		marker = "✓"
-	}
-	fmt.Printf("  [%s] Backlog State\n", marker)
-
-	marker = " "
-	if status.AssignmentComplete {
-		marker = "✓"
+	for _, step := range status.Steps() {
+		marker := " "
+		if status.isDone(step) {
+			marker = "✓"
+		} else if status.SkippedSteps[step.ID()] {
+			marker = "-"
+		}
+		fmt.Printf("  [%s] %s\n", marker, step.Label())
 	}
-	fmt.Printf("  [%s] Assignment\n", marker)
 	fmt.Println()
 }
 
@@ -243,15 +266,15 @@ const (
 )
 
 // HandleWorkflowError handles errors during workflow execution
-func HandleWorkflowError(err error, step WorkflowStep, reader *bufio.Reader) (Action, error) {
-	fmt.Printf("\nError in %s: %v\n", step.String(), err)
-	fmt.Print("What would you like to do? [r]etry | [s]kip remaining | [a]bort > ")
-
-	input, err := reader.ReadString('\n')
+// stepName is the label of the step that failed
+func HandleWorkflowError(err error, stepName string, reader *bufio.Reader) (Action, error) {
+	fmt.Printf("\nError in %s: %v\n", stepName, err)
+	input, err := prompt.Line(reader, "workflow_error",
+		"What would you like to do? [r]etry | [s]kip remaining | [a]bort > ")
 	if err != nil {
 		return ActionAbort, err
 	}
-	input = strings.TrimSpace(strings.ToLower(input))
+	input = strings.ToLower(input)
 
 	switch input {
 	case "r", "retry":
@@ -267,94 +290,198 @@ func HandleWorkflowError(err error, step WorkflowStep, reader *bufio.Reader) (Ac
 	}
 }
 
+// Suggestion is a suggested value for a triage step and where it came from
+type Suggestion struct {
+	Value     string
+	Source    string // e.g. "AI" or the triage rule that set it
+	Rationale string
+}
+
+// StepDefaults are suggested values for the triage steps (from triage rules or AI),
+// offered as the default choice when prompting
+type StepDefaults struct {
+	Priority  Suggestion
+	Severity  Suggestion
+	Component Suggestion
+}
+
 // ProcessTicketWorkflow processes a single ticket through the guided review workflow
+// The steps come from the review_workflow config for the ticket's project and issue type.
+// defaults may be nil if there are no suggestions. progress may be nil outside a review session;
+// otherwise steps completed or skipped in earlier sittings aren't asked again, and each step's
+// outcome is recorded in it.
 func ProcessTicketWorkflow(
 	client jira.JiraClient, geminiClient gemini.GeminiClient, reader *bufio.Reader,
-	cfg *config.Config, ticket *jira.Issue, configDir string,
+	cfg *config.Config, ticket *jira.Issue, configDir string, defaults *StepDefaults, progress *TicketProgress,
 ) error {
+	if defaults == nil {
+		defaults = &StepDefaults{}
+	}
+
+	pipeline, err := ResolvePipeline(cfg, ticket)
+	if err != nil {
+		return err
+	}
+	if len(pipeline) == 0 {
+		fmt.Printf("No review steps are configured for %s (%s); nothing to review.\n",
+			ticket.Key, ticket.Fields.IssueType.Name)
+		return nil
+	}
+
+	if geminiClient != nil {
+		geminiClient.SetTicketKey(ticket.Key)
+	}
+
 	// Initialize status based on current ticket state
 	status := &TicketStatus{}
-	*status = InitializeStatusFromTicket(client, ticket, cfg)
+	*status = InitializeStatusFromTicket(client, ticket, cfg, pipeline)
+	restoreProgress(status, progress)
+
+	run := &workflowRun{
+		client: client, geminiClient: geminiClient, reader: reader,
+		cfg: cfg, ticket: ticket, configDir: configDir, defaults: defaults,
+	}
+
+	// Score the description against the rubric up front, so the progress display
+	// reflects it and the description step can reuse the findings
+	if cfg.DescriptionQualityAI && geminiClient != nil && status.HasStep(StepDescription) {
+		if q, err := CheckDescriptionQuality(client, geminiClient, ticket, cfg); err == nil {
+			run.quality = q
+			status.DescriptionComplete = q.Valid
+		}
+	}
+
+	// One AI call recommends whatever the triage rules didn't
+	if geminiClient != nil {
+		suggestTriageValues(client, geminiClient, cfg, ticket, status, defaults)
+	}
 
 	// Display initial progress
 	DisplayProgress(ticket, *status)
 
 	// Process each step in order
-	steps := []struct {
-		step     WorkflowStep
-		handler  func() (bool, error)
-		required bool
-	}{
-		{
-			step: StepDescription,
-			handler: func() (bool, error) {
-				return handleDescriptionStep(client, geminiClient, reader, cfg, ticket)
-			},
-			required: true,
-		},
-		{
-			step: StepComponent,
-			handler: func() (bool, error) {
-				return HandleComponentStep(client, reader, cfg, ticket, configDir)
-			},
-			required: true,
-		},
-		{
-			step: StepPriority,
-			handler: func() (bool, error) {
-				return HandlePriorityStep(client, reader, ticket)
-			},
-			required: true,
-		},
-		{
-			step: StepSeverity,
-			handler: func() (bool, error) {
-				return HandleSeverityStep(client, reader, cfg, ticket)
-			},
-			required: false, // Only if configured
-		},
-		{
-			step: StepStoryPoints,
-			handler: func() (bool, error) {
-				if geminiClient == nil {
-					// Skip AI estimation if Gemini not available
-					fmt.Println("Gemini client not available - skipping story points estimation")
-					return false, nil // Skip this step
-				}
-				return HandleStoryPointsStep(client, geminiClient, reader, cfg, ticket)
-			},
-			required: true,
-		},
-		{
-			step: StepBacklog,
-			handler: func() (bool, error) {
-				return HandleBacklogTransitionStep(client, ticket)
-			},
-			required: true,
-		},
-		{
-			step: StepAssignment,
-			handler: func() (bool, error) {
-				return HandleAssignmentStep(client, reader, cfg, ticket, configDir)
-			},
-			required: false, // Optional
-		},
-	}
-
-	// Process each step
-	for _, stepInfo := range steps {
-		if shouldSkipStep(status, ticket, stepInfo.step) {
+	for _, step := range status.Steps() {
+		if shouldSkipStep(status, ticket, step) {
+			continue
+		}
+		if status.SkippedSteps[step.ID()] {
+			fmt.Printf("Skipping %s (skipped in an earlier sitting)\n", step.Label())
 			continue
 		}
 
-		if err := executeWorkflowStep(client, reader, status, ticket, stepInfo); err != nil {
+		stepInfo := pipelineStep{step: step, handler: run.handler(step)}
+		completed, err := executeWorkflowStep(client, reader, status, ticket, stepInfo)
+		if err != nil {
 			return err
 		}
+		progress.Record(step.ID(), completed)
 	}
 
 	return nil
 }
 
+// restoreProgress applies the step outcomes saved in a review session to a fresh status
+func restoreProgress(status *TicketStatus, progress *TicketProgress) {
+	if progress == nil {
+		return
+	}
+	for _, step := range status.Steps() {
+		switch {
+		case progress.IsCompleted(step.ID()):
+			status.markDone(step)
+		case progress.IsSkipped(step.ID()):
+			if status.SkippedSteps == nil {
+				status.SkippedSteps = map[string]bool{}
+			}
+			status.SkippedSteps[step.ID()] = true
+		}
+	}
+}
+
+// workflowRun holds what the step handlers need while a ticket goes through the workflow
+type workflowRun struct {
+	client       jira.JiraClient
+	geminiClient gemini.GeminiClient
+	reader       *bufio.Reader
+	cfg          *config.Config
+	ticket       *jira.Issue
+	configDir    string
+	defaults     *StepDefaults
+	// quality is the up-front description check, used by the first attempt of the description step
+	quality *DescriptionQuality
+}
+
+// pipelineStep is a step with the handler that runs it
+type pipelineStep struct {
+	step    Step
+	handler func() (bool, error)
+}
+
+// handler returns the function that runs a step
+func (r *workflowRun) handler(step Step) func() (bool, error) {
+	switch step.Kind {
+	case StepDescription:
+		return func() (bool, error) {
+			q := r.quality
+			r.quality = nil // Re-check on retry, the description may have changed
+			return handleDescriptionStep(r.client, r.geminiClient, r.reader, r.cfg, r.ticket, q)
+		}
+	case StepComponent:
+		return func() (bool, error) {
+			return HandleComponentStep(r.client, r.reader, r.cfg, r.ticket, r.configDir, r.defaults.Component)
+		}
+	case StepPriority:
+		return func() (bool, error) {
+			return HandlePriorityStep(r.client, r.reader, r.ticket, r.defaults.Priority)
+		}
+	case StepSeverity:
+		return func() (bool, error) {
+			return HandleSeverityStep(r.client, r.reader, r.cfg, r.ticket, r.defaults.Severity)
+		}
+	case StepStoryPoints:
+		return func() (bool, error) {
+			if r.geminiClient == nil {
+				// Skip AI estimation if Gemini not available
+				fmt.Println("Gemini client not available - skipping story points estimation")
+				return false, nil // Skip this step
+			}
+			return HandleStoryPointsStep(r.client, r.geminiClient, r.reader, r.cfg, r.ticket, r.configDir)
+		}
+	case StepBacklog:
+		return func() (bool, error) {
+			return HandleBacklogTransitionStep(r.client, r.ticket)
+		}
+	case StepAssignment:
+		return func() (bool, error) {
+			return HandleAssignmentStep(r.client, r.reader, r.cfg, r.ticket, r.configDir)
+		}
+	case StepLabels:
+		return func() (bool, error) {
+			return HandleLabelsStep(r.client, r.reader, r.ticket)
+		}
+	case StepFixVersion:
+		return func() (bool, error) {
+			return HandleFixVersionStep(r.client, r.reader, r.ticket)
+		}
+	case StepDueDate:
+		return func() (bool, error) {
+			return HandleDueDateStep(r.client, r.reader, r.ticket)
+		}
+	case StepLinks:
+		return func() (bool, error) {
+			return HandleLinksStep(r.client, r.reader, r.ticket)
+		}
+	case StepCustomField:
+		return func() (bool, error) {
+			return HandleFieldStep(r.client, r.reader, r.ticket, step.Field)
+		}
+	default:
+		return func() (bool, error) {
+			return false, fmt.Errorf("no handler for step %s", step.Label())
+		}
+	}
+}
+
 // IsStepComplete checks if a specific step is complete
 func (ts *TicketStatus) IsStepComplete(step WorkflowStep) bool {
 	switch step {
@@ -372,40 +499,76 @@ func (ts *TicketStatus) IsStepComplete(step WorkflowStep) bool {
 		return ts.BacklogComplete
 	case StepAssignment:
 		return ts.AssignmentComplete
+	case StepLabels:
+		return ts.LabelsComplete
+	case StepFixVersion:
+		return ts.FixVersionComplete
+	case StepDueDate:
+		return ts.DueDateComplete
+	case StepLinks:
+		return ts.LinksComplete
+	case StepCustomField:
+		// Complete once every field step in the pipeline is
+		for _, step := range ts.Steps() {
+			if step.Field != nil && !ts.FieldStepsComplete[step.Field.Name] {
+				return false
+			}
+		}
+		return true
 	default:
 		return false
 	}
 }
 
+// handleDescriptionStep checks the description and offers to improve it with the Q&A flow
+// quality is the result of an earlier check, or nil to check now
 func handleDescriptionStep(
 	client jira.JiraClient, geminiClient gemini.GeminiClient,
-	reader *bufio.Reader, cfg *config.Config, ticket *jira.Issue,
+	reader *bufio.Reader, cfg *config.Config, ticket *jira.Issue, quality *DescriptionQuality,
 ) (bool, error) {
-	isValid, reason, err := CheckDescriptionQuality(client, ticket, cfg)
-	if err != nil {
-		return false, err
+	if quality == nil {
+		var err error
+		quality, err = CheckDescriptionQuality(client, geminiClient, ticket, cfg)
+		if err != nil {
+			return false, err
+		}
 	}
-	if isValid {
+	if quality.Valid {
 		return true, nil
 	}
 
-	fmt.Printf("Description issue: %s\n", reason)
-	fmt.Print("Generate/update description? [Y/n] ")
-	response, err := reader.ReadString('\n')
-	if err != nil {
+	fmt.Printf("Description issue: %s\n", quality.Reason)
+	displayAssessment(quality.Assessment)
+	generate, err := prompt.Confirm(reader, "generate_description", "Generate/update description? [Y/n] ", true)
+	if err != nil || !generate {
 		return false, err
 	}
-	response = strings.TrimSpace(strings.ToLower(response))
-	if response != "" && response != "y" && response != "yes" {
-		return false, nil
+
+	return generateAndUpdateDescription(client, geminiClient, reader, cfg, ticket, quality.SeedContext())
+}
+
+// displayAssessment prints the rubric scores and improvement hints, if any
+func displayAssessment(assessment *gemini.DescriptionAssessment) {
+	if assessment == nil {
+		return
 	}
 
-	return generateAndUpdateDescription(client, geminiClient, reader, cfg, ticket)
+	for _, s := range assessment.Scores {
+		fmt.Printf("  %-22s %d/%d\n", gemini.CriterionLabel(s.Criterion), s.Score, gemini.MaxRubricScore)
+	}
+	if hints := assessment.Hints(); len(hints) > 0 {
+		fmt.Println("Suggestions:")
+		for _, hint := range hints {
+			fmt.Printf("  - %s\n", hint)
+		}
+	}
 }
 
+// generateAndUpdateDescription runs the Q&A flow and updates the ticket description
+// seedContext is added to the Q&A context (e.g. rubric findings to focus the questions)
 func generateAndUpdateDescription(
 	client jira.JiraClient, geminiClient gemini.GeminiClient,
-	reader *bufio.Reader, cfg *config.Config, ticket *jira.Issue,
+	reader *bufio.Reader, cfg *config.Config, ticket *jira.Issue, seedContext string,
 ) (bool, error) {
 	existingDesc, err := client.GetTicketDescription(ticket.Key)
 	if err != nil {
@@ -418,8 +581,13 @@ func generateAndUpdateDescription(
 		answerInputMethod = "readline"
 	}
 
+	initialContext := ticket.Fields.Summary
+	if seedContext != "" {
+		initialContext += "\n\n" + seedContext
+	}
+
 	description, err := qa.RunQnAFlow(
-		geminiClient, ticket.Fields.Summary, cfg.MaxQuestions,
+		geminiClient, initialContext, cfg.MaxQuestions,
 		ticket.Fields.Summary, issueTypeName, existingDesc,
 		client, ticket.Key, cfg.EpicLinkFieldID, answerInputMethod)
 	if err != nil {
@@ -430,13 +598,10 @@ func generateAndUpdateDescription(
 	fmt.Println("---")
 	fmt.Println(description)
 	fmt.Println("---")
-	fmt.Print("\nUpdate ticket with this description? [Y/n/e(dit)] ")
-
-	confirm, err := reader.ReadString('\n')
+	confirm, err := prompt.Choose(reader, "update_description", "\nUpdate ticket with this description? [Y/n/e(dit)] ")
 	if err != nil {
 		return false, err
 	}
-	confirm = strings.TrimSpace(strings.ToLower(confirm))
 
 	if confirm == "e" || confirm == "edit" {
 		editedDescription, err := editor.OpenInEditor(description)
@@ -456,26 +621,24 @@ func generateAndUpdateDescription(
 	return true, nil
 }
 
-func shouldSkipStep(status *TicketStatus, ticket *jira.Issue, step WorkflowStep) bool {
-	if status.IsStepComplete(step) {
+func shouldSkipStep(status *TicketStatus, ticket *jira.Issue, step Step) bool {
+	if status.isDone(step) {
 		return true
 	}
 
-	switch step {
+	switch step.Kind {
 	case StepComponent:
-		if len(ticket.Fields.Components) > 0 {
+		if HasComponent(ticket) {
 			status.MarkComplete(StepComponent)
 			return true
 		}
 	case StepAssignment:
-		if ticket.Fields.Assignee.DisplayName != "" ||
-			ticket.Fields.Assignee.AccountID != "" ||
-			ticket.Fields.Assignee.Name != "" {
+		if IsAssigned(ticket) {
 			status.MarkComplete(StepAssignment)
 			return true
 		}
 	case StepStoryPoints:
-		if ticket.Fields.StoryPoints > 0 {
+		if HasStoryPoints(ticket) {
 			status.MarkComplete(StepStoryPoints)
 			return true
 		}
@@ -484,42 +647,37 @@ func shouldSkipStep(status *TicketStatus, ticket *jira.Issue, step WorkflowStep)
 	return false
 }
 
+// executeWorkflowStep runs a step, handling errors, and reports whether it was completed
 func executeWorkflowStep(
 	client jira.JiraClient, reader *bufio.Reader,
-	status *TicketStatus, ticket *jira.Issue,
-	stepInfo struct {
-		step     WorkflowStep
-		handler  func() (bool, error)
-		required bool
-	},
-) error {
+	status *TicketStatus, ticket *jira.Issue, stepInfo pipelineStep,
+) (bool, error) {
 	for {
 		completed, err := stepInfo.handler()
 		if err != nil {
-			action, actionErr := HandleWorkflowError(err, stepInfo.step, reader)
+			action, actionErr := HandleWorkflowError(err, stepInfo.step.Label(), reader)
 			if actionErr != nil {
-				return actionErr
+				return false, actionErr
 			}
 
 			switch action {
 			case ActionRetry:
 				continue
 			case ActionSkip:
-				return nil
+				return false, nil
 			case ActionAbort:
-				return fmt.Errorf("workflow aborted by user")
+				return false, fmt.Errorf("workflow aborted by user")
 			}
 		}
 
 		if !completed {
-			return nil
+			return false, nil
 		}
 
-		status.MarkComplete(stepInfo.step)
+		status.markDone(stepInfo.step)
 		refreshTicketData(client, ticket)
-		break
+		return true, nil
 	}
-	return nil
 }
 
 func refreshTicketData(client jira.JiraClient, ticket *jira.Issue) {
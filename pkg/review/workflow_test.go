@@ -2,6 +2,8 @@ package review
 
 import (
 	"testing"
+
+	"github.com/beekhof/jira-tool/pkg/gemini"
 )
 
 func TestTicketStatus(t *testing.T) {
@@ -83,3 +85,24 @@ func TestWorkflowStepString(t *testing.T) {
 		}
 	}
 }
+
+func TestApplyClassification(t *testing.T) {
+	defaults := &StepDefaults{
+		Priority: Suggestion{Value: "Blocker", Source: `triage rule "crashes"`},
+	}
+	ApplyClassification(defaults, &gemini.Classification{
+		Priority:  gemini.Recommendation{Value: "Minor", Rationale: "Cosmetic"},
+		Component: gemini.Recommendation{Value: "Networking", Rationale: "DNS lookups fail"},
+	})
+
+	if defaults.Priority.Value != "Blocker" {
+		t.Errorf("Expected the triage rule priority to win, got %+v", defaults.Priority)
+	}
+	if defaults.Severity.Value != "" {
+		t.Errorf("Expected no severity suggestion, got %+v", defaults.Severity)
+	}
+	want := Suggestion{Value: "Networking", Source: "AI", Rationale: "DNS lookups fail"}
+	if defaults.Component != want {
+		t.Errorf("Expected %+v, got %+v", want, defaults.Component)
+	}
+}
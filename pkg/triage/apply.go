@@ -0,0 +1,251 @@
+package triage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+// Applier applies triage plans, resolving names (priorities, components, users,
+// sprints, transitions) to Jira IDs and caching lookups across tickets
+type Applier struct {
+	client     jira.JiraClient
+	cfg        *config.Config
+	priorities []jira.Priority
+	components map[string][]jira.Component // By project
+	users      map[string]jira.User        // By search query
+	sprints    map[string]int              // By project and sprint name
+}
+
+// NewApplier creates an Applier
+func NewApplier(client jira.JiraClient, cfg *config.Config) *Applier {
+	return &Applier{
+		client:     client,
+		cfg:        cfg,
+		components: map[string][]jira.Component{},
+		users:      map[string]jira.User{},
+		sprints:    map[string]int{},
+	}
+}
+
+// Apply makes the planned changes to a ticket
+// Every change is attempted; the errors of those that failed are returned
+func (a *Applier) Apply(plan *Plan) []error {
+	var errs []error
+	for i := range plan.Changes {
+		change := &plan.Changes[i]
+		if err := a.applyChange(plan, change); err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", plan.Key, change.Field, err))
+		}
+	}
+	return errs
+}
+
+func (a *Applier) applyChange(plan *Plan, change *Change) error {
+	switch change.Field {
+	case FieldPriority:
+		id, err := a.PriorityID(change.To)
+		if err != nil {
+			return err
+		}
+		return a.client.UpdateTicketPriority(plan.Key, id)
+	case FieldSeverity:
+		if a.cfg.SeverityFieldID == "" {
+			return fmt.Errorf("severity_field_id not configured")
+		}
+		return a.client.UpdateTicketSeverity(plan.Key, a.cfg.SeverityFieldID, change.To)
+	case FieldComponent:
+		id, err := a.ComponentID(plan.Project, change.To)
+		if err != nil {
+			return err
+		}
+		return a.client.UpdateTicketComponents(plan.Key, []string{id})
+	case FieldLabels:
+		return a.client.AddLabels(plan.Key, change.Values)
+	case FieldAssignee:
+		user, err := a.User(change.To)
+		if err != nil {
+			return err
+		}
+		return a.client.AssignTicket(plan.Key, user.AccountID, user.Name)
+	case FieldSprint:
+		id, err := a.SprintID(plan.Project, change.To)
+		if err != nil {
+			return err
+		}
+		return a.client.AddIssuesToSprint(id, []string{plan.Key})
+	case FieldTransition:
+		id, err := a.transitionID(plan.Key, change.To)
+		if err != nil {
+			return err
+		}
+		return a.client.TransitionTicket(plan.Key, id)
+	default:
+		return fmt.Errorf("unknown field")
+	}
+}
+
+// PriorityID resolves a priority name to its ID
+func (a *Applier) PriorityID(name string) (string, error) {
+	if a.priorities == nil {
+		priorities, err := a.client.GetPriorities()
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch priorities: %w", err)
+		}
+		a.priorities = priorities
+	}
+	for _, p := range a.priorities {
+		if strings.EqualFold(p.Name, name) {
+			return p.ID, nil
+		}
+	}
+	return "", fmt.Errorf("priority %q not found", name)
+}
+
+// ComponentID resolves a component name in a project to its ID
+func (a *Applier) ComponentID(project, name string) (string, error) {
+	components, ok := a.components[project]
+	if !ok {
+		var err error
+		components, err = a.client.GetComponents(project)
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch components: %w", err)
+		}
+		a.components[project] = components
+	}
+	for _, c := range components {
+		if strings.EqualFold(c.Name, name) {
+			return c.ID, nil
+		}
+	}
+	return "", fmt.Errorf("component %q not found in project %s", name, project)
+}
+
+// User finds the one user matching a search query (e.g. an email address or name)
+func (a *Applier) User(query string) (jira.User, error) {
+	if user, ok := a.users[query]; ok {
+		return user, nil
+	}
+	users, err := a.client.SearchUsers(query)
+	if err != nil {
+		return jira.User{}, fmt.Errorf("failed to search users: %w", err)
+	}
+	if len(users) == 0 {
+		return jira.User{}, fmt.Errorf("no user found for %q", query)
+	}
+	if len(users) > 1 {
+		// Prefer an exact match on email or name, otherwise the query is ambiguous
+		for _, u := range users {
+			if strings.EqualFold(u.EmailAddress, query) || strings.EqualFold(u.DisplayName, query) ||
+				strings.EqualFold(u.Name, query) {
+				a.users[query] = u
+				return u, nil
+			}
+		}
+		return jira.User{}, fmt.Errorf("%d users match %q, use a more specific query", len(users), query)
+	}
+	a.users[query] = users[0]
+	return users[0], nil
+}
+
+// SprintID resolves "active", "next", or a sprint name or ID on the project's board
+func (a *Applier) SprintID(project, name string) (int, error) {
+	cacheKey := project + "/" + strings.ToLower(name)
+	if id, ok := a.sprints[cacheKey]; ok {
+		return id, nil
+	}
+
+	boardID, err := a.boardID(project)
+	if err != nil {
+		return 0, err
+	}
+
+	active, err := a.client.GetActiveSprints(boardID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch sprints: %w", err)
+	}
+	planned, err := a.client.GetPlannedSprints(boardID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch sprints: %w", err)
+	}
+
+	var id int
+	switch strings.ToLower(name) {
+	case "active":
+		if len(active) == 0 {
+			return 0, fmt.Errorf("no active sprint on board %d", boardID)
+		}
+		id = active[0].ID
+	case "next":
+		if len(planned) == 0 {
+			return 0, fmt.Errorf("no planned sprint on board %d", boardID)
+		}
+		next := planned[0]
+		for _, s := range planned[1:] {
+			if !s.StartDate.IsZero() && (next.StartDate.IsZero() || s.StartDate.Before(next.StartDate)) {
+				next = s
+			}
+		}
+		id = next.ID
+	default:
+		for _, s := range append(active, planned...) {
+			if strings.EqualFold(s.Name, name) || strconv.Itoa(s.ID) == name {
+				id = s.ID
+				break
+			}
+		}
+		if id == 0 {
+			return 0, fmt.Errorf("sprint %q not found on board %d", name, boardID)
+		}
+	}
+
+	a.sprints[cacheKey] = id
+	return id, nil
+}
+
+// ReleaseID resolves the name or ID of an unreleased version of the project
+func (a *Applier) ReleaseID(project, name string) (string, error) {
+	releases, err := a.client.GetReleases(project)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch releases: %w", err)
+	}
+	for _, r := range releases {
+		if !r.Released && (strings.EqualFold(r.Name, name) || r.ID == name) {
+			return r.ID, nil
+		}
+	}
+	return "", fmt.Errorf("unreleased version %q not found in project %s", name, project)
+}
+
+// boardID returns the configured default board, or the project's only board
+func (a *Applier) boardID(project string) (int, error) {
+	if a.cfg.DefaultBoardID > 0 {
+		return a.cfg.DefaultBoardID, nil
+	}
+	boards, err := a.client.GetBoardsForProject(project)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch boards: %w", err)
+	}
+	if len(boards) != 1 {
+		return 0, fmt.Errorf("found %d boards for project %s. Please configure default_board_id in config",
+			len(boards), project)
+	}
+	return boards[0].ID, nil
+}
+
+// transitionID finds a transition by its name or the name of the status it leads to
+func (a *Applier) transitionID(ticketKey, name string) (string, error) {
+	transitions, err := a.client.GetTransitions(ticketKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch transitions: %w", err)
+	}
+	for _, t := range transitions {
+		if strings.EqualFold(t.Name, name) || strings.EqualFold(t.To.Name, name) {
+			return t.ID, nil
+		}
+	}
+	return "", fmt.Errorf("no transition to %q available", name)
+}
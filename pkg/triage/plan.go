@@ -0,0 +1,226 @@
+package triage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/jira"
+	"github.com/beekhof/jira-tool/pkg/review"
+)
+
+// Fields that triage actions change
+const (
+	FieldPriority   = "priority"
+	FieldSeverity   = "severity"
+	FieldComponent  = "component"
+	FieldLabels     = "labels"
+	FieldAssignee   = "assignee"
+	FieldSprint     = "sprint"
+	FieldTransition = "transition"
+)
+
+// Facts is what the rules can see about a ticket
+// Reporter, labels and severity aren't in search results, so they are read from the raw ticket
+type Facts struct {
+	Issue        *jira.Issue
+	Description  string
+	Reporter     []string // Display name, email, account ID and username, where available
+	Labels       []string
+	Severity     string
+	InOpenSprint bool
+}
+
+// Components returns the names of the ticket's components
+func (f *Facts) Components() []string {
+	names := make([]string, len(f.Issue.Fields.Components))
+	for i, c := range f.Issue.Fields.Components {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// Gather collects the facts about a ticket that the rule set needs
+func Gather(client jira.JiraClient, cfg *config.Config, ruleSet *RuleSet, issue *jira.Issue) *Facts {
+	facts := &Facts{Issue: issue}
+
+	description, err := client.GetTicketDescription(issue.Key)
+	if err == nil {
+		facts.Description = description
+	}
+
+	if raw, err := client.GetTicketRaw(issue.Key); err == nil {
+		if fields, ok := raw["fields"].(map[string]interface{}); ok {
+			facts.Reporter = reporterIdentities(fields["reporter"])
+			if labels, ok := fields["labels"].([]interface{}); ok {
+				for _, l := range labels {
+					if label, ok := l.(string); ok {
+						facts.Labels = append(facts.Labels, label)
+					}
+				}
+			}
+			if cfg.SeverityFieldID != "" {
+				facts.Severity = severityValue(fields[cfg.SeverityFieldID])
+			}
+		}
+	}
+
+	if ruleSet.usesSprint() {
+		issues, err := client.SearchTickets(fmt.Sprintf("key = %s AND sprint in openSprints()", issue.Key))
+		facts.InOpenSprint = err == nil && len(issues) > 0
+	}
+
+	return facts
+}
+
+// reporterIdentities returns the ways a reporter can be referred to in rules
+func reporterIdentities(reporter interface{}) []string {
+	fields, ok := reporter.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	var identities []string
+	for _, key := range []string{"displayName", "emailAddress", "accountId", "name", "key"} {
+		if v, ok := fields[key].(string); ok && v != "" {
+			identities = append(identities, v)
+		}
+	}
+	return identities
+}
+
+// severityValue extracts the value of a severity field, which may be an option object or a string
+func severityValue(value interface{}) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if val, ok := v["value"].(string); ok {
+			return val
+		}
+		if val, ok := v["name"].(string); ok {
+			return val
+		}
+	case string:
+		return v
+	}
+	return ""
+}
+
+// Change is a single planned change to a ticket
+type Change struct {
+	Field string
+	From  string // Current value, empty if unset
+	To    string
+	Rule  string // Name of the rule that planned the change
+	// Values holds the individual values for multi-valued fields (labels)
+	Values []string
+}
+
+// Plan is the set of changes planned for one ticket
+type Plan struct {
+	Key     string
+	Summary string
+	Project string
+	Changes []Change
+}
+
+// Change returns the planned change for a field, or nil
+func (p *Plan) Change(field string) *Change {
+	for i := range p.Changes {
+		if p.Changes[i].Field == field {
+			return &p.Changes[i]
+		}
+	}
+	return nil
+}
+
+// BuildPlan works out the changes the rules make to a ticket
+// Rules are applied in order; the first rule to set a field wins, except labels, which accumulate.
+// Fields that already have a value are only changed by rules with overwrite set.
+func BuildPlan(ruleSet *RuleSet, facts *Facts) *Plan {
+	issue := facts.Issue
+	plan := &Plan{Key: issue.Key, Summary: issue.Fields.Summary, Project: jira.ProjectKey(issue.Key)}
+
+	assignee := issue.Fields.Assignee.DisplayName
+	if assignee == "" {
+		assignee = issue.Fields.Assignee.Name
+	}
+	components := strings.Join(facts.Components(), ", ")
+
+	for i := range ruleSet.Rules {
+		rule := &ruleSet.Rules[i]
+		if !rule.When.Matches(facts) {
+			continue
+		}
+
+		a := rule.Then
+		plan.planValue(rule, FieldPriority, issue.Fields.Priority.Name, a.Priority)
+		plan.planValue(rule, FieldSeverity, facts.Severity, a.Severity)
+		if a.Component != "" && !containsFold(facts.Components(), a.Component) {
+			plan.planValue(rule, FieldComponent, components, a.Component)
+		}
+		plan.planValue(rule, FieldAssignee, assignee, a.Assignee)
+		if a.Sprint != "" && (!facts.InOpenSprint || rule.Overwrite) {
+			plan.planValue(rule, FieldSprint, "", a.Sprint)
+		}
+		if !strings.EqualFold(issue.Fields.Status.Name, a.Transition) {
+			plan.planValue(rule, FieldTransition, issue.Fields.Status.Name, a.Transition)
+		}
+		plan.planLabels(rule, facts.Labels, a.Labels)
+
+		if rule.Stop {
+			break
+		}
+	}
+
+	return plan
+}
+
+// planValue plans setting a single-valued field, unless it's already planned,
+// already has this value, or has a value and the rule doesn't overwrite
+func (p *Plan) planValue(rule *Rule, field, current, value string) {
+	if value == "" || p.Change(field) != nil || strings.EqualFold(current, value) {
+		return
+	}
+	// Transitions always move on from the current status; everything else only fills gaps
+	if current != "" && !rule.Overwrite && field != FieldTransition {
+		return
+	}
+	p.Changes = append(p.Changes, Change{Field: field, From: current, To: value, Rule: rule.Name})
+}
+
+// planLabels adds labels the ticket doesn't have yet to the planned labels change
+func (p *Plan) planLabels(rule *Rule, current, labels []string) {
+	for _, label := range labels {
+		if containsFold(current, label) {
+			continue
+		}
+		change := p.Change(FieldLabels)
+		if change == nil {
+			p.Changes = append(p.Changes, Change{Field: FieldLabels, From: strings.Join(current, ", "), Rule: rule.Name})
+			change = &p.Changes[len(p.Changes)-1]
+		}
+		if !containsFold(change.Values, label) {
+			change.Values = append(change.Values, label)
+			change.To = "+" + strings.Join(change.Values, ", +")
+		}
+	}
+}
+
+// Suggest returns the priority, severity and component the rules would set for
+// a ticket, to offer as defaults in the review workflow
+func Suggest(client jira.JiraClient, cfg *config.Config, ruleSet *RuleSet, issue *jira.Issue) *review.StepDefaults {
+	plan := BuildPlan(ruleSet, Gather(client, cfg, ruleSet, issue))
+
+	suggestion := func(field string) review.Suggestion {
+		change := plan.Change(field)
+		if change == nil {
+			return review.Suggestion{}
+		}
+		return review.Suggestion{Value: change.To, Source: fmt.Sprintf("triage rule %q", change.Rule)}
+	}
+
+	return &review.StepDefaults{
+		Priority:  suggestion(FieldPriority),
+		Severity:  suggestion(FieldSeverity),
+		Component: suggestion(FieldComponent),
+	}
+}
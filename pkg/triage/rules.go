@@ -0,0 +1,171 @@
+package triage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Conditions select the tickets a rule applies to
+// All set conditions must match; list conditions match if any entry matches
+type Conditions struct {
+	Summary     string   `yaml:"summary,omitempty"`     // Regular expression
+	Description string   `yaml:"description,omitempty"` // Regular expression
+	IssueTypes  []string `yaml:"issue_types,omitempty"`
+	Reporters   []string `yaml:"reporters,omitempty"` // Display name, email, account ID or username
+	Labels      []string `yaml:"labels,omitempty"`
+	Components  []string `yaml:"components,omitempty"`
+
+	summaryRe     *regexp.Regexp
+	descriptionRe *regexp.Regexp
+}
+
+// Actions are the changes a rule makes to matching tickets
+type Actions struct {
+	Priority   string   `yaml:"priority,omitempty"`   // Priority name
+	Severity   string   `yaml:"severity,omitempty"`   // Severity value
+	Component  string   `yaml:"component,omitempty"`  // Component name
+	Labels     []string `yaml:"labels,omitempty"`     // Labels to add
+	Assignee   string   `yaml:"assignee,omitempty"`   // User search query, e.g. an email address
+	Sprint     string   `yaml:"sprint,omitempty"`     // "active", "next" or a sprint name
+	Transition string   `yaml:"transition,omitempty"` // Transition or target status name
+}
+
+// Rule is a triage rule: when a ticket matches the conditions, the actions are planned
+type Rule struct {
+	Name string     `yaml:"name"`
+	When Conditions `yaml:"when"`
+	Then Actions    `yaml:"then"`
+	// Overwrite replaces values that are already set; by default only empty fields are filled
+	Overwrite bool `yaml:"overwrite,omitempty"`
+	// Stop skips the remaining rules once this rule matches
+	Stop bool `yaml:"stop,omitempty"`
+}
+
+// RuleSet is an ordered list of triage rules
+// When several rules set the same field, the first matching rule wins
+type RuleSet struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// GetRulesPath returns the default path for the triage rules file
+// If configDir is empty, uses the default ~/.jira-tool
+func GetRulesPath(configDir string) string {
+	if configDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "./.jira-tool/triage.yaml"
+		}
+		configDir = filepath.Join(homeDir, ".jira-tool")
+	}
+	return filepath.Join(configDir, "triage.yaml")
+}
+
+// LoadRules loads and validates a triage rules file
+func LoadRules(path string) (*RuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read triage rules: %w", err)
+	}
+
+	return ParseRules(data)
+}
+
+// ParseRules parses and validates triage rules from YAML
+func ParseRules(data []byte) (*RuleSet, error) {
+	ruleSet := &RuleSet{}
+	if err := yaml.Unmarshal(data, ruleSet); err != nil {
+		return nil, fmt.Errorf("failed to parse triage rules: %w", err)
+	}
+
+	for i := range ruleSet.Rules {
+		rule := &ruleSet.Rules[i]
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule %d", i+1)
+		}
+		if err := rule.compile(); err != nil {
+			return nil, fmt.Errorf("invalid triage rule %q: %w", rule.Name, err)
+		}
+	}
+
+	return ruleSet, nil
+}
+
+// compile compiles the rule's regular expressions and checks it has an action
+func (r *Rule) compile() error {
+	var err error
+	if r.When.Summary != "" {
+		if r.When.summaryRe, err = regexp.Compile(r.When.Summary); err != nil {
+			return fmt.Errorf("invalid summary pattern: %w", err)
+		}
+	}
+	if r.When.Description != "" {
+		if r.When.descriptionRe, err = regexp.Compile(r.When.Description); err != nil {
+			return fmt.Errorf("invalid description pattern: %w", err)
+		}
+	}
+
+	a := r.Then
+	if a.Priority == "" && a.Severity == "" && a.Component == "" && len(a.Labels) == 0 &&
+		a.Assignee == "" && a.Sprint == "" && a.Transition == "" {
+		return fmt.Errorf("no actions")
+	}
+	return nil
+}
+
+// usesSprint reports whether any rule adds tickets to a sprint
+func (rs *RuleSet) usesSprint() bool {
+	for i := range rs.Rules {
+		if rs.Rules[i].Then.Sprint != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold reports whether values contains s, ignoring case
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// anyFold reports whether any of wanted is in values, ignoring case
+func anyFold(values, wanted []string) bool {
+	for _, w := range wanted {
+		if containsFold(values, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches reports whether a ticket meets all of the conditions
+func (c *Conditions) Matches(facts *Facts) bool {
+	if c.summaryRe != nil && !c.summaryRe.MatchString(facts.Issue.Fields.Summary) {
+		return false
+	}
+	if c.descriptionRe != nil && !c.descriptionRe.MatchString(facts.Description) {
+		return false
+	}
+	if len(c.IssueTypes) > 0 && !containsFold(c.IssueTypes, facts.Issue.Fields.IssueType.Name) {
+		return false
+	}
+	if len(c.Reporters) > 0 && !anyFold(facts.Reporter, c.Reporters) {
+		return false
+	}
+	if len(c.Labels) > 0 && !anyFold(facts.Labels, c.Labels) {
+		return false
+	}
+	if len(c.Components) > 0 && !anyFold(facts.Components(), c.Components) {
+		return false
+	}
+	return true
+}
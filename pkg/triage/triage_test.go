@@ -0,0 +1,220 @@
+package triage
+
+import (
+	"testing"
+
+	"github.com/beekhof/jira-tool/pkg/config"
+	"github.com/beekhof/jira-tool/pkg/jira"
+)
+
+const testRules = `
+rules:
+  - name: crashes
+    when:
+      summary: "(?i)crash|panic"
+      issue_types: [Bug]
+    then:
+      priority: Critical
+      labels: [crash]
+  - name: support
+    when:
+      reporters: [support@example.com]
+    then:
+      priority: Low
+      component: Support
+      labels: [customer, crash]
+      assignee: triager@example.com
+      transition: To Do
+  - name: overwrite ui
+    when:
+      components: [UI]
+    then:
+      component: Frontend
+    overwrite: true
+    stop: true
+  - name: never reached for UI tickets
+    when:
+      summary: "."
+    then:
+      severity: High
+`
+
+func newIssue(key, summary, issueType string) *jira.Issue {
+	issue := &jira.Issue{Key: key}
+	issue.Fields.Summary = summary
+	issue.Fields.IssueType.Name = issueType
+	issue.Fields.Status.Name = "New"
+	return issue
+}
+
+func TestParseRulesInvalid(t *testing.T) {
+	for name, data := range map[string]string{
+		"bad regex":  "rules:\n  - when: {summary: \"(\"}\n    then: {priority: High}\n",
+		"no actions": "rules:\n  - name: empty\n    when: {summary: x}\n",
+	} {
+		if _, err := ParseRules([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestBuildPlan(t *testing.T) {
+	ruleSet, err := ParseRules([]byte(testRules))
+	if err != nil {
+		t.Fatalf("ParseRules failed: %v", err)
+	}
+
+	t.Run("first rule wins and labels accumulate", func(t *testing.T) {
+		facts := &Facts{
+			Issue:    newIssue("ENG-1", "App crash on login", "Bug"),
+			Reporter: []string{"Support Team", "support@example.com"},
+			Labels:   []string{"customer"},
+		}
+		plan := BuildPlan(ruleSet, facts)
+
+		if c := plan.Change(FieldPriority); c == nil || c.To != "Critical" || c.Rule != "crashes" {
+			t.Errorf("Expected priority Critical from crashes, got %+v", c)
+		}
+		if c := plan.Change(FieldLabels); c == nil || len(c.Values) != 1 || c.Values[0] != "crash" {
+			t.Errorf("Expected only the missing crash label, got %+v", c)
+		}
+		if c := plan.Change(FieldComponent); c == nil || c.To != "Support" {
+			t.Errorf("Expected component Support, got %+v", c)
+		}
+		if c := plan.Change(FieldTransition); c == nil || c.From != "New" || c.To != "To Do" {
+			t.Errorf("Expected transition New -> To Do, got %+v", c)
+		}
+		if c := plan.Change(FieldSeverity); c == nil || c.To != "High" {
+			t.Errorf("Expected severity High from the last rule, got %+v", c)
+		}
+	})
+
+	t.Run("existing values are kept unless overwrite", func(t *testing.T) {
+		issue := newIssue("ENG-2", "Button misaligned", "Bug")
+		issue.Fields.Priority.Name = "Medium"
+		issue.Fields.Components = append(issue.Fields.Components, struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		}{ID: "1", Name: "UI"})
+		facts := &Facts{Issue: issue, Reporter: []string{"support@example.com"}}
+
+		plan := BuildPlan(ruleSet, facts)
+		if c := plan.Change(FieldPriority); c != nil {
+			t.Errorf("Expected existing priority to be kept, got %+v", c)
+		}
+		if c := plan.Change(FieldComponent); c == nil || c.From != "UI" || c.To != "Frontend" {
+			t.Errorf("Expected component UI -> Frontend, got %+v", c)
+		}
+		if c := plan.Change(FieldSeverity); c != nil {
+			t.Errorf("Expected stop to skip later rules, got %+v", c)
+		}
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		plan := BuildPlan(ruleSet, &Facts{Issue: newIssue("ENG-3", "", "Story")})
+		if len(plan.Changes) != 0 {
+			t.Errorf("Expected no changes, got %+v", plan.Changes)
+		}
+	})
+}
+
+// applyClient records updates; lookups return fixed data
+type applyClient struct {
+	jira.JiraClient
+	priority    string
+	components  []string
+	labels      []string
+	assignee    string
+	transition  string
+	sprint      int
+	userResults []jira.User
+}
+
+func (c *applyClient) GetPriorities() ([]jira.Priority, error) {
+	return []jira.Priority{{ID: "1", Name: "Critical"}, {ID: "4", Name: "Low"}}, nil
+}
+
+func (c *applyClient) UpdateTicketPriority(_, priorityID string) error {
+	c.priority = priorityID
+	return nil
+}
+
+func (c *applyClient) GetComponents(_ string) ([]jira.Component, error) {
+	return []jira.Component{{ID: "10", Name: "Support"}}, nil
+}
+
+func (c *applyClient) UpdateTicketComponents(_ string, ids []string) error {
+	c.components = ids
+	return nil
+}
+
+func (c *applyClient) AddLabels(_ string, labels []string) error {
+	c.labels = labels
+	return nil
+}
+
+func (c *applyClient) SearchUsers(_ string) ([]jira.User, error) {
+	return c.userResults, nil
+}
+
+func (c *applyClient) AssignTicket(_, accountID, _ string) error {
+	c.assignee = accountID
+	return nil
+}
+
+func (c *applyClient) GetTransitions(_ string) ([]jira.Transition, error) {
+	t := jira.Transition{ID: "21", Name: "Accept"}
+	t.To.Name = "To Do"
+	return []jira.Transition{t}, nil
+}
+
+func (c *applyClient) TransitionTicket(_, transitionID string) error {
+	c.transition = transitionID
+	return nil
+}
+
+func (c *applyClient) GetActiveSprints(_ int) ([]jira.SprintParsed, error) {
+	return []jira.SprintParsed{{ID: 7, Name: "Sprint 7"}}, nil
+}
+
+func (c *applyClient) GetPlannedSprints(_ int) ([]jira.SprintParsed, error) {
+	return []jira.SprintParsed{{ID: 8, Name: "Sprint 8"}}, nil
+}
+
+func (c *applyClient) AddIssuesToSprint(sprintID int, _ []string) error {
+	c.sprint = sprintID
+	return nil
+}
+
+func TestApply(t *testing.T) {
+	client := &applyClient{userResults: []jira.User{
+		{AccountID: "a1", EmailAddress: "other@example.com"},
+		{AccountID: "a2", EmailAddress: "triager@example.com"},
+	}}
+	plan := &Plan{Key: "ENG-1", Project: "ENG", Changes: []Change{
+		{Field: FieldPriority, To: "critical"},
+		{Field: FieldComponent, To: "Support"},
+		{Field: FieldLabels, Values: []string{"crash"}},
+		{Field: FieldAssignee, To: "triager@example.com"},
+		{Field: FieldTransition, To: "To Do"},
+		{Field: FieldSprint, To: "next"},
+		{Field: FieldSeverity, To: "High"},
+	}}
+
+	errs := NewApplier(client, &config.Config{DefaultBoardID: 3}).Apply(plan)
+
+	// Severity fails: no severity field configured; everything else is applied
+	if len(errs) != 1 {
+		t.Fatalf("Expected 1 error, got %v", errs)
+	}
+	if client.priority != "1" || len(client.components) != 1 || client.components[0] != "10" {
+		t.Errorf("Expected priority 1 and component 10, got %q and %v", client.priority, client.components)
+	}
+	if client.assignee != "a2" || client.transition != "21" || client.sprint != 8 {
+		t.Errorf("Expected assignee a2, transition 21, sprint 8, got %q, %q, %d",
+			client.assignee, client.transition, client.sprint)
+	}
+	if len(client.labels) != 1 || client.labels[0] != "crash" {
+		t.Errorf("Expected crash label, got %v", client.labels)
+	}
+}
@@ -0,0 +1,66 @@
+package tui
+
+// Special keys that don't stand for a character
+const (
+	keyNone = iota
+	KeyUp
+	KeyDown
+	KeyPageUp
+	KeyPageDown
+	KeyHome
+	KeyEnd
+	KeyEnter
+	KeyEscape
+	KeyInterrupt
+	KeyBackspace
+	KeyTab
+)
+
+// Key is one key press: either a character (Rune) or a special key (Special)
+type Key struct {
+	Rune    rune
+	Special int
+}
+
+// escapeSequences maps the input sequences terminals send for special keys
+var escapeSequences = map[string]int{
+	"\x1b[A":  KeyUp,
+	"\x1bOA":  KeyUp,
+	"\x1b[B":  KeyDown,
+	"\x1bOB":  KeyDown,
+	"\x1b[5~": KeyPageUp,
+	"\x1b[6~": KeyPageDown,
+	"\x1b[H":  KeyHome,
+	"\x1bOH":  KeyHome,
+	"\x1b[1~": KeyHome,
+	"\x1b[F":  KeyEnd,
+	"\x1bOF":  KeyEnd,
+	"\x1b[4~": KeyEnd,
+}
+
+// ParseKey decodes the bytes of one key press read from a terminal in raw mode
+// Unknown escape sequences (e.g. function keys) give the zero Key
+func ParseKey(input []byte) Key {
+	if len(input) == 0 {
+		return Key{}
+	}
+
+	switch input[0] {
+	case '\r', '\n':
+		return Key{Special: KeyEnter}
+	case 0x03:
+		return Key{Special: KeyInterrupt}
+	case 0x7f, 0x08:
+		return Key{Special: KeyBackspace}
+	case '\t':
+		return Key{Special: KeyTab}
+	case 0x1b:
+		if len(input) == 1 {
+			return Key{Special: KeyEscape}
+		}
+		return Key{Special: escapeSequences[string(input)]}
+	}
+
+	runes := []rune(string(input))
+	return Key{Rune: runes[0]}
+}
@@ -0,0 +1,30 @@
+package tui
+
+import "testing"
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Key
+	}{
+		{"j", Key{Rune: 'j'}},
+		{" ", Key{Rune: ' '}},
+		{"é", Key{Rune: 'é'}},
+		{"\r", Key{Special: KeyEnter}},
+		{"\x03", Key{Special: KeyInterrupt}},
+		{"\x7f", Key{Special: KeyBackspace}},
+		{"\t", Key{Special: KeyTab}},
+		{"\x1b", Key{Special: KeyEscape}},
+		{"\x1b[A", Key{Special: KeyUp}},
+		{"\x1bOB", Key{Special: KeyDown}},
+		{"\x1b[6~", Key{Special: KeyPageDown}},
+		{"\x1b[15~", Key{}},
+		{"", Key{}},
+	}
+
+	for _, tt := range tests {
+		if got := ParseKey([]byte(tt.input)); got != tt.want {
+			t.Errorf("ParseKey(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
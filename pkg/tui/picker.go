@@ -0,0 +1,391 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/beekhof/jira-tool/pkg/prompt"
+
+	"golang.org/x/term"
+)
+
+// ErrCancelled is returned by Pick when the user leaves the picker without choosing
+var ErrCancelled = errors.New("selection cancelled")
+
+// PickItem is one choice in a picker
+type PickItem struct {
+	Label string
+	// Detail is shown after the label, e.g. a ticket's type and summary, and is matched too
+	Detail string
+	// Value identifies the item to the caller, e.g. an ID; the label is used if it is empty
+	Value string
+	// Recent items are pinned at the top of the list
+	Recent bool
+}
+
+func (item *PickItem) id() string {
+	if item.Value != "" {
+		return item.Value
+	}
+	return item.Label
+}
+
+// Picker is a type-to-filter list to choose one item, or several if Multi is set
+type Picker struct {
+	Title string
+	Items []PickItem
+	Multi bool
+	// Search finds more items for what has been typed, e.g. users in Jira (optional)
+	// Its items are listed after the matching Items
+	Search func(query string) ([]PickItem, error)
+
+	query     []rune
+	found     []PickItem
+	matches   []*PickItem
+	chosen    map[string]bool
+	cursor    int
+	offset    int
+	message   string
+	cancelled bool
+	searched  bool
+}
+
+// NewPicker creates a picker over items; multi allows several to be chosen
+func NewPicker(title string, items []PickItem, multi bool) *Picker {
+	p := &Picker{Title: title, Items: items, Multi: multi, chosen: map[string]bool{}}
+	p.filter()
+	return p
+}
+
+// PinRecent marks the items named in recent (by label or value) as recent and moves them
+// to the front, most recent first. Like the lists in config.State, recent is oldest first
+func PinRecent(items []PickItem, recent []string) []PickItem {
+	pinned := make([]PickItem, 0, len(items))
+	used := make([]bool, len(items))
+	for r := len(recent) - 1; r >= 0; r-- {
+		name := recent[r]
+		for i := range items {
+			if !used[i] && (items[i].Label == name || items[i].Value == name) {
+				used[i] = true
+				item := items[i]
+				item.Recent = true
+				pinned = append(pinned, item)
+				break
+			}
+		}
+	}
+	for i := range items {
+		if !used[i] {
+			pinned = append(pinned, items[i])
+		}
+	}
+	return pinned
+}
+
+// Pickable checks if the named prompt can be asked with a picker:
+// it has no pre-set answer and stdin and stdout are terminals
+func Pickable(name string) bool {
+	if _, ok := prompt.Answer(name); ok || !prompt.Interactive() {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// Query returns what has been typed to filter the list
+func (p *Picker) Query() string {
+	return string(p.query)
+}
+
+// Current returns the item under the cursor, or nil if nothing matches
+func (p *Picker) Current() *PickItem {
+	if len(p.matches) == 0 {
+		return nil
+	}
+	return p.matches[p.cursor]
+}
+
+// Chosen returns the items chosen: those marked with Tab, in list order,
+// or the one under the cursor if none are marked
+func (p *Picker) Chosen() []PickItem {
+	chosen := []PickItem{}
+	for _, item := range p.all() {
+		if p.chosen[item.id()] {
+			chosen = append(chosen, *item)
+		}
+	}
+	if len(chosen) == 0 && p.Current() != nil {
+		chosen = append(chosen, *p.Current())
+	}
+	return chosen
+}
+
+// HandleKey applies a key press to the picker. It returns done once the user has chosen
+// (Enter) or cancelled (Escape)
+func (p *Picker) HandleKey(key Key, pageSize int) (done bool) {
+	if pageSize < 1 {
+		pageSize = 1
+	}
+	p.message = ""
+
+	switch key.Special {
+	case KeyUp:
+		p.move(-1)
+	case KeyDown:
+		p.move(1)
+	case KeyPageUp:
+		p.move(-pageSize)
+	case KeyPageDown:
+		p.move(pageSize)
+	case KeyHome:
+		p.move(-len(p.matches))
+	case KeyEnd:
+		p.move(len(p.matches))
+	case KeyTab:
+		if !p.Multi {
+			p.message = "Only one can be chosen"
+		} else if item := p.Current(); item != nil {
+			p.chosen[item.id()] = !p.chosen[item.id()]
+			p.move(1)
+		}
+	case KeyBackspace:
+		if len(p.query) > 0 {
+			p.query = p.query[:len(p.query)-1]
+			p.queryChanged()
+		}
+	case KeyEscape, KeyInterrupt:
+		p.cancelled = true
+		return true
+	case KeyEnter:
+		return true
+	case keyNone:
+		if unicode.IsPrint(key.Rune) {
+			p.query = append(p.query, key.Rune)
+			p.queryChanged()
+		}
+	}
+	return false
+}
+
+func (p *Picker) queryChanged() {
+	p.searched = false
+	p.filter()
+}
+
+// search loads the Search items for the current query, once per query
+func (p *Picker) search() {
+	if p.Search == nil || p.searched {
+		return
+	}
+	p.searched = true
+	p.found = nil
+	if len(p.query) == 0 {
+		p.filter()
+		return
+	}
+
+	found, err := p.Search(p.Query())
+	if err != nil {
+		p.message = fmt.Sprintf("Search failed: %v", err)
+	}
+	p.found = found
+	p.filter()
+}
+
+// all returns the items and the search results that aren't among them
+func (p *Picker) all() []*PickItem {
+	items := make([]*PickItem, 0, len(p.Items)+len(p.found))
+	seen := map[string]bool{}
+	for _, list := range [][]PickItem{p.Items, p.found} {
+		for i := range list {
+			if !seen[list[i].id()] {
+				seen[list[i].id()] = true
+				items = append(items, &list[i])
+			}
+		}
+	}
+	return items
+}
+
+// filter lists the items matching the query: recent ones first, then best matches first
+func (p *Picker) filter() {
+	type match struct {
+		item  *PickItem
+		score int
+	}
+	matches := []match{}
+	for _, item := range p.all() {
+		score, ok := fuzzyScore(p.Query(), item.Label+" "+item.Detail)
+		if ok {
+			matches = append(matches, match{item, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].item.Recent != matches[j].item.Recent {
+			return matches[i].item.Recent
+		}
+		return matches[i].score > matches[j].score
+	})
+
+	p.matches = p.matches[:0]
+	for _, m := range matches {
+		p.matches = append(p.matches, m.item)
+	}
+	p.cursor = 0
+	p.offset = 0
+}
+
+func (p *Picker) move(delta int) {
+	if len(p.matches) == 0 {
+		return
+	}
+	p.cursor += delta
+	if p.cursor < 0 {
+		p.cursor = 0
+	}
+	if p.cursor >= len(p.matches) {
+		p.cursor = len(p.matches) - 1
+	}
+}
+
+// fuzzyScore checks if the letters of query appear in order in text, ignoring case
+// Matches at the start of words and runs of adjacent letters score higher
+func fuzzyScore(query, text string) (int, bool) {
+	if query == "" {
+		return 0, true
+	}
+
+	q := []rune(strings.ToLower(query))
+	t := []rune(strings.ToLower(text))
+	score, qi, previous := 0, 0, -2
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			continue
+		}
+		score++
+		if ti == previous+1 {
+			score += 4
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 6
+		}
+		previous = ti
+		qi++
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+	return score, true
+}
+
+// Render draws the picker as exactly height lines of at most width columns:
+// a title, the query, the matching items, a message and the keys
+func (p *Picker) Render(width, height int) []string {
+	if width < 20 {
+		width = 20
+	}
+	if height < 6 {
+		height = 6
+	}
+	listHeight := height - 4
+
+	title := fmt.Sprintf(" %s: %d of %d", p.Title, len(p.matches), len(p.all()))
+	if count := len(p.chosenIDs()); count > 0 {
+		title += fmt.Sprintf(", %d chosen", count)
+	}
+	lines := []string{inverse(pad(title, width)), pad(" > "+p.Query(), width)}
+
+	// Keep the cursor on screen
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if p.cursor >= p.offset+listHeight {
+		p.offset = p.cursor - listHeight + 1
+	}
+	for i := p.offset; i < p.offset+listHeight; i++ {
+		if i >= len(p.matches) {
+			lines = append(lines, pad("", width))
+			continue
+		}
+		item := p.matches[i]
+		marker := " "
+		if p.chosen[item.id()] {
+			marker = markerSelected
+		}
+		text := item.Label
+		if item.Detail != "" {
+			text += "  " + item.Detail
+		}
+		if item.Recent {
+			text += "  (recent)"
+		}
+		line := pad(fmt.Sprintf(" %s %s", marker, text), width)
+		if i == p.cursor {
+			line = inverse(line)
+		}
+		lines = append(lines, line)
+	}
+
+	message := p.message
+	if message == "" && len(p.matches) == 0 {
+		message = "No matches"
+	}
+	lines = append(lines, pad(" "+message, width), pad(" "+p.help(), width))
+	return lines
+}
+
+func (p *Picker) chosenIDs() []string {
+	ids := []string{}
+	for id, chosen := range p.chosen {
+		if chosen {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (p *Picker) help() string {
+	parts := []string{"type to filter", "↑↓ move"}
+	if p.Multi {
+		parts = append(parts, "tab choose")
+	}
+	return strings.Join(append(parts, "enter accept", "esc cancel"), " · ")
+}
+
+// Pick shows the picker full screen and returns the chosen items
+// The list is empty if nothing matched what was typed (see Query); leaving with Escape
+// gives ErrCancelled
+func Pick(p *Picker) ([]PickItem, error) {
+	in := int(os.Stdin.Fd())
+	if !term.IsTerminal(in) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return nil, errors.New("the picker needs a terminal")
+	}
+
+	s := &screen{in: in, out: os.Stdout}
+	if err := s.enter(); err != nil {
+		return nil, err
+	}
+	defer s.exit()
+
+	for {
+		width, height := s.size()
+		s.draw(p.Render(width, height))
+
+		key, err := s.readKey()
+		if err != nil {
+			return nil, err
+		}
+		if p.HandleKey(key, height-4) {
+			break
+		}
+		p.search()
+	}
+
+	if p.cancelled {
+		return nil, ErrCancelled
+	}
+	return p.Chosen(), nil
+}
@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func typeQuery(p *Picker, query string) {
+	for _, r := range query {
+		p.HandleKey(Key{Rune: r}, 10)
+		p.search()
+	}
+}
+
+func TestPickerFilter(t *testing.T) {
+	p := NewPicker("Component", []PickItem{
+		{Label: "Backend"},
+		{Label: "Frontend"},
+		{Label: "Docs", Recent: true},
+		{Label: "Build tooling"},
+	}, false)
+
+	if got := p.Current().Label; got != "Docs" {
+		t.Errorf("Expected the recent item pinned first, got %s", got)
+	}
+
+	typeQuery(p, "t")
+	if got := p.Current().Label; got != "Build tooling" {
+		t.Errorf("Expected the word-start match first, got %s", got)
+	}
+	if len(p.matches) != 2 {
+		t.Errorf("Expected Build tooling and Frontend to match, got %d matches", len(p.matches))
+	}
+
+	typeQuery(p, "zz")
+	if p.Current() != nil || len(p.Chosen()) != 0 || p.Query() != "tzz" {
+		t.Errorf("Expected no matches for %q, got %v", p.Query(), p.Chosen())
+	}
+	p.HandleKey(Key{Special: KeyBackspace}, 10)
+	p.HandleKey(Key{Special: KeyBackspace}, 10)
+	if len(p.matches) != 2 {
+		t.Errorf("Expected backspace to widen the matches again, got %d", len(p.matches))
+	}
+
+	p.HandleKey(Key{Special: KeyTab}, 10)
+	if len(p.chosenIDs()) != 0 {
+		t.Error("Expected Tab to do nothing in a single-choice picker")
+	}
+	if !p.HandleKey(Key{Special: KeyEnter}, 10) || p.cancelled {
+		t.Error("Expected Enter to accept")
+	}
+}
+
+func TestPickerMulti(t *testing.T) {
+	p := NewPicker("Component", []PickItem{
+		{Label: "Backend", Value: "1"},
+		{Label: "Frontend", Value: "2"},
+		{Label: "Docs", Value: "3"},
+	}, true)
+
+	if chosen := p.Chosen(); len(chosen) != 1 || chosen[0].Value != "1" {
+		t.Errorf("Expected the current item when none are marked, got %v", chosen)
+	}
+
+	p.HandleKey(Key{Special: KeyTab}, 10)
+	p.HandleKey(Key{Special: KeyDown}, 10)
+	p.HandleKey(Key{Special: KeyTab}, 10)
+	// Filtering keeps the marks on items that are no longer listed
+	typeQuery(p, "back")
+	chosen := p.Chosen()
+	if len(chosen) != 2 || chosen[0].Value != "1" || chosen[1].Value != "3" {
+		t.Errorf("Expected Backend and Docs chosen, got %v", chosen)
+	}
+
+	if !p.HandleKey(Key{Special: KeyEscape}, 10) || !p.cancelled {
+		t.Error("Expected Escape to cancel")
+	}
+}
+
+func TestPickerSearch(t *testing.T) {
+	queries := []string{}
+	p := NewPicker("User", []PickItem{{Label: "jdoe", Recent: true}}, false)
+	p.Search = func(query string) ([]PickItem, error) {
+		queries = append(queries, query)
+		if query == "jx" {
+			return nil, errors.New("offline")
+		}
+		return []PickItem{{Label: "jdoe"}, {Label: "jsmith", Detail: "Jane Smith"}}, nil
+	}
+
+	typeQuery(p, "j")
+	if len(p.matches) != 2 || p.matches[0].Label != "jdoe" || !p.matches[0].Recent {
+		t.Errorf("Expected the recent user once, then the other search result, got %d matches", len(p.matches))
+	}
+
+	p.HandleKey(Key{Special: KeyDown}, 10)
+	p.search()
+	if len(queries) != 1 {
+		t.Errorf("Expected one search per query, got %v", queries)
+	}
+
+	typeQuery(p, "x")
+	lines := p.Render(60, 10)
+	if len(lines) != 10 || !strings.Contains(lines[8], "Search failed: offline") {
+		t.Errorf("Expected the search error in the message line, got %q", lines)
+	}
+}
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		query, text string
+		match       bool
+	}{
+		{"", "anything", true},
+		{"sp1", "Sprint 1", true},
+		{"SPR", "sprint", true},
+		{"ps", "sprint", false},
+	}
+	for _, tt := range tests {
+		if _, ok := fuzzyScore(tt.query, tt.text); ok != tt.match {
+			t.Errorf("fuzzyScore(%q, %q) matched = %v, want %v", tt.query, tt.text, ok, tt.match)
+		}
+	}
+
+	wordStart, _ := fuzzyScore("t", "Build tooling")
+	inWord, _ := fuzzyScore("t", "Frontend")
+	if wordStart <= inWord {
+		t.Errorf("Expected a match at the start of a word to score higher, got %d vs %d", wordStart, inWord)
+	}
+}
+
+func TestPinRecent(t *testing.T) {
+	items := PinRecent([]PickItem{
+		{Label: "Sprint 1", Value: "1"},
+		{Label: "Sprint 2", Value: "2"},
+		{Label: "Sprint 3", Value: "3"},
+	}, []string{"Sprint 9", "2", "Sprint 3"})
+
+	got := []string{}
+	for _, item := range items {
+		got = append(got, fmt.Sprintf("%s:%v", item.Value, item.Recent))
+	}
+	if strings.Join(got, " ") != "3:true 2:true 1:false" {
+		t.Errorf("Expected recent sprints first in recent order, got %v", got)
+	}
+}
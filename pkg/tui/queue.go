@@ -0,0 +1,437 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Status markers shown before each ticket
+const (
+	markerSelected = "✓"
+	markerDone     = "•"
+	markerFailed   = "✗"
+)
+
+// minDetailWidth is the narrowest terminal that still gets a detail pane next to the list
+const minDetailWidth = 80
+
+// Row is one ticket in a queue
+type Row struct {
+	Key string
+	// Columns are shown between the key and the summary, e.g. type, priority and status
+	Columns []string
+	Summary string
+}
+
+// Action is an inline action on tickets, bound to a key
+type Action struct {
+	Key   rune
+	Label string
+	// Run runs the action on one ticket, with the terminal back in normal mode
+	// so the action can print and prompt as usual
+	Run func(key string) error
+}
+
+// Queue is a list of tickets to work through in the full-screen UI
+type Queue struct {
+	Title   string
+	Rows    []Row
+	Actions []Action
+	// Selected and Done are the selected tickets and those an action has completed
+	// They may be shared with the caller, e.g. to save a review session
+	Selected map[string]bool
+	Done     map[string]bool
+	// Details returns the text of the detail pane for a ticket (optional)
+	Details func(key string) (string, error)
+	// Refresh reloads a ticket's row after an action changed it (optional)
+	Refresh func(key string) (Row, error)
+	// OnChange is called after the selection or a ticket's status changes (optional)
+	OnChange func()
+
+	failed       map[string]string
+	details      map[string]string
+	cursor       int
+	offset       int
+	detailOffset int
+	message      string
+}
+
+// NewQueue creates a queue of rows with the given inline actions
+func NewQueue(title string, rows []Row, actions []Action) *Queue {
+	return &Queue{
+		Title:    title,
+		Rows:     rows,
+		Actions:  actions,
+		Selected: map[string]bool{},
+		Done:     map[string]bool{},
+		failed:   map[string]string{},
+		details:  map[string]string{},
+	}
+}
+
+// Current returns the key of the ticket under the cursor, or "" if the queue is empty
+func (q *Queue) Current() string {
+	if len(q.Rows) == 0 {
+		return ""
+	}
+	return q.Rows[q.cursor].Key
+}
+
+// HandleKey applies a key press to the queue. It returns quit if the user is done,
+// or the action to run if the key is bound to one.
+func (q *Queue) HandleKey(key Key, pageSize int) (quit bool, action *Action) {
+	if pageSize < 1 {
+		pageSize = 1
+	}
+	q.message = ""
+
+	switch key.Special {
+	case KeyUp:
+		q.move(-1)
+	case KeyDown:
+		q.move(1)
+	case KeyPageUp:
+		q.move(-pageSize)
+	case KeyPageDown:
+		q.move(pageSize)
+	case KeyHome:
+		q.move(-len(q.Rows))
+	case KeyEnd:
+		q.move(len(q.Rows))
+	case KeyEscape, KeyInterrupt:
+		return true, nil
+	case KeyEnter:
+		if len(q.Actions) > 0 {
+			return false, &q.Actions[0]
+		}
+	}
+	if key.Special != keyNone {
+		return false, nil
+	}
+
+	return q.handleRune(key.Rune, pageSize)
+}
+
+func (q *Queue) handleRune(r rune, pageSize int) (quit bool, action *Action) {
+	switch r {
+	case 'q':
+		return true, nil
+	case 'k':
+		q.move(-1)
+	case 'j':
+		q.move(1)
+	case 'g':
+		q.move(-len(q.Rows))
+	case 'G':
+		q.move(len(q.Rows))
+	case 'K':
+		q.scrollDetail(-pageSize / 2)
+	case 'J':
+		q.scrollDetail(pageSize / 2)
+	case ' ':
+		if key := q.Current(); key != "" {
+			q.Selected[key] = !q.Selected[key]
+			q.move(1)
+			q.changed()
+		}
+	case 'm', 'u':
+		for i := range q.Rows {
+			q.Selected[q.Rows[i].Key] = r == 'm'
+		}
+		q.changed()
+	default:
+		for i := range q.Actions {
+			if q.Actions[i].Key == r {
+				return false, &q.Actions[i]
+			}
+		}
+		q.message = fmt.Sprintf("Unknown key %q", r)
+	}
+	return false, nil
+}
+
+func (q *Queue) move(delta int) {
+	if len(q.Rows) == 0 {
+		return
+	}
+	previous := q.cursor
+	q.cursor += delta
+	if q.cursor < 0 {
+		q.cursor = 0
+	}
+	if q.cursor >= len(q.Rows) {
+		q.cursor = len(q.Rows) - 1
+	}
+	if q.cursor != previous {
+		q.detailOffset = 0
+	}
+}
+
+func (q *Queue) scrollDetail(delta int) {
+	q.detailOffset += delta
+	if q.detailOffset < 0 {
+		q.detailOffset = 0
+	}
+}
+
+func (q *Queue) changed() {
+	if q.OnChange != nil {
+		q.OnChange()
+	}
+}
+
+// Targets returns the tickets an action applies to: the selected ones in queue order,
+// or the one under the cursor if none are selected
+func (q *Queue) Targets() []string {
+	keys := []string{}
+	for i := range q.Rows {
+		if q.Selected[q.Rows[i].Key] {
+			keys = append(keys, q.Rows[i].Key)
+		}
+	}
+	if len(keys) == 0 && q.Current() != "" {
+		keys = append(keys, q.Current())
+	}
+	return keys
+}
+
+// RunAction runs an action on each target ticket and records the outcome in its marker
+func (q *Queue) RunAction(action *Action) {
+	targets := q.Targets()
+	failed := 0
+	for i, key := range targets {
+		fmt.Printf("\n=== [%d/%d] %s %s ===\n", i+1, len(targets), action.Label, key)
+		if err := action.Run(key); err != nil {
+			fmt.Printf("%s %s: %v\n", markerFailed, key, err)
+			q.failed[key] = err.Error()
+			failed++
+		} else {
+			delete(q.failed, key)
+			q.Done[key] = true
+			q.Selected[key] = false
+		}
+
+		// The ticket may have changed, so its details and row are reloaded
+		delete(q.details, key)
+		if q.Refresh != nil {
+			if row, err := q.Refresh(key); err == nil {
+				q.replaceRow(row)
+			}
+		}
+		q.changed()
+	}
+
+	q.message = fmt.Sprintf("%s: %d done, %d failed", action.Label, len(targets)-failed, failed)
+}
+
+func (q *Queue) replaceRow(row Row) {
+	for i := range q.Rows {
+		if q.Rows[i].Key == row.Key {
+			q.Rows[i] = row
+			return
+		}
+	}
+}
+
+func (q *Queue) marker(key string) string {
+	switch {
+	case q.failed[key] != "":
+		return markerFailed
+	case q.Selected[key]:
+		return markerSelected
+	case q.Done[key]:
+		return markerDone
+	default:
+		return " "
+	}
+}
+
+// detail returns the detail pane text for a ticket, loading it once
+func (q *Queue) detail(key string) string {
+	if key == "" || q.Details == nil {
+		return ""
+	}
+	if text, ok := q.details[key]; ok {
+		return text
+	}
+	text, err := q.Details(key)
+	if err != nil {
+		// Not cached, so moving back to the ticket tries again
+		return fmt.Sprintf("Could not load %s: %v", key, err)
+	}
+	q.details[key] = text
+	return text
+}
+
+// Render draws the queue as exactly height lines of at most width columns:
+// a title, the list (with the detail pane beside it on wide terminals), a message and the keys
+func (q *Queue) Render(width, height int) []string {
+	if width < 20 {
+		width = 20
+	}
+	if height < 5 {
+		height = 5
+	}
+	bodyHeight := height - 3
+
+	selected, done := 0, 0
+	for i := range q.Rows {
+		if q.Selected[q.Rows[i].Key] {
+			selected++
+		}
+		if q.Done[q.Rows[i].Key] {
+			done++
+		}
+	}
+	title := fmt.Sprintf(" %s: %d ticket(s), %d selected, %d done", q.Title, len(q.Rows), selected, done)
+	lines := []string{inverse(pad(title, width))}
+
+	listWidth, detailWidth := width, 0
+	if width >= minDetailWidth {
+		listWidth = width * 11 / 20
+		detailWidth = width - listWidth - 1
+	}
+
+	list := q.renderList(listWidth, bodyHeight)
+	var detail []string
+	if detailWidth > 0 {
+		detail = q.renderDetail(detailWidth, bodyHeight)
+	}
+	for i := 0; i < bodyHeight; i++ {
+		line := list[i]
+		if detailWidth > 0 {
+			line += "│" + detail[i]
+		}
+		lines = append(lines, line)
+	}
+
+	message := q.message
+	if key := q.Current(); message == "" && q.failed[key] != "" {
+		message = fmt.Sprintf("%s failed: %s", key, q.failed[key])
+	}
+	lines = append(lines, pad(" "+message, width), pad(" "+q.help(), width))
+	return lines
+}
+
+func (q *Queue) renderList(width, height int) []string {
+	// Keep the cursor on screen
+	if q.cursor < q.offset {
+		q.offset = q.cursor
+	}
+	if q.cursor >= q.offset+height {
+		q.offset = q.cursor - height + 1
+	}
+
+	columnWidths := []int{}
+	for i := range q.Rows {
+		for c, column := range q.Rows[i].Columns {
+			if c >= len(columnWidths) {
+				columnWidths = append(columnWidths, 0)
+			}
+			if n := len([]rune(column)); n > columnWidths[c] {
+				columnWidths[c] = minInt(n, 14)
+			}
+		}
+	}
+
+	lines := make([]string, 0, height)
+	for i := q.offset; i < q.offset+height; i++ {
+		if i >= len(q.Rows) {
+			lines = append(lines, pad("", width))
+			continue
+		}
+		row := &q.Rows[i]
+		parts := []string{q.marker(row.Key), pad(row.Key, 10)}
+		for c, column := range row.Columns {
+			parts = append(parts, pad(column, columnWidths[c]))
+		}
+		parts = append(parts, row.Summary)
+		line := pad(" "+strings.Join(parts, " "), width)
+		if i == q.cursor {
+			line = inverse(line)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func (q *Queue) renderDetail(width, height int) []string {
+	text := wrap(q.detail(q.Current()), width-2)
+	if q.detailOffset > len(text)-1 {
+		q.detailOffset = maxInt(len(text)-1, 0)
+	}
+	text = text[q.detailOffset:]
+
+	lines := make([]string, 0, height)
+	for i := 0; i < height; i++ {
+		line := ""
+		if i < len(text) {
+			line = text[i]
+		}
+		lines = append(lines, pad(" "+line, width))
+	}
+	return lines
+}
+
+func (q *Queue) help() string {
+	parts := []string{"↑↓ move", "space select", "m/u mark/unmark all"}
+	for i := range q.Actions {
+		parts = append(parts, fmt.Sprintf("%c %s", q.Actions[i].Key, q.Actions[i].Label))
+	}
+	parts = append(parts, "J/K scroll details", "q quit")
+	return strings.Join(parts, " · ")
+}
+
+// pad truncates or pads s with spaces to exactly width runes
+func pad(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) > width {
+		if width <= 1 {
+			return string(runes[:width])
+		}
+		return string(runes[:width-1]) + "…"
+	}
+	return s + strings.Repeat(" ", width-len(runes))
+}
+
+// wrap breaks text into lines of at most width runes, at spaces where possible
+func wrap(text string, width int) []string {
+	if width < 1 {
+		width = 1
+	}
+	lines := []string{}
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r", ""), "\n") {
+		runes := []rune(strings.ReplaceAll(paragraph, "\t", "    "))
+		for len(runes) > width {
+			cut := width
+			for i := width; i > width/2; i-- {
+				if runes[i] == ' ' {
+					cut = i
+					break
+				}
+			}
+			lines = append(lines, string(runes[:cut]))
+			runes = []rune(strings.TrimLeft(string(runes[cut:]), " "))
+		}
+		lines = append(lines, string(runes))
+	}
+	return lines
+}
+
+func inverse(s string) string {
+	return "\x1b[7m" + s + "\x1b[0m"
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func testQueue() *Queue {
+	rows := []Row{
+		{Key: "ENG-1", Columns: []string{"Bug", "High"}, Summary: "Crash on start"},
+		{Key: "ENG-2", Columns: []string{"Story", "Low"}, Summary: "Dark mode"},
+		{Key: "ENG-3", Columns: []string{"Task", "Medium"}, Summary: "Update docs"},
+	}
+	return NewQueue("Review", rows, []Action{{Key: 'r', Label: "review", Run: func(key string) error {
+		if key == "ENG-2" {
+			return errors.New("no permission")
+		}
+		return nil
+	}}})
+}
+
+func TestHandleKey(t *testing.T) {
+	q := testQueue()
+	changes := 0
+	q.OnChange = func() { changes++ }
+
+	q.HandleKey(Key{Special: KeyDown}, 10)
+	if q.Current() != "ENG-2" {
+		t.Fatalf("Expected the cursor on ENG-2, got %s", q.Current())
+	}
+	q.HandleKey(Key{Special: KeyPageDown}, 10)
+	if q.Current() != "ENG-3" {
+		t.Errorf("Expected page down to stop at the last ticket, got %s", q.Current())
+	}
+	q.HandleKey(Key{Rune: 'g'}, 10)
+
+	q.HandleKey(Key{Rune: ' '}, 10)
+	if !q.Selected["ENG-1"] || q.Current() != "ENG-2" {
+		t.Errorf("Expected ENG-1 selected and the cursor moved on, got %v at %s", q.Selected, q.Current())
+	}
+	q.HandleKey(Key{Rune: 'm'}, 10)
+	if len(q.Targets()) != 3 || changes != 2 {
+		t.Errorf("Expected all tickets selected with 2 changes, got %v after %d", q.Targets(), changes)
+	}
+	q.HandleKey(Key{Rune: 'u'}, 10)
+	if targets := q.Targets(); len(targets) != 1 || targets[0] != "ENG-2" {
+		t.Errorf("Expected the current ticket as the only target, got %v", targets)
+	}
+
+	if _, action := q.HandleKey(Key{Rune: 'r'}, 10); action == nil || action.Label != "review" {
+		t.Errorf("Expected the review action, got %v", action)
+	}
+	if _, action := q.HandleKey(Key{Special: KeyEnter}, 10); action == nil || action.Label != "review" {
+		t.Errorf("Expected Enter to give the first action, got %v", action)
+	}
+	if quit, _ := q.HandleKey(Key{Rune: 'q'}, 10); !quit {
+		t.Error("Expected q to quit")
+	}
+}
+
+func TestRunAction(t *testing.T) {
+	q := testQueue()
+	q.Selected["ENG-1"] = true
+	q.Selected["ENG-2"] = true
+	q.Refresh = func(key string) (Row, error) {
+		return Row{Key: key, Columns: []string{"Bug", "Low"}, Summary: "Refreshed"}, nil
+	}
+
+	q.RunAction(&q.Actions[0])
+
+	if !q.Done["ENG-1"] || q.Selected["ENG-1"] {
+		t.Errorf("Expected ENG-1 done and deselected, got done=%v selected=%v", q.Done, q.Selected)
+	}
+	if q.Done["ENG-2"] || !q.Selected["ENG-2"] || q.marker("ENG-2") != markerFailed {
+		t.Errorf("Expected ENG-2 to stay selected and be marked failed")
+	}
+	if q.Rows[0].Summary != "Refreshed" {
+		t.Errorf("Expected ENG-1's row to be refreshed, got %q", q.Rows[0].Summary)
+	}
+	if q.message != "review: 1 done, 1 failed" {
+		t.Errorf("Unexpected message %q", q.message)
+	}
+}
+
+func TestRender(t *testing.T) {
+	q := testQueue()
+	loads := 0
+	q.Details = func(key string) (string, error) {
+		loads++
+		return key + " details\n\n" + strings.Repeat("word ", 40), nil
+	}
+	q.Selected["ENG-3"] = true
+
+	lines := q.Render(100, 12)
+	if len(lines) != 12 {
+		t.Fatalf("Expected 12 lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		plain := strings.NewReplacer("\x1b[7m", "", "\x1b[0m", "").Replace(line)
+		if n := utf8.RuneCountInString(plain); n != 100 {
+			t.Errorf("Line %d is %d columns wide: %q", i, n, plain)
+		}
+	}
+	if !strings.Contains(lines[0], "3 ticket(s), 1 selected") {
+		t.Errorf("Unexpected title %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "\x1b[7m") || !strings.Contains(lines[1], "ENG-1 details") {
+		t.Errorf("Expected the cursor row highlighted with its details beside it, got %q", lines[1])
+	}
+	if !strings.Contains(lines[3], markerSelected+" ENG-3") {
+		t.Errorf("Expected ENG-3 marked selected, got %q", lines[3])
+	}
+
+	q.Render(100, 12)
+	if loads != 1 {
+		t.Errorf("Expected the details to be loaded once, got %d loads", loads)
+	}
+
+	// Narrow terminals get the list only
+	if lines := q.Render(60, 12); strings.Contains(lines[1], "│") {
+		t.Errorf("Expected no detail pane at 60 columns, got %q", lines[1])
+	}
+}
+
+func TestWrap(t *testing.T) {
+	got := wrap("one two three four\n\nfive", 9)
+	want := []string{"one two", "three", "four", "", "five"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("wrap() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// screen puts the terminal into raw mode on the alternate screen while the queue is shown
+type screen struct {
+	in    int
+	out   io.Writer
+	state *term.State
+}
+
+func (s *screen) enter() error {
+	state, err := term.MakeRaw(s.in)
+	if err != nil {
+		return fmt.Errorf("failed to set up terminal: %w", err)
+	}
+	s.state = state
+	// Switch to the alternate screen and hide the cursor
+	fmt.Fprint(s.out, "\x1b[?1049h\x1b[?25l")
+	return nil
+}
+
+func (s *screen) exit() {
+	if s.state == nil {
+		return
+	}
+	fmt.Fprint(s.out, "\x1b[?25h\x1b[?1049l")
+	_ = term.Restore(s.in, s.state) // Ignore - nothing more can be done with the terminal
+	s.state = nil
+}
+
+func (s *screen) size() (width, height int) {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 80, 24
+	}
+	return width, height
+}
+
+func (s *screen) draw(lines []string) {
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+	for i, line := range lines {
+		b.WriteString(line)
+		b.WriteString("\x1b[K")
+		if i < len(lines)-1 {
+			b.WriteString("\r\n")
+		}
+	}
+	fmt.Fprint(s.out, b.String())
+}
+
+func (s *screen) readKey() (Key, error) {
+	buf := make([]byte, 16)
+	n, err := os.Stdin.Read(buf)
+	if err != nil {
+		return Key{}, fmt.Errorf("failed to read key: %w", err)
+	}
+	return ParseKey(buf[:n]), nil
+}
+
+// Run shows the queue full screen until the user quits
+// Actions run with the terminal restored, then the queue is shown again
+func Run(q *Queue) error {
+	in := int(os.Stdin.Fd())
+	if !term.IsTerminal(in) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return errors.New("the full-screen UI needs a terminal")
+	}
+
+	s := &screen{in: in, out: os.Stdout}
+	if err := s.enter(); err != nil {
+		return err
+	}
+	defer s.exit()
+
+	for {
+		width, height := s.size()
+		s.draw(q.Render(width, height))
+
+		key, err := s.readKey()
+		if err != nil {
+			return err
+		}
+		quit, action := q.HandleKey(key, height-3)
+		if quit {
+			return nil
+		}
+		if action == nil {
+			continue
+		}
+
+		s.exit()
+		q.RunAction(action)
+		fmt.Print("\nPress Enter to return to the list...")
+		_, _ = bufio.NewReader(os.Stdin).ReadString('\n') // Ignore - any input returns
+		if err := s.enter(); err != nil {
+			return err
+		}
+	}
+}